@@ -3,20 +3,30 @@ package main
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
+	"net"
 	"net/url"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
+	"time"
 
 	docs "backend/docs"
+	"backend/internal/adapter/http/middleware"
 	"backend/internal/adapter/http/routes"
+	"backend/internal/infrastructure/background"
 	"backend/internal/infrastructure/config"
 	"backend/internal/infrastructure/database"
 	"backend/internal/infrastructure/database/migrations"
+	"backend/internal/infrastructure/health"
+	"backend/internal/infrastructure/logger"
+	"backend/internal/infrastructure/server"
+	"backend/internal/infrastructure/tracing"
+	httputil "backend/internal/shared/http"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
-	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
 )
 
@@ -43,35 +53,50 @@ func main() {
 	// Load configuration from .env in current directory
 	cfg := config.Init()
 
-	// Initialize database
-	db, err := database.New(&cfg.DB)
+	// SIGINT/SIGTERM cancel ctx and start a graceful shutdown
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Structured logger (JSON in production, text in development)
+	appLogger := logger.New(cfg.Log.Level, cfg.Server.Env)
+	slog.SetDefault(appLogger)
+
+	// OpenTelemetry tracing (TRACING_EXPORTER=none|stdout|otlp)
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing, "1.0.0")
 	if err != nil {
-		log.Fatalf("Database connection failed: %v", err)
+		fatal("Tracing setup failed", err)
 	}
 	defer func() {
-		sqlDB, _ := db.DB().DB()
-		sqlDB.Close()
+		if err := shutdownTracing(context.Background()); err != nil {
+			slog.Error("tracing shutdown failed", "error", err)
+		}
 	}()
 
-	// Ping database to verify connection
-	sqlDB, err := db.DB().DB()
-	if err != nil {
-		log.Fatalf("Failed to get sql.DB: %v", err)
-	}
-	if err := sqlDB.Ping(); err != nil {
-		log.Fatalf("Database ping failed: %v", err)
-	}
-	log.Println("✓ Database connected successfully")
+	// Health probes: readiness depends on the database, schema and config
+	healthRegistry := health.NewRegistry(cfg.Health.CheckTimeout)
 
-	// Auto-seed database on startup
-	log.Println("Running database seeding...")
-	seeder := migrations.NewSeeder(db.DB(), cfg)
-	if err := seeder.SeedAll(context.Background()); err != nil {
-		log.Fatalf("Database seeding failed: %v", err)
+	// Initialize database (STORAGE=memory runs without one)
+	var dbRouter *database.Router
+	if cfg.Storage.Driver != config.StorageMemory {
+		db := connectDatabase(ctx, cfg, healthRegistry)
+		defer func() {
+			if err := db.Close(); err != nil {
+				slog.Error("database close failed", "error", err)
+			}
+			slog.Info("database pool closed")
+		}()
+		dbRouter = database.NewRouter(db.DB(), db.Replicas(), cfg.DB.ReadYourWritesWindow)
 	}
+	healthRegistry.Register("config", health.Readiness, health.ConfigValid(func() error { return config.Validate(cfg) }))
 
-	// Create Fiber app
-	app := fiber.New()
+	// Create Fiber app; handlers return errors and the error handler renders
+	// them from the registry the modules fill in routes.Setup
+	errorRegistry := httputil.NewErrorRegistry()
+	app := fiber.New(server.TrustProxies(
+		fiber.Config{ErrorHandler: httputil.ErrorHandler(errorRegistry)},
+		cfg.Server.TrustedProxies,
+		cfg.Server.ProxyHeader,
+	))
 
 	// Enable CORS for frontend origin(s) (configurable via CORS_ALLOW_ORIGINS)
 	corsConfig := cors.Config{
@@ -85,15 +110,26 @@ func main() {
 	app.Use(cors.New(corsConfig))
 
 	// Middleware
-	app.Use(logger.New())
+	app.Use(middleware.RequestID(appLogger))
+	app.Use(middleware.Tracing())
+	app.Use(middleware.RequestLogger())
 	app.Use(recover.New())
 
+	// Background workers share a context cancelled on shutdown; this defer runs
+	// before the DB close so no worker uses a closed pool
+	workers := background.NewGroup(context.Background())
+	defer func() {
+		if err := workers.Stop(workerStopTimeout); err != nil {
+			slog.Error("background workers stop failed", "error", err)
+		}
+	}()
+
 	// Setup routes
-	routes.Setup(app, db.DB(), cfg)
+	routes.Setup(app, dbRouter, cfg, healthRegistry, workers, errorRegistry)
 
 	// Start server
 	addr := fmt.Sprintf(":%d", cfg.Server.Port)
-	log.Printf("Starting Spin Head API v1.0.0 on %s\n", addr)
+	slog.Info("starting Spin Head API", "version", "1.0.0", "addr", addr)
 
 	// Show Swagger UI URL. Prefer explicit external URL via SWAGGER_EXTERNAL_URL env var when running behind NAT/proxy/docker
 	swaggerExternal := os.Getenv("SWAGGER_EXTERNAL_URL")
@@ -106,9 +142,9 @@ func main() {
 			if u.Scheme != "" {
 				docs.SwaggerInfo.Schemes = []string{u.Scheme}
 			}
-			log.Printf("Swagger UI: %s/swagger/", swaggerExternal)
+			slog.Info("swagger UI available", "url", swaggerExternal+"/swagger/")
 		} else {
-			log.Printf("Swagger UI: %s/swagger/ (invalid SWAGGER_EXTERNAL_URL)", swaggerExternal)
+			slog.Warn("invalid SWAGGER_EXTERNAL_URL", "url", swaggerExternal+"/swagger/")
 		}
 	} else {
 		// Fallback to internal URL (container-local)
@@ -116,10 +152,62 @@ func main() {
 		// (this helps "Try it" work when accessed via different hostnames / IPs)
 		docs.SwaggerInfo.Host = ""
 		docs.SwaggerInfo.Schemes = []string{}
-		log.Printf("Swagger UI (internal): http://localhost:%d/swagger/ (may be mapped to different host port)", cfg.Server.Port)
+		slog.Info("swagger UI available (internal, may be mapped to a different host port)", "url", fmt.Sprintf("http://localhost:%d/swagger/", cfg.Server.Port))
+	}
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		fatal("Server failed", err)
+	}
+	shutdownCfg := server.ShutdownConfig{
+		DrainDelay: cfg.Server.ShutdownDrainDelay,
+		Timeout:    cfg.Server.ShutdownTimeout,
 	}
+	if err := server.Serve(ctx, app, ln, healthRegistry, shutdownCfg); err != nil {
+		slog.Error("server stopped with error", "error", err)
+	}
+}
 
-	if err := app.Listen(addr); err != nil {
-		log.Fatalf("Server failed: %v", err)
+// connectDatabase connects, pings and seeds the database and registers its
+// readiness checks; it exits on failure
+func connectDatabase(ctx context.Context, cfg *config.Config, healthRegistry *health.Registry) *database.Database {
+	db, err := database.New(ctx, &cfg.DB)
+	if err != nil {
+		fatal("Database connection failed", err)
 	}
+
+	// Ping database to verify connection
+	sqlDB, err := db.DB().DB()
+	if err != nil {
+		fatal("Failed to get sql.DB", err)
+	}
+	if err := sqlDB.Ping(); err != nil {
+		fatal("Database ping failed", err)
+	}
+	slog.Info("database connected")
+
+	// Auto-seed database on startup
+	slog.Info("running database seeding")
+	seeder := migrations.NewSeeder(db.DB(), cfg)
+	if err := seeder.SeedAll(context.Background()); err != nil {
+		fatal("Database seeding failed", err)
+	}
+
+	healthRegistry.Register("database", health.Readiness, health.DatabasePing(sqlDB))
+	if migrator, err := migrations.NewMigrator(db.DB()); err != nil {
+		slog.Warn("migration check unavailable", "error", err)
+		healthRegistry.Register("migrations", health.Readiness, health.CheckerFunc(func(ctx context.Context) error { return err }))
+	} else {
+		healthRegistry.Register("migrations", health.Readiness, health.MigrationsClean(migrator))
+	}
+	return db
+}
+
+// workerStopTimeout bounds how long background workers may take to exit
+const workerStopTimeout = 5 * time.Second
+
+// fatal logs the error and exits
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
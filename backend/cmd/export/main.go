@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+
+	"backend/internal/infrastructure/config"
+	"backend/internal/infrastructure/database"
+	"backend/internal/infrastructure/logger"
+	historyrepo "backend/internal/modules/history/adapter/repository"
+	historyapp "backend/internal/modules/history/application"
+	"backend/internal/modules/history/application/export_spins"
+	rewardrepo "backend/internal/modules/reward/adapter/repository"
+	rewardapp "backend/internal/modules/reward/application"
+	"backend/internal/modules/reward/application/export_rewards"
+	"backend/internal/shared/cursor"
+	"backend/internal/shared/export"
+)
+
+// options are the flags shared by both subcommands
+type options struct {
+	out            string
+	format         string
+	columns        string
+	tz             string
+	from           string
+	to             string
+	playerID       string
+	nicknamePrefix string
+}
+
+func (o *options) register(flags *flag.FlagSet) {
+	flags.StringVar(&o.out, "out", "", "output file (default <name>.<format>, - for stdout)")
+	flags.StringVar(&o.format, "format", "csv", "csv or ndjson")
+	flags.StringVar(&o.columns, "columns", "", "comma-separated columns (default from export.yaml)")
+	flags.StringVar(&o.tz, "tz", "", "IANA timezone for timestamps (default from export.yaml)")
+	flags.StringVar(&o.from, "from", "", "only rows at or after this RFC3339 timestamp")
+	flags.StringVar(&o.to, "to", "", "only rows before this RFC3339 timestamp")
+	flags.StringVar(&o.playerID, "player-id", "", "only this player's rows")
+	flags.StringVar(&o.nicknamePrefix, "nickname-prefix", "", "only players whose nickname starts with this prefix")
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(1)
+	}
+	command := os.Args[1]
+
+	var opts options
+	flags := flag.NewFlagSet("export "+command, flag.ExitOnError)
+	opts.register(flags)
+
+	var source, minPoints, maxPoints, checkpoint string
+	switch command {
+	case "spins":
+		flags.StringVar(&source, "source", "", "GAME, BONUS or ADMIN")
+		flags.StringVar(&minPoints, "min-points", "", "minimum points gained")
+		flags.StringVar(&maxPoints, "max-points", "", "maximum points gained")
+	case "rewards":
+		flags.StringVar(&checkpoint, "checkpoint", "", "only claims of this checkpoint")
+	default:
+		printUsage()
+		os.Exit(1)
+	}
+	flags.Parse(os.Args[2:])
+
+	// Initialize configuration
+	cfg := config.Init()
+	slog.SetDefault(logger.New(cfg.Log.Level, cfg.Server.Env))
+
+	// Each FETCH is a statement, but a large export should not be cut by the API timeout
+	cfg.DB.StatementTimeout = 0
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Initialize database connection
+	db, err := database.New(ctx, &cfg.DB)
+	if err != nil {
+		log.Fatalf("[Export] Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	// Exports prefer a replica when one is configured
+	reads := database.NewRouter(db.DB(), db.Replicas(), 0)
+	codec := cursor.NewCodec(cfg.Pagination.CursorSecret)
+
+	var job *export.Job
+	switch command {
+	case "spins":
+		defaults := export.DefaultOptions(cfg.Export.Timezone, cfg.Export.BatchSize, cfg.Export.SpinColumns)
+		uc := export_spins.New(historyrepo.NewSpinLogRepositoryGorm(db.DB(), reads, codec), defaults)
+		job, err = uc.Prepare(historyapp.ExportRequest{
+			HistoryFilterRequest: historyapp.HistoryFilterRequest{
+				From:      opts.from,
+				To:        opts.to,
+				Source:    source,
+				MinPoints: parseOptionalInt("min-points", minPoints),
+				MaxPoints: parseOptionalInt("max-points", maxPoints),
+			},
+			NicknamePrefix: opts.nicknamePrefix,
+			PlayerID:       opts.playerID,
+			Format:         opts.format,
+			Columns:        opts.columns,
+			Timezone:       opts.tz,
+		})
+
+	case "rewards":
+		defaults := export.DefaultOptions(cfg.Export.Timezone, cfg.Export.BatchSize, cfg.Export.RewardColumns)
+		uc := export_rewards.New(rewardrepo.NewRewardTransactionRepositoryGorm(db.DB(), reads, codec), defaults)
+		job, err = uc.Prepare(rewardapp.ExportRequest{
+			PlayerID:       opts.playerID,
+			From:           opts.from,
+			To:             opts.to,
+			CheckpointVal:  parseOptionalInt("checkpoint", checkpoint),
+			NicknamePrefix: opts.nicknamePrefix,
+			Format:         opts.format,
+			Columns:        opts.columns,
+			Timezone:       opts.tz,
+		})
+	}
+	if err != nil {
+		log.Fatalf("[Export] Invalid export: %v", err)
+	}
+
+	out := opts.out
+	if out == "" {
+		out = job.Filename
+	}
+
+	rows, err := writeJob(ctx, job, out)
+	if err != nil {
+		log.Fatalf("[Export] Export failed after %d rows: %v", rows, err)
+	}
+	log.Printf("[Export] ✓ Wrote %d rows to %s", rows, out)
+}
+
+// writeJob runs job into path. Files are written under a temporary name and
+// renamed when complete, so a failed export never leaves a truncated file.
+func writeJob(ctx context.Context, job *export.Job, path string) (int, error) {
+	if path == "-" {
+		return job.Run(ctx, os.Stdout)
+	}
+
+	partial := path + ".partial"
+	f, err := os.Create(partial)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := job.Run(ctx, f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(partial)
+		return rows, err
+	}
+	return rows, os.Rename(partial, path)
+}
+
+func parseOptionalInt(name, value string) *int {
+	if value == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		log.Fatalf("[Export] --%s must be an integer", name)
+	}
+	return &n
+}
+
+func printUsage() {
+	fmt.Println("SpinHead Export Tool")
+	fmt.Println()
+	fmt.Println("Usage:")
+	fmt.Println("  export spins [flags]   - Export spin history")
+	fmt.Println("  export rewards [flags] - Export reward claims")
+	fmt.Println()
+	fmt.Println("Flags:")
+	fmt.Println("  --out <file>              - Output file (default spin_history.csv / reward_history.csv, - for stdout)")
+	fmt.Println("  --format csv|ndjson       - Output format (default csv)")
+	fmt.Println("  --columns a,b,c           - Columns in output order (default from configs/export.yaml)")
+	fmt.Println("  --tz <zone>               - Timezone for timestamps, e.g. Asia/Bangkok")
+	fmt.Println("  --from, --to <RFC3339>    - Time range (from inclusive, to exclusive)")
+	fmt.Println("  --player-id <id>          - Only one player")
+	fmt.Println("  --nickname-prefix <text>  - Only players whose nickname starts with text")
+	fmt.Println("  --source, --min-points, --max-points - Spin filters (spins only)")
+	fmt.Println("  --checkpoint <value>      - Checkpoint filter (rewards only)")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  go run ./cmd/export spins --from 2025-06-01T00:00:00Z --to 2025-07-01T00:00:00Z")
+	fmt.Println("  go run ./cmd/export rewards --format ndjson --tz Asia/Bangkok --out rewards-june.ndjson")
+}
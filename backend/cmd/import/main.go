@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+
+	"backend/internal/infrastructure/config"
+	"backend/internal/infrastructure/database"
+	"backend/internal/infrastructure/legacyimport"
+	"backend/internal/infrastructure/logger"
+	playerdomain "backend/internal/modules/player/domain"
+)
+
+func main() {
+	file := flag.String("file", "../mock_data_copy.csv", "legacy CSV export (nickname,point,datetime)")
+	batchSize := flag.Int("batch-size", 500, "spin logs per INSERT")
+	dryRun := flag.Bool("dry-run", false, "validate and report without writing")
+	errorsFile := flag.String("errors", "import_errors.csv", "where rejected rows are written")
+	flag.Parse()
+
+	// Initialize configuration
+	cfg := config.Init()
+	slog.SetDefault(logger.New(cfg.Log.Level, cfg.Server.Env))
+
+	// Imports may run long batches, so the API statement timeout does not apply
+	cfg.DB.StatementTimeout = 0
+
+	f, err := os.Open(*file)
+	if err != nil {
+		log.Fatalf("[Import] Failed to open %s: %v", *file, err)
+	}
+	defer f.Close()
+
+	// Nicknames follow the same rules as players created through the API
+	factory := playerdomain.NewPlayerFactory(cfg.Validation.Nickname.MinLength, cfg.Validation.Nickname.MaxLength)
+
+	rows, rejected, err := legacyimport.Parse(f, factory)
+	if err != nil {
+		log.Fatalf("[Import] Failed to parse %s: %v", *file, err)
+	}
+
+	if len(rejected) > 0 {
+		out, err := os.Create(*errorsFile)
+		if err != nil {
+			log.Fatalf("[Import] Failed to create error report: %v", err)
+		}
+		if err := legacyimport.WriteErrors(out, rejected); err != nil {
+			log.Fatalf("[Import] Failed to write error report: %v", err)
+		}
+		out.Close()
+		log.Printf("[Import] %d rows rejected, see %s", len(rejected), *errorsFile)
+	}
+
+	// Initialize database connection
+	db, err := database.New(context.Background(), &cfg.DB)
+	if err != nil {
+		log.Fatalf("[Import] Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	importer := legacyimport.New(db.DB(), factory, *batchSize)
+	report, err := importer.Run(context.Background(), rows, *dryRun)
+	if err != nil {
+		log.Fatalf("[Import] Import failed: %v", err)
+	}
+	report.Rejected = len(rejected)
+
+	title := "Import completed"
+	if report.DryRun {
+		title = "Dry run (nothing written)"
+	}
+	fmt.Println(title)
+	fmt.Printf("  valid rows:       %d\n", report.Rows)
+	fmt.Printf("  rejected rows:    %d\n", report.Rejected)
+	fmt.Printf("  players created:  %d\n", report.PlayersCreated)
+	fmt.Printf("  spins inserted:   %d\n", report.SpinsInserted)
+	fmt.Printf("  duplicates:       %d\n", report.Duplicates)
+}
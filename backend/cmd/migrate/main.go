@@ -1,29 +1,63 @@
 package main
 
 import (
+	"bufio"
 	"context"
+	"flag"
 	"fmt"
 	"log"
+	"log/slog"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"backend/internal/infrastructure/config"
 	"backend/internal/infrastructure/database"
 	"backend/internal/infrastructure/database/migrations"
+	"backend/internal/infrastructure/logger"
 )
 
 func main() {
-	if len(os.Args) < 2 {
+	// Flags may appear before or after the command (e.g. "up --dry-run")
+	flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	dryRun := flags.Bool("dry-run", false, "print the SQL that up, down or goto would run without executing it")
+	confirm := flags.String("confirm", "", "database name, confirms drop/reset when SERVER_ENV=production")
+	dir := flags.String("dir", "migrations", "directory where create writes new migration files")
+	flags.Usage = printUsage
+
+	args := parseArgs(flags, os.Args[1:])
+	if len(args) < 1 {
 		printUsage()
 		os.Exit(1)
 	}
 
-	command := os.Args[1]
+	command := args[0]
+
+	// create only writes files and needs neither config nor a database
+	if command == "create" {
+		if len(args) < 2 {
+			log.Fatalf("[Migrate] Create requires a name argument")
+		}
+		paths, err := migrations.Create(*dir, args[1], time.Now())
+		if err != nil {
+			log.Fatalf("[Migrate] Create failed: %v", err)
+		}
+		for _, path := range paths {
+			log.Printf("[Migrate] ✓ Created %s", path)
+		}
+		return
+	}
 
 	// Initialize configuration
 	cfg := config.Init()
+	slog.SetDefault(logger.New(cfg.Log.Level, cfg.Server.Env))
+
+	// Migrations may run long DDL, so the API statement timeout does not apply
+	cfg.DB.StatementTimeout = 0
 
 	// Initialize database connection
-	db, err := database.New(&cfg.DB)
+	db, err := database.New(context.Background(), &cfg.DB)
 	if err != nil {
 		log.Fatalf("[Migrate] Failed to connect to database: %v", err)
 	}
@@ -33,7 +67,7 @@ func main() {
 	}()
 
 	// Create migrator
-	migrator, err := migrations.NewMigrator(db.DB(), cfg)
+	migrator, err := migrations.NewMigrator(db.DB())
 	if err != nil {
 		log.Fatalf("[Migrate] Failed to create migrator: %v", err)
 	}
@@ -41,34 +75,61 @@ func main() {
 	// Execute command
 	switch command {
 	case "up":
+		if *dryRun {
+			printPlan(migrator.PlanUp())
+			return
+		}
 		if err := migrator.Up(); err != nil {
 			log.Fatalf("[Migrate] Up failed: %v", err)
 		}
 		log.Println("[Migrate] ✓ Migrations up completed successfully")
 
 	case "down":
+		if *dryRun {
+			printPlan(migrator.PlanDown())
+			return
+		}
 		if err := migrator.Down(); err != nil {
 			log.Fatalf("[Migrate] Down failed: %v", err)
 		}
 		log.Println("[Migrate] ✓ Migration down completed successfully")
 
+	case "goto":
+		if len(args) < 2 {
+			log.Fatalf("[Migrate] Goto requires a version argument")
+		}
+		version, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			log.Fatalf("[Migrate] Invalid version %q: %v", args[1], err)
+		}
+		if *dryRun {
+			printPlan(migrator.PlanGoto(uint(version)))
+			return
+		}
+		if err := migrator.Goto(uint(version)); err != nil {
+			log.Fatalf("[Migrate] Goto failed: %v", err)
+		}
+		log.Printf("[Migrate] ✓ Migrated to version %d", version)
+
 	case "reset":
+		confirmProduction(cfg, *confirm, "reset")
 		if err := migrator.Reset(); err != nil {
 			log.Fatalf("[Migrate] Reset failed: %v", err)
 		}
 		log.Println("[Migrate] ✓ Database reset completed successfully")
 
 	case "force":
-		if len(os.Args) < 3 {
+		if len(args) < 2 {
 			log.Fatalf("[Migrate] Force requires a version argument")
 		}
-		version := os.Args[2]
+		version := args[1]
 		if err := migrator.Force(version); err != nil {
 			log.Fatalf("[Migrate] Force failed: %v", err)
 		}
 		log.Printf("[Migrate] ✓ Forced migration version to %s", version)
 
 	case "drop":
+		confirmProduction(cfg, *confirm, "drop")
 		if err := migrator.Drop(); err != nil {
 			log.Fatalf("[Migrate] Drop failed: %v", err)
 		}
@@ -92,6 +153,27 @@ func main() {
 		}
 		fmt.Printf("Current migration version: %d (%s)\n", version, status)
 
+	case "status":
+		current, dirty, list, err := migrator.Status()
+		if err != nil {
+			log.Fatalf("[Migrate] Status failed: %v", err)
+		}
+		state := "clean"
+		if dirty {
+			state = "dirty"
+		}
+		fmt.Printf("Current migration version: %d (%s)\n\n", current, state)
+		pending := 0
+		for _, m := range list {
+			mark := "applied"
+			if !m.Applied {
+				mark = "pending"
+				pending++
+			}
+			fmt.Printf("  [%s] %d_%s\n", mark, m.Version, m.Name)
+		}
+		fmt.Printf("\n%d applied, %d pending\n", len(list)-pending, pending)
+
 	default:
 		fmt.Printf("Unknown command: %s\n\n", command)
 		printUsage()
@@ -99,20 +181,89 @@ func main() {
 	}
 }
 
+// parseArgs parses flags placed before and after the command and returns the positional arguments
+func parseArgs(flags *flag.FlagSet, args []string) []string {
+	flags.Parse(args)
+	positional := flags.Args()
+	if len(positional) == 0 {
+		return nil
+	}
+
+	rest := positional[1:]
+	var extra []string
+	for len(rest) > 0 {
+		flags.Parse(rest)
+		rest = flags.Args()
+		if len(rest) > 0 {
+			extra = append(extra, rest[0])
+			rest = rest[1:]
+		}
+	}
+	return append([]string{positional[0]}, extra...)
+}
+
+// confirmProduction requires the database name before destructive commands in production
+func confirmProduction(cfg *config.Config, confirmed, command string) {
+	if cfg.Server.Env != "production" {
+		return
+	}
+	if confirmed == cfg.DB.Database {
+		return
+	}
+	if confirmed != "" {
+		log.Fatalf("[Migrate] --confirm=%q does not match database %q, refusing to %s", confirmed, cfg.DB.Database, command)
+	}
+
+	fmt.Printf("SERVER_ENV=production: %s will destroy data in database %q on %s.\n", command, cfg.DB.Database, cfg.DB.Host)
+	fmt.Print("Type the database name to continue: ")
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	if strings.TrimSpace(answer) != cfg.DB.Database {
+		log.Fatalf("[Migrate] Confirmation failed, refusing to %s", command)
+	}
+}
+
+// printPlan prints the SQL of each planned step (used by --dry-run)
+func printPlan(steps []migrations.Step, err error) {
+	if err != nil {
+		log.Fatalf("[Migrate] Dry run failed: %v", err)
+	}
+	if len(steps) == 0 {
+		fmt.Println("-- dry run: no migrations to run")
+		return
+	}
+	for _, step := range steps {
+		fmt.Printf("-- dry run: %s %d_%s\n", step.Direction, step.Version, step.Name)
+		fmt.Println(strings.TrimSpace(step.SQL))
+		fmt.Println()
+	}
+}
+
 func printUsage() {
 	fmt.Println("SpinHead Database Migration Tool")
 	fmt.Println()
 	fmt.Println("Usage:")
 	fmt.Println("  migrate up      - Run all pending migrations")
 	fmt.Println("  migrate down    - Rollback last migration")
+	fmt.Println("  migrate goto <version> - Migrate up or down to a version")
 	fmt.Println("  migrate reset   - Drop all tables and run all migrations")
 	fmt.Println("  migrate force <version> - Force migration version")
 	fmt.Println("  migrate drop    - Drop all tables and migration tracking")
 	fmt.Println("  migrate seed    - Seed database with initial data")
 	fmt.Println("  migrate version - Show current migration version")
+	fmt.Println("  migrate status  - List applied and pending migrations")
+	fmt.Println("  migrate create <name> - Create timestamped up/down pairs (Postgres and SQLite)")
+	fmt.Println()
+	fmt.Println("Flags:")
+	fmt.Println("  --dry-run        - Print the SQL for up, down or goto without running it")
+	fmt.Println("  --confirm <db>   - Confirm drop/reset when SERVER_ENV=production")
+	fmt.Println("  --dir <path>     - Directory for create (default: migrations)")
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  go run cmd/migrate/main.go up")
+	fmt.Println("  go run cmd/migrate/main.go up --dry-run")
+	fmt.Println("  go run cmd/migrate/main.go goto 4")
+	fmt.Println("  go run cmd/migrate/main.go status")
+	fmt.Println("  go run cmd/migrate/main.go create add_player_streaks")
 	fmt.Println("  go run cmd/migrate/main.go reset")
 	fmt.Println("  go run cmd/migrate/main.go force 4")
 	fmt.Println("  go run cmd/migrate/main.go drop")
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"crypto/subtle"
+
+	"backend/internal/shared/constants"
+	httputil "backend/internal/shared/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// HeaderAdminKey carries the admin API key
+const HeaderAdminKey = "X-Admin-Key"
+
+// AdminAuth rejects requests whose X-Admin-Key does not match apiKey
+func AdminAuth(apiKey string) fiber.Handler {
+	expected := []byte(apiKey)
+	return func(c *fiber.Ctx) error {
+		if subtle.ConstantTimeCompare([]byte(c.Get(HeaderAdminKey)), expected) != 1 {
+			return httputil.Unauthorized(c, constants.ErrCodeUnauthorized, "Missing or invalid admin key")
+		}
+		return c.Next()
+	}
+}
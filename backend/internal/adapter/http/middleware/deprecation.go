@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Deprecation response headers
+const (
+	HeaderDeprecation = "Deprecation"
+	HeaderSunset      = "Sunset"
+)
+
+// DeprecationConfig describes a deprecated version of the API
+type DeprecationConfig struct {
+	DeprecatedAt time.Time
+	// Sunset is when the version stops being served (zero when not planned)
+	Sunset time.Time
+	// Successor maps a request URL to the same resource in the version that
+	// replaces this one (nil sends no Link)
+	Successor func(url string) string
+}
+
+// Deprecation marks every response with Deprecation (RFC 9745), Sunset
+// (RFC 8594) and a successor-version Link so clients can find out they
+// should move before the version is removed
+func Deprecation(cfg DeprecationConfig) fiber.Handler {
+	deprecation := "@" + strconv.FormatInt(cfg.DeprecatedAt.Unix(), 10)
+	sunset := ""
+	if !cfg.Sunset.IsZero() {
+		sunset = cfg.Sunset.UTC().Format(http.TimeFormat)
+	}
+
+	return func(c *fiber.Ctx) error {
+		c.Set(HeaderDeprecation, deprecation)
+		if sunset != "" {
+			c.Set(HeaderSunset, sunset)
+		}
+		if cfg.Successor != nil {
+			c.Append(fiber.HeaderLink, fmt.Sprintf(`<%s>; rel="successor-version"`, cfg.Successor(c.OriginalURL())))
+		}
+		return c.Next()
+	}
+}
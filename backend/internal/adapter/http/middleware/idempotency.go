@@ -0,0 +1,137 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"backend/internal/infrastructure/idempotency"
+	"backend/internal/shared/constants"
+	httputil "backend/internal/shared/http"
+	"backend/internal/shared/logging"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// HeaderIdempotencyKey is the request header carrying the client key
+	HeaderIdempotencyKey = "Idempotency-Key"
+
+	// HeaderIdempotentReplayed marks a response served from the store
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+// IdempotencyConfig configures the idempotency middleware
+type IdempotencyConfig struct {
+	Store idempotency.Store
+
+	// TTL is how long a completed response is replayed
+	TTL time.Duration
+
+	// LockTTL is how long a key stays locked while the first request runs
+	LockTTL time.Duration
+}
+
+// Idempotency replays the first response for a repeated Idempotency-Key.
+// Keys are scoped per player (player_id in the JSON body), so two players
+// may use the same key independently.
+func Idempotency(cfg IdempotencyConfig) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if c.Method() != fiber.MethodPost {
+			return c.Next()
+		}
+
+		key := c.Get(HeaderIdempotencyKey)
+		if key == "" {
+			return c.Next()
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			return httputil.BadRequest(c, constants.ErrCodeInvalidIdempotencyKey, "Idempotency-Key must be at most 255 characters")
+		}
+
+		scope := truncate(playerIDFromBody(c.Body()), 64)
+		requestHash := hashRequest(c.Method(), unversionedPath(c.Path()), c.Body())
+
+		existing, acquired, err := cfg.Store.Acquire(c.UserContext(), key, scope, requestHash, cfg.LockTTL)
+		if err != nil {
+			if errors.Is(err, idempotency.ErrRequestInProgress) {
+				return httputil.Conflict(c, constants.ErrCodeIdempotencyKeyInUse, "A request with this Idempotency-Key is already in progress")
+			}
+			return httputil.InternalError(c, "Failed to check Idempotency-Key")
+		}
+
+		if !acquired {
+			if existing.RequestHash != requestHash {
+				return httputil.Conflict(c, constants.ErrCodeIdempotencyKeyReused, "Idempotency-Key was already used with a different request")
+			}
+			if !existing.IsCompleted() {
+				return httputil.Conflict(c, constants.ErrCodeIdempotencyKeyInUse, "A request with this Idempotency-Key is already in progress")
+			}
+			return replay(c, existing)
+		}
+
+		// Run the handler while holding the key; returned errors are rendered
+		// here so client errors are replayed like any other response
+		httputil.RenderError(c, c.Next())
+
+		status := c.Response().StatusCode()
+		if status >= fiber.StatusInternalServerError {
+			// Server errors are not cached so the client can retry
+			releaseKey(c.UserContext(), cfg.Store, key, scope)
+			return nil
+		}
+
+		resp := idempotency.Response{
+			StatusCode:  status,
+			ContentType: string(c.Response().Header.ContentType()),
+			Body:        append([]byte(nil), c.Response().Body()...),
+		}
+		if err := cfg.Store.Complete(context.Background(), key, scope, resp, cfg.TTL); err != nil {
+			logging.FromContext(c.UserContext()).Error("failed to save idempotent response", "idempotency_key", key, "error", err)
+			releaseKey(c.UserContext(), cfg.Store, key, scope)
+		}
+
+		return nil
+	}
+}
+
+// replay writes a stored response byte for byte
+func replay(c *fiber.Ctx, record *idempotency.Record) error {
+	if record.ContentType != "" {
+		c.Set(fiber.HeaderContentType, record.ContentType)
+	}
+	c.Set(HeaderIdempotentReplayed, "true")
+	return c.Status(record.StatusCode).Send(record.Body)
+}
+
+func releaseKey(ctx context.Context, store idempotency.Store, key, scope string) {
+	if err := store.Release(context.Background(), key, scope); err != nil {
+		logging.FromContext(ctx).Error("failed to release idempotency key", "idempotency_key", key, "error", err)
+	}
+}
+
+// unversionedPath strips the API version (e.g. /v1), so a key retried on the
+// legacy alias of the same route matches
+func unversionedPath(path string) string {
+	segment, rest, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	version, ok := strings.CutPrefix(segment, "v")
+	if !ok || version == "" || strings.Trim(version, "0123456789") != "" {
+		return path
+	}
+	return "/" + rest
+}
+
+func hashRequest(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{0})
+	h.Write([]byte(path))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
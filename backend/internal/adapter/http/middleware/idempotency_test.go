@@ -0,0 +1,154 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"backend/internal/infrastructure/idempotency"
+	"backend/internal/shared/constants"
+	httputil "backend/internal/shared/http"
+)
+
+// idempotencyApp serves POST /spin and its /v1 alias behind the middleware;
+// handler decides each response and calls counts the handler runs
+func idempotencyApp(handler fiber.Handler) (*fiber.App, *atomic.Int32) {
+	calls := new(atomic.Int32)
+	app := fiber.New(fiber.Config{ErrorHandler: httputil.ErrorHandler(httputil.NewErrorRegistry())})
+	app.Use(Idempotency(IdempotencyConfig{
+		Store:   idempotency.NewMemoryStore(),
+		TTL:     time.Hour,
+		LockTTL: time.Minute,
+	}))
+	counted := func(c *fiber.Ctx) error {
+		calls.Add(1)
+		return handler(c)
+	}
+	app.Post("/spin", counted)
+	app.Post("/v1/spin", counted)
+	return app, calls
+}
+
+type idempotentResult struct {
+	status   int
+	body     string
+	replayed bool
+}
+
+func postIdempotent(t *testing.T, app *fiber.App, path, key, body string) idempotentResult {
+	t.Helper()
+	req := httptest.NewRequest(fiber.MethodPost, path, strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	req.Header.Set(HeaderIdempotencyKey, key)
+	res, err := app.Test(req, -1)
+	if err != nil {
+		// Error, not Fatal: requests also run in other goroutines
+		t.Error(err)
+		return idempotentResult{}
+	}
+	raw, _ := io.ReadAll(res.Body)
+	return idempotentResult{status: res.StatusCode, body: string(raw), replayed: res.Header.Get(HeaderIdempotentReplayed) == "true"}
+}
+
+func errorCode(t *testing.T, body string) string {
+	t.Helper()
+	var resp httputil.Response
+	if err := json.Unmarshal([]byte(body), &resp); err != nil || resp.Error == nil {
+		t.Fatalf("body %s is not an error envelope", body)
+	}
+	return resp.Error.Code
+}
+
+func TestIdempotencyReplaysFirstResponse(t *testing.T) {
+	spins := 0
+	app, calls := idempotencyApp(func(c *fiber.Ctx) error {
+		spins++
+		return c.JSON(fiber.Map{"spin": spins})
+	})
+	const body = `{"player_id":"p1"}`
+
+	first := postIdempotent(t, app, "/spin", "key-1", body)
+	// The /v1 path of the same route replays too
+	for _, path := range []string{"/spin", "/v1/spin"} {
+		again := postIdempotent(t, app, path, "key-1", body)
+		if again.status != first.status || again.body != first.body || !again.replayed {
+			t.Fatalf("%s: got %+v, want a replay of %+v", path, again, first)
+		}
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("handler ran %d times, want 1", calls.Load())
+	}
+
+	// Keys are scoped per player
+	if other := postIdempotent(t, app, "/spin", "key-1", `{"player_id":"p2"}`); other.replayed {
+		t.Fatal("another player's request was replayed")
+	}
+}
+
+func TestIdempotencyRejectsKeyReusedWithDifferentBody(t *testing.T) {
+	app, calls := idempotencyApp(func(c *fiber.Ctx) error { return c.JSON(fiber.Map{"ok": true}) })
+
+	postIdempotent(t, app, "/spin", "key-1", `{"player_id":"p1","n":1}`)
+	res := postIdempotent(t, app, "/spin", "key-1", `{"player_id":"p1","n":2}`)
+	if res.status != fiber.StatusConflict || errorCode(t, res.body) != constants.ErrCodeIdempotencyKeyReused {
+		t.Fatalf("got %+v, want 409 %s", res, constants.ErrCodeIdempotencyKeyReused)
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("handler ran %d times, want 1", calls.Load())
+	}
+}
+
+func TestIdempotencyRejectsRequestWhileFirstIsInFlight(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	app, calls := idempotencyApp(func(c *fiber.Ctx) error {
+		close(started)
+		<-release
+		return c.JSON(fiber.Map{"ok": true})
+	})
+	const body = `{"player_id":"p1"}`
+
+	done := make(chan idempotentResult)
+	go func() { done <- postIdempotent(t, app, "/spin", "key-1", body) }()
+	<-started
+
+	res := postIdempotent(t, app, "/spin", "key-1", body)
+	close(release)
+	if res.status != fiber.StatusConflict || errorCode(t, res.body) != constants.ErrCodeIdempotencyKeyInUse {
+		t.Fatalf("got %+v, want 409 %s", res, constants.ErrCodeIdempotencyKeyInUse)
+	}
+	if first := <-done; first.status != fiber.StatusOK {
+		t.Fatalf("first request got %+v, want 200", first)
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("handler ran %d times, want 1", calls.Load())
+	}
+}
+
+func TestIdempotencyDoesNotCacheServerErrors(t *testing.T) {
+	fail := true
+	app, calls := idempotencyApp(func(c *fiber.Ctx) error {
+		if fail {
+			return fiber.ErrServiceUnavailable
+		}
+		return c.JSON(fiber.Map{"ok": true})
+	})
+	const body = `{"player_id":"p1"}`
+
+	if res := postIdempotent(t, app, "/spin", "key-1", body); res.status != fiber.StatusServiceUnavailable {
+		t.Fatalf("got %+v, want 503", res)
+	}
+	fail = false
+	if res := postIdempotent(t, app, "/spin", "key-1", body); res.status != fiber.StatusOK || res.replayed {
+		t.Fatalf("retry got %+v, want a fresh 200", res)
+	}
+	if calls.Load() != 2 {
+		t.Fatalf("handler ran %d times, want 2", calls.Load())
+	}
+}
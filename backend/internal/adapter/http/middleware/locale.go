@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"backend/internal/shared/i18n"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Locale negotiates the response language from Accept-Language and stores it
+// in the user context. Use cases may switch to the player's preferred locale;
+// Content-Language reports the one finally used.
+func Locale(bundle *i18n.Bundle) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := i18n.WithLocale(c.UserContext(), bundle, bundle.Negotiate(c.Get(fiber.HeaderAcceptLanguage)))
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+		c.Vary(fiber.HeaderAcceptLanguage)
+		c.Set(fiber.HeaderContentLanguage, i18n.Locale(ctx))
+		return err
+	}
+}
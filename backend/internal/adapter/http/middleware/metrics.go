@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"backend/internal/infrastructure/metrics"
+	httputil "backend/internal/shared/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Metrics records request count and latency per route template and status.
+// The route template (e.g. /players/:id) keeps label cardinality bounded.
+func Metrics(m *metrics.Metrics) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		// Let the error handler write the response so the status is final
+		httputil.RenderError(c, c.Next())
+		status := c.Response().StatusCode()
+
+		route := c.Route().Path
+		if status == fiber.StatusNotFound && route == "/" {
+			route = "unmatched"
+		}
+
+		labels := []string{c.Method(), route, strconv.Itoa(status)}
+		m.HTTPRequests.WithLabelValues(labels...).Inc()
+		m.HTTPRequestDuration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+
+		return nil
+	}
+}
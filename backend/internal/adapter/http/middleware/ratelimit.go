@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"backend/internal/infrastructure/ratelimit"
+	"backend/internal/shared/constants"
+	httputil "backend/internal/shared/http"
+	"backend/internal/shared/logging"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Rate limit key types
+const (
+	RateLimitKeyIP     = "ip"
+	RateLimitKeyPlayer = "player"
+	RateLimitKeyToken  = "token"
+)
+
+// Rate limit response headers
+const (
+	HeaderRateLimitLimit     = "X-RateLimit-Limit"
+	HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+	HeaderRateLimitReset     = "X-RateLimit-Reset"
+	HeaderRetryAfter         = "Retry-After"
+)
+
+// RateLimitRule binds a policy to the request attribute it is keyed by
+type RateLimitRule struct {
+	Policy ratelimit.Policy
+	Key    string // ip, player or token
+}
+
+// RateLimit enforces every rule for the route group.
+// The headers reflect the most restrictive rule; a request is rejected
+// with 429 as soon as one rule denies it.
+func RateLimit(store ratelimit.Store, rules []RateLimitRule) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		now := time.Now()
+
+		var tightest *ratelimit.Result
+		for _, rule := range rules {
+			bucketKey := "rl:" + rule.Policy.Name + ":" + rule.Key + ":" + rateLimitSubject(c, rule.Key)
+
+			result, err := store.Take(c.UserContext(), bucketKey, rule.Policy, now)
+			if err != nil {
+				// Fail open: a broken limiter must not take the API down
+				logging.FromContext(c.UserContext()).Error("rate limit policy failed", "policy", rule.Policy.Name, "error", err)
+				continue
+			}
+
+			if !result.Allowed {
+				setRateLimitHeaders(c, result)
+				retryAfter := ceilSeconds(result.RetryAfter)
+				if retryAfter < 1 {
+					retryAfter = 1
+				}
+				c.Set(HeaderRetryAfter, strconv.Itoa(retryAfter))
+				return httputil.TooManyRequests(c, constants.ErrCodeRateLimitExceeded, "Too many requests, please retry later")
+			}
+
+			if tightest == nil || result.Remaining < tightest.Remaining {
+				r := result
+				tightest = &r
+			}
+		}
+
+		if tightest != nil {
+			setRateLimitHeaders(c, *tightest)
+		}
+		return c.Next()
+	}
+}
+
+func setRateLimitHeaders(c *fiber.Ctx, result ratelimit.Result) {
+	c.Set(HeaderRateLimitLimit, strconv.Itoa(result.Limit))
+	c.Set(HeaderRateLimitRemaining, strconv.Itoa(result.Remaining))
+	c.Set(HeaderRateLimitReset, strconv.Itoa(ceilSeconds(result.ResetAfter)))
+}
+
+// rateLimitSubject resolves the bucket subject, falling back to the client IP
+func rateLimitSubject(c *fiber.Ctx, keyType string) string {
+	switch keyType {
+	case RateLimitKeyPlayer:
+		if playerID := playerIDFromBody(c.Body()); playerID != "" {
+			return truncate(playerID, 64)
+		}
+		if playerID := c.Query("player_id"); playerID != "" {
+			return truncate(playerID, 64)
+		}
+	case RateLimitKeyToken:
+		if subject := tokenSubject(c.Get(fiber.HeaderAuthorization)); subject != "" {
+			return subject
+		}
+	}
+	return c.IP()
+}
+
+// tokenSubject returns the JWT "sub" claim of a bearer token, or a hash of
+// an opaque token. The token is not verified; it is only used for bucketing.
+func tokenSubject(authorization string) string {
+	token, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok || token == "" {
+		return ""
+	}
+
+	if parts := strings.Split(token, "."); len(parts) == 3 {
+		if payload, err := base64.RawURLEncoding.DecodeString(parts[1]); err == nil {
+			var claims struct {
+				Sub string `json:"sub"`
+			}
+			if json.Unmarshal(payload, &claims) == nil && claims.Sub != "" {
+				return "sub:" + truncate(claims.Sub, 64)
+			}
+		}
+	}
+
+	sum := sha256.Sum256([]byte(token))
+	return "tok:" + hex.EncodeToString(sum[:8])
+}
+
+func ceilSeconds(d time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"backend/internal/infrastructure/ratelimit"
+	"backend/internal/infrastructure/server"
+	"backend/internal/shared/constants"
+	httputil "backend/internal/shared/http"
+)
+
+// rateLimitApp serves POST /spin behind one rule allowing a single request
+// per minute; the test peer 0.0.0.0 is a trusted proxy
+func rateLimitApp(key string) *fiber.App {
+	cfg := fiber.Config{ErrorHandler: httputil.ErrorHandler(httputil.NewErrorRegistry())}
+	app := fiber.New(server.TrustProxies(cfg, []string{"0.0.0.0"}, fiber.HeaderXForwardedFor))
+	app.Use(RateLimit(ratelimit.NewMemoryStore(), []RateLimitRule{{
+		Policy: ratelimit.Policy{Name: "spin", Algorithm: ratelimit.AlgorithmTokenBucket, Limit: 1, Window: time.Minute},
+		Key:    key,
+	}}))
+	app.Post("/spin", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusNoContent) })
+	return app
+}
+
+func postRateLimited(t *testing.T, app *fiber.App, clientIP, body string) int {
+	t.Helper()
+	req := httptest.NewRequest(fiber.MethodPost, "/spin", strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	req.Header.Set(fiber.HeaderXForwardedFor, clientIP+", 10.0.0.1")
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res.StatusCode
+}
+
+func TestRateLimitKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		clientIP string
+		body     string
+		// second request, after the first one used up the bucket
+		clientIP2 string
+		body2     string
+		want      int
+	}{
+		{"same ip", RateLimitKeyIP, "203.0.113.1", `{}`, "203.0.113.1", `{}`, fiber.StatusTooManyRequests},
+		{"ip from forwarded header", RateLimitKeyIP, "203.0.113.1", `{}`, "203.0.113.2", `{}`, fiber.StatusNoContent},
+		{"ip ignores player", RateLimitKeyIP, "203.0.113.1", `{"player_id":"p1"}`, "203.0.113.1", `{"player_id":"p2"}`, fiber.StatusTooManyRequests},
+		{"same player across ips", RateLimitKeyPlayer, "203.0.113.1", `{"player_id":"p1"}`, "203.0.113.2", `{"player_id":"p1"}`, fiber.StatusTooManyRequests},
+		{"other player on same ip", RateLimitKeyPlayer, "203.0.113.1", `{"player_id":"p1"}`, "203.0.113.1", `{"player_id":"p2"}`, fiber.StatusNoContent},
+		{"player falls back to ip", RateLimitKeyPlayer, "203.0.113.1", `{}`, "203.0.113.1", `{}`, fiber.StatusTooManyRequests},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := rateLimitApp(tt.key)
+			if status := postRateLimited(t, app, tt.clientIP, tt.body); status != fiber.StatusNoContent {
+				t.Fatalf("first request got %d, want 204", status)
+			}
+			if status := postRateLimited(t, app, tt.clientIP2, tt.body2); status != tt.want {
+				t.Fatalf("second request got %d, want %d", status, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimitRejectsWithRetryAfter(t *testing.T) {
+	app := rateLimitApp(RateLimitKeyIP)
+	postRateLimited(t, app, "203.0.113.1", `{}`)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/spin", nil)
+	req.Header.Set(fiber.HeaderXForwardedFor, "203.0.113.1")
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != fiber.StatusTooManyRequests {
+		t.Fatalf("got %d, want 429", res.StatusCode)
+	}
+	// One token per minute: the next one is a minute away, give or take the test's runtime
+	if got := res.Header.Get(HeaderRetryAfter); got != "60" && got != "59" {
+		t.Errorf("%s = %q, want about 60", HeaderRetryAfter, got)
+	}
+	if got := res.Header.Get(HeaderRateLimitRemaining); got != "0" {
+		t.Errorf("%s = %q, want 0", HeaderRateLimitRemaining, got)
+	}
+	raw, _ := io.ReadAll(res.Body)
+	if code := errorCode(t, string(raw)); code != constants.ErrCodeRateLimitExceeded {
+		t.Errorf("code = %q, want %s", code, constants.ErrCodeRateLimitExceeded)
+	}
+}
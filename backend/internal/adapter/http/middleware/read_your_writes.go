@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"strings"
+
+	"backend/internal/infrastructure/database"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// HeaderReadYourWrites lets a client force its reads to the primary
+const HeaderReadYourWrites = "X-Read-Your-Writes"
+
+// ReadYourWrites routes the request's reads to the primary when the client
+// sends X-Read-Your-Writes: true (e.g. right after its own write)
+func ReadYourWrites() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		switch strings.ToLower(c.Get(HeaderReadYourWrites)) {
+		case "1", "true":
+			c.SetUserContext(database.WithPrimary(c.UserContext()))
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"encoding/json"
+)
+
+// playerIDFromBody extracts player_id from a JSON body (empty if absent)
+func playerIDFromBody(body []byte) string {
+	var payload struct {
+		PlayerID string `json:"player_id"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return ""
+	}
+	return payload.PlayerID
+}
+
+func truncate(s string, max int) string {
+	if len(s) > max {
+		return s[:max]
+	}
+	return s
+}
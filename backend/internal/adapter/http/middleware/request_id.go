@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"log/slog"
+
+	"backend/internal/shared/logging"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// HeaderRequestID is the request/response header carrying the request ID
+const HeaderRequestID = "X-Request-ID"
+
+const maxRequestIDLength = 128
+
+// RequestID accepts the client's X-Request-ID (or generates one), echoes it
+// back and stores a request-scoped logger in the user context
+func RequestID(base *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		requestID := c.Get(HeaderRequestID)
+		if !isValidRequestID(requestID) {
+			requestID = uuid.New().String()
+		}
+		c.Set(HeaderRequestID, requestID)
+
+		ctx := logging.WithRequestID(c.UserContext(), requestID)
+		ctx = logging.WithLogger(ctx, base.With("request_id", requestID))
+		c.SetUserContext(ctx)
+
+		return c.Next()
+	}
+}
+
+// isValidRequestID allows printable ASCII without spaces to keep logs safe
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
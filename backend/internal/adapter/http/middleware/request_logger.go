@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"log/slog"
+	"time"
+
+	httputil "backend/internal/shared/http"
+	"backend/internal/shared/logging"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequestLogger writes one structured access log line per request
+func RequestLogger() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		// Let the error handler write the response so the status is final
+		httputil.RenderError(c, c.Next())
+
+		status := c.Response().StatusCode()
+		level := slog.LevelInfo
+		switch {
+		case status >= fiber.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= fiber.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", c.Method()),
+			slog.String("path", c.Path()),
+			slog.Int("status", status),
+			slog.Int64("duration_ms", time.Since(start).Milliseconds()),
+			slog.String("ip", c.IP()),
+		}
+		// Reading a streamed body (the exports) would buffer all of it, so
+		// streams only log a size when one was declared
+		if !c.Response().IsBodyStream() {
+			attrs = append(attrs, slog.Int("bytes", len(c.Response().Body())))
+		} else if size := c.Response().Header.ContentLength(); size >= 0 {
+			attrs = append(attrs, slog.Int("bytes", size))
+		}
+
+		ctx := c.UserContext()
+		logging.FromContext(ctx).LogAttrs(ctx, level, "http request", attrs...)
+
+		return nil
+	}
+}
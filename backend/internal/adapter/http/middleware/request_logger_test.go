@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestRequestLoggerLeavesStreamsUnbuffered(t *testing.T) {
+	const size = 1 << 20
+	chunk := bytes.Repeat([]byte("x"), 1024)
+
+	app := fiber.New()
+	streaming := false
+	app.Use(func(c *fiber.Ctx) error {
+		err := c.Next()
+		streaming = c.Response().IsBodyStream()
+		return err
+	})
+	app.Use(RequestLogger())
+	app.Get("/export", func(c *fiber.Ctx) error {
+		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			for range size / len(chunk) {
+				w.Write(chunk)
+			}
+		})
+		return nil
+	})
+
+	res, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/export", nil), -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(res.Body)
+	if !streaming {
+		t.Error("the logger buffered the streamed body")
+	}
+	if len(body) != size {
+		t.Errorf("received %d bytes, want %d", len(body), size)
+	}
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"net/http"
+
+	"backend/internal/shared/logging"
+	"backend/internal/shared/tracing"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracing starts a server span per request, continuing the caller's trace
+// from the W3C traceparent header. The span context and a logger tagged
+// with trace_id are stored in the user context for downstream layers.
+func Tracing() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		carrier := propagation.HeaderCarrier(http.Header{})
+		c.Request().Header.VisitAll(func(key, value []byte) {
+			carrier.Set(string(key), string(value))
+		})
+		ctx := otel.GetTextMapPropagator().Extract(c.UserContext(), carrier)
+
+		ctx, span := tracing.Start(ctx, c.Method()+" "+c.Path(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(c.Method()),
+				semconv.URLPath(c.Path()),
+				semconv.ClientAddress(c.IP()),
+			),
+		)
+		defer span.End()
+
+		if sc := span.SpanContext(); sc.HasTraceID() {
+			ctx = logging.WithLogger(ctx, logging.FromContext(ctx).With("trace_id", sc.TraceID().String()))
+		}
+		c.SetUserContext(ctx)
+
+		chainErr := c.Next()
+
+		status := c.Response().StatusCode()
+		if chainErr != nil {
+			status = fiber.StatusInternalServerError
+			if fe, ok := chainErr.(*fiber.Error); ok {
+				status = fe.Code
+			}
+			span.RecordError(chainErr)
+		}
+
+		// Rename to the route template once routing has happened
+		route := c.Route().Path
+		span.SetName(c.Method() + " " + route)
+		span.SetAttributes(
+			semconv.HTTPRoute(route),
+			semconv.HTTPResponseStatusCode(status),
+			attribute.String("request_id", logging.RequestID(ctx)),
+		)
+		if status >= fiber.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+
+		return chainErr
+	}
+}
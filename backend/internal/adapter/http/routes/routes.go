@@ -1,33 +1,90 @@
 package routes
 
 import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"backend/internal/adapter/http/middleware"
+	"backend/internal/infrastructure/background"
 	"backend/internal/infrastructure/config"
+	"backend/internal/infrastructure/database"
+	"backend/internal/infrastructure/health"
+	"backend/internal/infrastructure/idempotency"
+	"backend/internal/infrastructure/metrics"
+	"backend/internal/infrastructure/ratelimit"
+	"backend/internal/infrastructure/tracing"
 	"backend/internal/modules/game"
 	"backend/internal/modules/history"
 	"backend/internal/modules/player"
+	"backend/internal/modules/privacy"
 	"backend/internal/modules/reward"
+	"backend/internal/shared/events"
+	httputil "backend/internal/shared/http"
+	"backend/internal/shared/i18n"
 
 	"github.com/gofiber/fiber/v2"
 	fiberSwagger "github.com/swaggo/fiber-swagger"
-	"gorm.io/gorm"
 )
 
-func Setup(app *fiber.App, db *gorm.DB, cfg *config.Config) {
-	// Health check
-	app.Get("/health", func(c *fiber.Ctx) error {
-		return c.JSON(fiber.Map{
-			"status":  "ok",
-			"message": "Server is running",
-		})
-	})
+// Setup wires modules and routes and registers the modules' errors in
+// errorRegistry; dbRouter is nil when STORAGE=memory
+func Setup(app *fiber.App, dbRouter *database.Router, cfg *config.Config, healthRegistry *health.Registry, workers *background.Group, errorRegistry *httputil.ErrorRegistry) {
+	// Metrics (registered first so every request is measured)
+	appMetrics := metrics.New()
+	app.Use(middleware.Metrics(appMetrics))
+	app.Get("/metrics", appMetrics.Handler())
 
-	// Database health check
-	app.Get("/health/db", func(c *fiber.Ctx) error {
-		return c.JSON(fiber.Map{
-			"status":   "ok",
-			"database": "connected",
-		})
-	})
+	// Response language from Accept-Language (players may prefer another)
+	bundle, err := i18n.Load(cfg.I18n.DefaultLocale)
+	if err != nil {
+		panic("Failed to load message catalogs: " + err.Error())
+	}
+	app.Use(middleware.Locale(bundle))
+
+	// Domain events feed the business metrics
+	eventBus := events.NewBus()
+	eventBus.SubscribeAll(appMetrics.HandleEvent)
+
+	var repos *repositories
+	var pinReads func(playerID string) // nil without a database router
+	if cfg.Storage.Driver == config.StorageMemory {
+		slog.Warn("STORAGE=memory: data is kept in process memory and lost on restart", "component", "routes")
+		repos = newMemoryRepositories(cfg, bundle)
+	} else {
+		db := dbRouter.Primary()
+		if err := db.Use(metrics.NewGormPlugin(appMetrics)); err != nil {
+			panic("Failed to register GORM metrics plugin: " + err.Error())
+		}
+		if sqlDB, err := db.DB(); err == nil {
+			name := cfg.DB.Database
+			if cfg.DB.Driver == config.StorageSQLite {
+				name = cfg.DB.Path
+			}
+			appMetrics.RegisterDBStats(sqlDB, name)
+		}
+
+		// Tracing spans for every SQL statement
+		if err := db.Use(tracing.NewGormPlugin()); err != nil {
+			panic("Failed to register GORM tracing plugin: " + err.Error())
+		}
+
+		// Read-your-writes: the modules pin players to the primary right after
+		// they spin or claim, and an explicit X-Read-Your-Writes header is honoured
+		pinReads = dbRouter.Pin
+		app.Use(middleware.ReadYourWrites())
+
+		repos = newGormRepositories(dbRouter, cfg)
+	}
+
+	// Liveness and readiness probes (per-component status and latency)
+	app.Get("/livez", healthRegistry.LivenessHandler())
+	app.Get("/readyz", healthRegistry.ReadinessHandler())
+
+	// Legacy health endpoints
+	app.Get("/health", healthRegistry.LivenessHandler())
+	app.Get("/health/db", healthRegistry.ComponentHandler("database"))
 
 	// API info
 	app.Get("/api/info", func(c *fiber.Ctx) error {
@@ -38,35 +95,188 @@ func Setup(app *fiber.App, db *gorm.DB, cfg *config.Config) {
 		})
 	})
 
-	// Swagger documentation
-	app.Get("/swagger/*", fiberSwagger.WrapHandler)
-
-	// Initialize modules (order matters for dependency injection)
-	// First create player module (no dependencies)
-	playerModule := player.NewModule(db, cfg, nil)
+	// Swagger UI documents version 1; /swagger/doc.json keeps the spec of the
+	// unversioned paths
+	app.Get("/swagger/v1/doc.json", versionDocHandler("v1"))
+	app.Get("/swagger/*", fiberSwagger.FiberWrapHandler(fiberSwagger.URL("v1/doc.json")))
 
-	// Create history module
-	historyModule := history.NewModule(db, cfg)
+	// Initialize modules on the chosen repositories
+	historyModule := history.NewModule(cfg, repos.spinLogs)
 
 	// Create reward module with player repo for claim usecase
-	rewardModule := reward.NewModule(db, cfg, playerModule.PlayerRepo)
+	rewardModule := reward.NewModule(cfg, repos.rewardConfigs, repos.rewardTxs, repos.players, eventBus)
+
+	// Player module reads reward and history repos for profile and stats endpoints
+	playerModule := player.NewModule(cfg, bundle, repos.players, repos.referrals, repos.rewardTxs, repos.spinLogs, repos.rewardConfigs, repos.transactor, eventBus)
+
+	// Referees' spins count towards their referrer's reward
+	eventBus.Subscribe("game.spin_executed", playerModule.ReferralTracker.HandleEvent)
+
+	// Deleted players' nicknames become available after the grace period
+	workers.Go("nickname-releaser", func(ctx context.Context) {
+		playerModule.NicknameReleaser.Run(ctx, cfg.Players.NicknameReleaseInterval)
+	})
 
-	// Update player module with reward repo for profile endpoint
-	playerModuleWithRewards := player.NewModule(db, cfg, rewardModule.RewardTxRepo)
+	// Data export and erasure (needs player, referral, spin log and reward repos)
+	privacyModule := privacy.NewModule(cfg, repos.complianceLog, playerModule.PlayerRepo, playerModule.ReferralRepo, historyModule.SpinLogRepo, rewardModule.RewardTxRepo, repos.transactor, eventBus)
 
 	// Initialize game module (needs player and spin log repos)
-	sqlDB, err := db.DB()
-	if err != nil {
-		panic("Failed to get SQL DB: " + err.Error())
-	}
-	gameModule, err := game.NewModule(cfg, sqlDB, playerModule.PlayerRepo, historyModule.SpinLogRepo)
+	gameModule, err := game.NewModule(cfg, playerModule.PlayerRepo, historyModule.SpinLogRepo, eventBus)
 	if err != nil {
 		panic("Failed to initialize game module: " + err.Error())
 	}
 
-	// Register routes
-	playerModuleWithRewards.RegisterRoutes(app)
-	historyModule.RegisterRoutes(app)
-	rewardModule.RegisterRoutes(app)
-	gameModule.RegisterRoutes(app)
+	if pinReads != nil {
+		gameModule.RegisterReadPinning(eventBus, pinReads)
+		rewardModule.RegisterReadPinning(eventBus, pinReads)
+	}
+
+	// Rate limiting (registered before idempotency so rejections are never cached)
+	var apiMiddleware []prefixedHandler
+	if cfg.RateLimit.Enabled {
+		apiMiddleware = append(apiMiddleware, setupRateLimit(dbRouter, cfg, workers)...)
+	}
+
+	// Idempotency-Key support for endpoints that mobile clients retry
+	idempotencyStore := repos.idempotency
+	workers.Go("idempotency-janitor", func(ctx context.Context) {
+		idempotency.RunJanitor(ctx, idempotencyStore, cfg.Idempotency.PurgeInterval)
+	})
+	idempotent := middleware.Idempotency(middleware.IdempotencyConfig{
+		Store:   idempotencyStore,
+		TTL:     cfg.Idempotency.TTL,
+		LockTTL: cfg.Idempotency.LockTTL,
+	})
+	apiMiddleware = append(apiMiddleware,
+		prefixedHandler{prefix: "/game/spin", handler: idempotent},
+		prefixedHandler{prefix: "/rewards/claim", handler: idempotent},
+	)
+
+	// Map each module's errors to HTTP responses
+	playerModule.RegisterErrors(errorRegistry)
+	privacyModule.RegisterErrors(errorRegistry)
+	rewardModule.RegisterErrors(errorRegistry)
+	gameModule.RegisterErrors(errorRegistry)
+
+	// Admin endpoints are only served when an admin key is configured
+	if cfg.Admin.APIKey == "" {
+		slog.Warn("ADMIN_API_KEY is not set; admin endpoints are disabled", "component", "routes")
+	}
+
+	// Every version registers the same modules into its own router group
+	registerAPI := func(router fiber.Router) {
+		for _, m := range apiMiddleware {
+			router.Use(m.prefix, m.handler)
+		}
+
+		playerModule.RegisterRoutes(router)
+		privacyModule.RegisterRoutes(router)
+		historyModule.RegisterRoutes(router)
+		rewardModule.RegisterRoutes(router)
+		gameModule.RegisterRoutes(router)
+
+		if cfg.Admin.APIKey != "" {
+			admin := router.Group("/admin", middleware.AdminAuth(cfg.Admin.APIKey))
+			playerModule.RegisterAdminRoutes(admin)
+			privacyModule.RegisterAdminRoutes(admin)
+			historyModule.RegisterAdminRoutes(admin)
+			rewardModule.RegisterAdminRoutes(admin)
+		}
+	}
+
+	// Version 1
+	v1 := app.Group(v1Prefix)
+	if deprecation := cfg.API.V1; !deprecation.DeprecatedAt.IsZero() {
+		v1.Use(middleware.Deprecation(middleware.DeprecationConfig{
+			DeprecatedAt: deprecation.DeprecatedAt,
+			Sunset:       deprecation.Sunset,
+		}))
+	}
+	registerAPI(v1)
+
+	// The unversioned paths stay as aliases of v1 for app builds released
+	// before versioning: they keep the response shapes those builds parse and
+	// are deprecated in favour of their /v1 path
+	legacy := []fiber.Handler{httputil.Legacy()}
+	if deprecation := cfg.API.Legacy; !deprecation.DeprecatedAt.IsZero() {
+		legacy = append(legacy, middleware.Deprecation(middleware.DeprecationConfig{
+			DeprecatedAt: deprecation.DeprecatedAt,
+			Sunset:       deprecation.Sunset,
+			Successor:    func(url string) string { return v1Prefix + url },
+		}))
+	}
+	for _, prefix := range routePrefixes(app, v1Prefix) {
+		for _, handler := range legacy {
+			app.Use(prefix, handler)
+		}
+	}
+	gameModule.RegisterLegacyErrors(app)
+	registerAPI(app)
+}
+
+// v1Prefix is where version 1 of the API is served
+const v1Prefix = "/v1"
+
+// prefixedHandler is middleware for the routes under prefix, registered in
+// every API version
+type prefixedHandler struct {
+	prefix  string
+	handler fiber.Handler
+}
+
+// routePrefixes returns the distinct first path segments of the routes
+// registered under version (e.g. /players for /v1/players/:id)
+func routePrefixes(app *fiber.App, version string) []string {
+	var prefixes []string
+	for _, route := range app.GetRoutes(true) {
+		path, ok := strings.CutPrefix(route.Path, version+"/")
+		if !ok {
+			continue
+		}
+		segment, _, _ := strings.Cut(path, "/")
+		if prefix := "/" + segment; segment != "" && !slices.Contains(prefixes, prefix) {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	return prefixes
+}
+
+// setupRateLimit returns one rate limit middleware per configured route prefix
+func setupRateLimit(dbRouter *database.Router, cfg *config.Config, workers *background.Group) []prefixedHandler {
+	var store ratelimit.Store
+	if cfg.RateLimit.Store == ratelimit.StorePostgres {
+		store = ratelimit.NewPostgresStore(dbRouter.Primary())
+	} else {
+		store = ratelimit.NewMemoryStore()
+	}
+	workers.Go("ratelimit-janitor", func(ctx context.Context) {
+		ratelimit.RunJanitor(ctx, store, cfg.RateLimit.PurgeInterval)
+	})
+
+	// Group rules by route prefix, keeping the order prefixes first appear in
+	var prefixes []string
+	rulesByPrefix := make(map[string][]middleware.RateLimitRule)
+	for _, p := range cfg.RateLimit.Policies {
+		rule := middleware.RateLimitRule{
+			Policy: ratelimit.Policy{
+				Name:      p.Name,
+				Algorithm: p.Algorithm,
+				Limit:     p.Limit,
+				Window:    p.Window,
+			},
+			Key: p.Key,
+		}
+		for _, route := range p.Routes {
+			if _, ok := rulesByPrefix[route]; !ok {
+				prefixes = append(prefixes, route)
+			}
+			rulesByPrefix[route] = append(rulesByPrefix[route], rule)
+		}
+	}
+
+	handlers := make([]prefixedHandler, len(prefixes))
+	for i, prefix := range prefixes {
+		handlers[i] = prefixedHandler{prefix: prefix, handler: middleware.RateLimit(store, rulesByPrefix[prefix])}
+	}
+	return handlers
 }
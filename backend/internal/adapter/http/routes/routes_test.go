@@ -0,0 +1,86 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"backend/internal/adapter/http/middleware"
+	"backend/internal/infrastructure/background"
+	"backend/internal/infrastructure/config"
+	"backend/internal/infrastructure/health"
+	httputil "backend/internal/shared/http"
+)
+
+func TestLegacyAliasKeepsOldBodyAndIsDeprecated(t *testing.T) {
+	deprecatedAt := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2027, 4, 30, 0, 0, 0, 0, time.UTC)
+	cfg := &config.Config{
+		Storage:    config.StorageConfig{Driver: config.StorageMemory},
+		Pagination: config.PaginationConfig{DefaultLimit: 20, MaxLimit: 100, CursorSecret: "secret"},
+		Game: config.GameConfig{Spin: config.SpinConfig{
+			MaxDailySpins: 10,
+			Distribution:  []config.SpinDistributionItem{{Points: 50, Weight: 1}},
+		}},
+		Validation:  config.ValidationConfig{Nickname: config.NicknameValidationConfig{MinLength: 3, MaxLength: 20}},
+		Streaks:     config.StreaksConfig{Timezone: "UTC"},
+		Players:     config.PlayersConfig{UpdateMaxAttempts: 1, NicknameReleaseInterval: time.Hour},
+		Idempotency: config.IdempotencyConfig{TTL: time.Hour, LockTTL: time.Minute},
+		I18n:        config.I18nConfig{DefaultLocale: "en"},
+		API:         config.APIConfig{Legacy: config.DeprecationConfig{DeprecatedAt: deprecatedAt, Sunset: sunset}},
+	}
+	workers := background.NewGroup(context.Background())
+	defer workers.Stop(time.Second)
+
+	registry := httputil.NewErrorRegistry()
+	app := fiber.New(fiber.Config{ErrorHandler: httputil.ErrorHandler(registry)})
+	Setup(app, nil, cfg, health.NewRegistry(time.Second), workers, registry)
+
+	enter := func(path string) (map[string]any, http.Header) {
+		t.Helper()
+		req := httptest.NewRequest(fiber.MethodPost, path, strings.NewReader(`{"nickname":"alice"}`))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		res, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		raw, _ := io.ReadAll(res.Body)
+		var body map[string]any
+		if err := json.Unmarshal(raw, &body); err != nil {
+			t.Fatalf("%s: decode %s: %v", path, raw, err)
+		}
+		return body, res.Header
+	}
+
+	// The alias answers with the bare EnterResponse and the deprecation headers
+	body, header := enter("/players/enter")
+	if body["nickname"] != "alice" || body["success"] != nil {
+		t.Fatalf("legacy body = %v, want a bare EnterResponse", body)
+	}
+	wantHeaders := map[string]string{
+		middleware.HeaderDeprecation: "@1792368000",
+		middleware.HeaderSunset:      "Fri, 30 Apr 2027 00:00:00 GMT",
+		fiber.HeaderLink:             `</v1/players/enter>; rel="successor-version"`,
+	}
+	for name, want := range wantHeaders {
+		if got := header.Get(name); got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+
+	// /v1 answers in the envelope and is not deprecated
+	body, header = enter("/v1/players/enter")
+	if data, ok := body["data"].(map[string]any); !ok || body["success"] != true || data["nickname"] != "alice" {
+		t.Fatalf("v1 body = %v, want the envelope", body)
+	}
+	if got := header.Get(middleware.HeaderDeprecation); got != "" {
+		t.Errorf("v1 %s = %q, want none", middleware.HeaderDeprecation, got)
+	}
+}
@@ -0,0 +1,82 @@
+package routes
+
+import (
+	"log/slog"
+
+	"backend/internal/infrastructure/config"
+	"backend/internal/infrastructure/database"
+	"backend/internal/infrastructure/idempotency"
+	historyrepo "backend/internal/modules/history/adapter/repository"
+	historydomain "backend/internal/modules/history/domain"
+	"backend/internal/modules/player"
+	playerrepo "backend/internal/modules/player/adapter/repository"
+	playerdomain "backend/internal/modules/player/domain"
+	privacyrepo "backend/internal/modules/privacy/adapter/repository"
+	privacydomain "backend/internal/modules/privacy/domain"
+	rewardrepo "backend/internal/modules/reward/adapter/repository"
+	rewarddomain "backend/internal/modules/reward/domain"
+	"backend/internal/shared/cursor"
+	shared "backend/internal/shared/domain"
+	"backend/internal/shared/i18n"
+)
+
+// repositories are the stores behind every module, chosen by STORAGE
+type repositories struct {
+	players       playerdomain.PlayerRepository
+	referrals     playerdomain.ReferralRepository
+	spinLogs      historydomain.SpinLogRepository
+	rewardConfigs rewarddomain.RewardConfigRepository
+	rewardTxs     rewarddomain.RewardTransactionRepository
+	complianceLog privacydomain.ComplianceLogRepository
+	idempotency   idempotency.Store
+	transactor    shared.Transactor
+}
+
+// newGormRepositories stores everything in the SQL database (Postgres or
+// SQLite); on Postgres, history and reward listings may be served by replicas
+func newGormRepositories(dbRouter *database.Router, cfg *config.Config) *repositories {
+	db := dbRouter.Primary()
+	cursors := cursor.NewCodec(cfg.Pagination.CursorSecret)
+
+	return &repositories{
+		players:       playerrepo.NewPlayerRepositoryGorm(db, player.NewFactory(cfg)),
+		referrals:     playerrepo.NewReferralRepositoryGorm(db),
+		spinLogs:      historyrepo.NewSpinLogRepositoryGorm(db, dbRouter, cursors),
+		rewardConfigs: rewardrepo.NewRewardConfigRepositoryGorm(db),
+		rewardTxs:     rewardrepo.NewRewardTransactionRepositoryGorm(db, dbRouter, cursors),
+		complianceLog: privacyrepo.NewComplianceLogRepositoryGorm(db),
+		idempotency:   idempotency.NewPostgresStore(db),
+		transactor:    database.NewTransactor(db),
+	}
+}
+
+// newMemoryRepositories keeps everything in process memory (single instance,
+// lost on restart); reward configs are seeded from rewards.yaml and the
+// catalogs' reward translations
+func newMemoryRepositories(cfg *config.Config, bundle *i18n.Bundle) *repositories {
+	cursors := cursor.NewCodec(cfg.Pagination.CursorSecret)
+
+	rewardConfigs := make([]*rewarddomain.RewardConfig, 0, len(cfg.Rewards.Checkpoints))
+	for _, cp := range cfg.Rewards.Checkpoints {
+		rc, err := rewarddomain.NewRewardConfig(cp.CheckpointVal, cp.RewardName, cp.RewardDescription)
+		if err != nil {
+			slog.Warn("skipping invalid reward checkpoint", "component", "routes", "checkpoint", cp.CheckpointVal, "error", err)
+			continue
+		}
+		rewardConfigs = append(rewardConfigs, rc.WithTranslations(bundle.RewardTranslations(cp.CheckpointVal)))
+	}
+
+	players := playerrepo.NewPlayerRepositoryMemory(player.NewFactory(cfg))
+	configs := rewardrepo.NewRewardConfigRepositoryMemory(rewardConfigs)
+
+	return &repositories{
+		players:       players,
+		referrals:     playerrepo.NewReferralRepositoryMemory(players),
+		spinLogs:      historyrepo.NewSpinLogRepositoryMemory(players, cursors),
+		rewardConfigs: configs,
+		rewardTxs:     rewardrepo.NewRewardTransactionRepositoryMemory(configs, players, cursors),
+		complianceLog: privacyrepo.NewComplianceLogRepositoryMemory(),
+		idempotency:   idempotency.NewMemoryStore(),
+		transactor:    database.NoTransactor{},
+	}
+}
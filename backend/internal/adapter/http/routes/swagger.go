@@ -0,0 +1,36 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/swaggo/swag"
+
+	"backend/docs"
+)
+
+// versionDoc serves the generated spec under an API version's base path. It
+// renders on every read so host changes made at startup (SWAGGER_EXTERNAL_URL)
+// apply to every version.
+type versionDoc struct {
+	version string
+}
+
+// ReadDoc implements swag.Swagger
+func (d versionDoc) ReadDoc() string {
+	spec := *docs.SwaggerInfo
+	spec.BasePath = "/" + d.version
+	spec.Title += " " + d.version
+	return spec.ReadDoc()
+}
+
+// versionDocHandler registers the spec of an API version and serves it as JSON
+func versionDocHandler(version string) fiber.Handler {
+	swag.Register(version, versionDoc{version: version})
+	return func(c *fiber.Ctx) error {
+		doc, err := swag.ReadDoc(version)
+		if err != nil {
+			return err
+		}
+		c.Type("json", "utf-8")
+		return c.SendString(doc)
+	}
+}
@@ -0,0 +1,52 @@
+package background
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Group runs long-lived workers (janitors, relays, schedulers) that share one
+// cancellable context, so shutdown can stop them before the DB pool closes
+type Group struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewGroup creates a worker group derived from parent
+func NewGroup(parent context.Context) *Group {
+	ctx, cancel := context.WithCancel(parent)
+	return &Group{ctx: ctx, cancel: cancel}
+}
+
+// Go starts fn in a goroutine; fn must return once ctx is cancelled
+func (g *Group) Go(name string, fn func(ctx context.Context)) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		slog.Debug("background worker started", "component", "background", "worker", name)
+		fn(g.ctx)
+		slog.Debug("background worker stopped", "component", "background", "worker", name)
+	}()
+}
+
+// Stop cancels all workers and waits for them up to timeout
+func (g *Group) Stop(timeout time.Duration) error {
+	g.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-time.After(timeout):
+		return fmt.Errorf("background workers did not stop within %s", timeout)
+	}
+}
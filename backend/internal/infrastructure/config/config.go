@@ -2,32 +2,90 @@ package config
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
+	"net"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
+	_ "time/tzdata" // export timezones must resolve in minimal images
 
+	"github.com/go-viper/mapstructure/v2"
 	"github.com/joho/godotenv"
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	DB         DBConfig
-	Server     ServerConfig
-	Log        LogConfig
-	Game       GameConfig
-	Pagination PaginationConfig
-	Rewards    RewardsConfig
-	Validation ValidationConfig
+	Storage     StorageConfig
+	DB          DBConfig
+	Server      ServerConfig
+	Log         LogConfig
+	Game        GameConfig
+	Pagination  PaginationConfig
+	Rewards     RewardsConfig
+	Validation  ValidationConfig
+	Idempotency IdempotencyConfig
+	RateLimit   RateLimitConfig
+	Tracing     TracingConfig
+	Health      HealthConfig
+	Export      ExportConfig
+	Streaks     StreaksConfig
+	Referrals   ReferralsConfig
+	Players     PlayersConfig
+	Admin       AdminConfig
+	I18n        I18nConfig
+	API         APIConfig
+}
+
+// Storage drivers
+const (
+	StoragePostgres = "postgres"
+	StorageSQLite   = "sqlite"
+	StorageMemory   = "memory"
+)
+
+// StorageConfig selects where repositories keep their data
+type StorageConfig struct {
+	// Driver is postgres, sqlite for a single file database, or memory to run
+	// without a database (data is lost on restart)
+	Driver string
 }
 
 type DBConfig struct {
+	// Driver is postgres or sqlite, taken from STORAGE
+	Driver string
+	// Path is the SQLite database file (DB_PATH)
+	Path string
+
 	Host     string
 	Port     int
 	User     string
 	Password string
 	Database string
 	SSLMode  string
+
+	// Connection pool
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+
+	// StatementTimeout is sent as the session statement_timeout (0 disables it)
+	StatementTimeout time.Duration
+	// SlowQueryThreshold marks queries the GORM logger reports as slow (0 disables it)
+	SlowQueryThreshold time.Duration
+
+	// ConnectRetries is how many extra attempts are made when the first connect fails
+	ConnectRetries int
+	// ConnectBackoff is the initial retry delay; it doubles up to ConnectMaxBackoff
+	ConnectBackoff    time.Duration
+	ConnectMaxBackoff time.Duration
+
+	// ReplicaDSNs are read-only replica connection strings (DB_REPLICA_DSNS, separated by ;)
+	ReplicaDSNs []string
+	// ReadYourWritesWindow pins a player's reads to the primary after a write
+	ReadYourWritesWindow time.Duration
 }
 
 type ServerConfig struct {
@@ -35,12 +93,70 @@ type ServerConfig struct {
 	Env  string
 	// AllowOrigins is a comma-separated list of CORS allowed origins (e.g. "http://localhost:3000,http://127.0.0.1:3000")
 	AllowOrigins string `mapstructure:"allow_origins"`
+	// ShutdownTimeout bounds how long in-flight requests may finish after SIGTERM
+	ShutdownTimeout time.Duration
+	// ShutdownDrainDelay keeps serving with readiness failing before the listener closes
+	ShutdownDrainDelay time.Duration
+	// TrustedProxies are the load balancers (IPs or CIDRs, TRUSTED_PROXIES)
+	// whose ProxyHeader gives the client IP; empty uses the peer address
+	TrustedProxies []string
+	ProxyHeader    string
 }
 
 type LogConfig struct {
 	Level string
 }
 
+// I18nConfig controls localized messages (catalogs are in locales/)
+type I18nConfig struct {
+	// DefaultLocale is used when neither Accept-Language nor the player's
+	// preference names a supported locale, and for missing translations
+	DefaultLocale string
+}
+
+// IdempotencyConfig controls Idempotency-Key handling for spin and claim
+type IdempotencyConfig struct {
+	// TTL is how long a completed response is replayed
+	TTL time.Duration
+	// LockTTL is how long a key stays locked while its first request runs
+	LockTTL time.Duration
+	// PurgeInterval is how often expired keys are deleted (0 disables purging)
+	PurgeInterval time.Duration
+}
+
+// TracingConfig controls OpenTelemetry tracing
+type TracingConfig struct {
+	// Exporter is none, stdout or otlp (OTLP endpoint via OTEL_EXPORTER_OTLP_ENDPOINT)
+	Exporter    string
+	ServiceName string
+	// SampleRatio is the fraction of new traces that are sampled (0..1)
+	SampleRatio float64
+}
+
+// HealthConfig controls the /livez and /readyz probes
+type HealthConfig struct {
+	// CheckTimeout bounds each dependency check
+	CheckTimeout time.Duration
+}
+
+// RateLimitConfig holds rate limiting policies (ratelimit.yaml)
+type RateLimitConfig struct {
+	Enabled       bool                    `mapstructure:"enabled"`
+	Store         string                  `mapstructure:"store"`
+	PurgeInterval time.Duration           `mapstructure:"purge_interval"`
+	Policies      []RateLimitPolicyConfig `mapstructure:"policies"`
+}
+
+// RateLimitPolicyConfig is a single policy applied to route prefixes
+type RateLimitPolicyConfig struct {
+	Name      string        `mapstructure:"name"`
+	Routes    []string      `mapstructure:"routes"`
+	Key       string        `mapstructure:"key"`       // ip, player or token
+	Algorithm string        `mapstructure:"algorithm"` // token_bucket or sliding_window
+	Limit     int           `mapstructure:"limit"`
+	Window    time.Duration `mapstructure:"window"`
+}
+
 type RewardsConfig struct {
 	Checkpoints []CheckpointItem `mapstructure:"checkpoints"`
 }
@@ -75,13 +191,13 @@ func Init() *Config {
 	}
 	if foundPath != "" {
 		if err := godotenv.Load(foundPath); err != nil {
-			log.Printf("[Config] Note: Found %s but failed to load: %v", foundPath, err)
+			slog.Warn("found .env but failed to load it", "component", "config", "path", foundPath, "error", err)
 		} else {
-			log.Printf("[Config] Loaded .env from %s", foundPath)
+			slog.Info("loaded .env", "component", "config", "path", foundPath)
 		}
 	} else {
 		// No .env file found; environment variables (from docker/env) will be used
-		log.Printf("[Config] No .env file found (checked .env and ../.env); using environment variables or defaults")
+		slog.Info("no .env file found (checked .env and ../.env); using environment variables or defaults", "component", "config")
 	}
 
 	// Also try to load from executable directory
@@ -91,7 +207,7 @@ func Init() *Config {
 		envExecPath := filepath.Join(execDir, ".env")
 		if _, err := os.Stat(envExecPath); err == nil {
 			if err := godotenv.Overload(envExecPath); err == nil {
-				log.Printf("[Config] Loaded .env from executable directory: %s", envExecPath)
+				slog.Info("loaded .env from executable directory", "component", "config", "path", envExecPath)
 			}
 		}
 	}
@@ -107,93 +223,240 @@ func Init() *Config {
 	viper.AutomaticEnv()
 
 	if err := viper.ReadInConfig(); err != nil {
-		log.Printf("[Config] Warning: Could not load game.yaml: %v", err)
+		slog.Warn("could not load config file", "component", "config", "file", "game.yaml", "error", err)
 	} else {
-		log.Printf("[Config] ✓ Loaded game config from %s", viper.ConfigFileUsed())
+		slog.Info("loaded config file", "component", "config", "file", viper.ConfigFileUsed())
 	}
 
 	// Load pagination configuration from YAML
 	viper.SetConfigName("pagination")
 	if err := viper.MergeInConfig(); err != nil {
-		log.Printf("[Config] Warning: Could not load pagination.yaml: %v", err)
+		slog.Warn("could not load config file", "component", "config", "file", "pagination.yaml", "error", err)
 	} else {
-		log.Printf("[Config] ✓ Loaded pagination config")
+		slog.Info("loaded config file", "component", "config", "file", "pagination.yaml")
 	}
 
 	// Load rewards configuration from YAML
 	viper.SetConfigName("rewards")
 	if err := viper.MergeInConfig(); err != nil {
-		log.Printf("[Config] Warning: Could not load rewards.yaml: %v", err)
+		slog.Warn("could not load config file", "component", "config", "file", "rewards.yaml", "error", err)
 	} else {
-		log.Printf("[Config] ✓ Loaded rewards config")
+		slog.Info("loaded config file", "component", "config", "file", "rewards.yaml")
 	}
 
 	// Load validation configuration from YAML
 	viper.SetConfigName("validation")
 	if err := viper.MergeInConfig(); err != nil {
-		log.Printf("[Config] Warning: Could not load validation.yaml: %v", err)
+		slog.Warn("could not load config file", "component", "config", "file", "validation.yaml", "error", err)
+	} else {
+		slog.Info("loaded config file", "component", "config", "file", "validation.yaml")
+	}
+
+	// Load rate limit configuration from YAML
+	viper.SetConfigName("ratelimit")
+	if err := viper.MergeInConfig(); err != nil {
+		slog.Warn("could not load config file", "component", "config", "file", "ratelimit.yaml", "error", err)
+	} else {
+		slog.Info("loaded config file", "component", "config", "file", "ratelimit.yaml")
+	}
+
+	// Load export configuration from YAML
+	viper.SetConfigName("export")
+	if err := viper.MergeInConfig(); err != nil {
+		slog.Warn("could not load config file", "component", "config", "file", "export.yaml", "error", err)
+	} else {
+		slog.Info("loaded config file", "component", "config", "file", "export.yaml")
+	}
+
+	// Load streak configuration from YAML
+	viper.SetConfigName("streaks")
+	if err := viper.MergeInConfig(); err != nil {
+		slog.Warn("could not load config file", "component", "config", "file", "streaks.yaml", "error", err)
+	} else {
+		slog.Info("loaded config file", "component", "config", "file", "streaks.yaml")
+	}
+
+	// Load referral configuration from YAML
+	viper.SetConfigName("referrals")
+	if err := viper.MergeInConfig(); err != nil {
+		slog.Warn("could not load config file", "component", "config", "file", "referrals.yaml", "error", err)
+	} else {
+		slog.Info("loaded config file", "component", "config", "file", "referrals.yaml")
+	}
+
+	// Load player account configuration from YAML
+	viper.SetConfigName("players")
+	if err := viper.MergeInConfig(); err != nil {
+		slog.Warn("could not load config file", "component", "config", "file", "players.yaml", "error", err)
+	} else {
+		slog.Info("loaded config file", "component", "config", "file", "players.yaml")
+	}
+
+	// Load API versioning configuration from YAML
+	viper.SetConfigName("api")
+	if err := viper.MergeInConfig(); err != nil {
+		slog.Warn("could not load config file", "component", "config", "file", "api.yaml", "error", err)
 	} else {
-		log.Printf("[Config] ✓ Loaded validation config")
+		slog.Info("loaded config file", "component", "config", "file", "api.yaml")
 	}
 
-	// Read from environment variables
+	// Read from environment variables; STORAGE also picks the SQL driver
+	storage := getEnv("STORAGE", StoragePostgres)
 	cfg := &Config{
+		Storage: StorageConfig{
+			Driver: storage,
+		},
 		DB: DBConfig{
+			Driver: storage,
+			Path:   getEnv("DB_PATH", "spinhead.db"),
+
 			Host:     getEnv("DB_HOST", "localhost"),
 			Port:     getEnvInt("DB_PORT", 5432),
 			User:     getEnv("DB_USER", "postgres"),
 			Password: getEnv("DB_PASSWORD", "postgres"),
 			Database: getEnv("DB_NAME", "postgres"),
 			SSLMode:  getEnv("DB_SSLMODE", "disable"),
+
+			MaxOpenConns:         getEnvInt("DB_MAX_OPEN_CONNS", 25),
+			MaxIdleConns:         getEnvInt("DB_MAX_IDLE_CONNS", 10),
+			ConnMaxLifetime:      getEnvDuration("DB_CONN_MAX_LIFETIME", 30*time.Minute),
+			ConnMaxIdleTime:      getEnvDuration("DB_CONN_MAX_IDLE_TIME", 5*time.Minute),
+			StatementTimeout:     getEnvDuration("DB_STATEMENT_TIMEOUT", 10*time.Second),
+			SlowQueryThreshold:   getEnvDuration("DB_SLOW_QUERY_THRESHOLD", 200*time.Millisecond),
+			ConnectRetries:       getEnvInt("DB_CONNECT_RETRIES", 5),
+			ConnectBackoff:       getEnvDuration("DB_CONNECT_BACKOFF", time.Second),
+			ConnectMaxBackoff:    getEnvDuration("DB_CONNECT_MAX_BACKOFF", 30*time.Second),
+			ReplicaDSNs:          getEnvList("DB_REPLICA_DSNS", ";"),
+			ReadYourWritesWindow: getEnvDuration("DB_READ_YOUR_WRITES_WINDOW", 5*time.Second),
 		},
 		Server: ServerConfig{
-			Port:         getEnvInt("SERVER_PORT", 3000),
-			Env:          getEnv("SERVER_ENV", "development"),
-			AllowOrigins: getEnv("CORS_ALLOW_ORIGINS", "*"),
+			Port:               getEnvInt("SERVER_PORT", 3000),
+			Env:                getEnv("SERVER_ENV", "development"),
+			AllowOrigins:       getEnv("CORS_ALLOW_ORIGINS", "*"),
+			ShutdownTimeout:    getEnvDuration("SHUTDOWN_TIMEOUT", 15*time.Second),
+			ShutdownDrainDelay: getEnvDuration("SHUTDOWN_DRAIN_DELAY", 0),
+			TrustedProxies:     getEnvList("TRUSTED_PROXIES", ","),
+			ProxyHeader:        getEnv("PROXY_HEADER", "X-Forwarded-For"),
 		},
 		Log: LogConfig{
 			Level: getEnv("LOG_LEVEL", "info"),
 		},
+		Idempotency: IdempotencyConfig{
+			TTL:           getEnvDuration("IDEMPOTENCY_TTL", 24*time.Hour),
+			LockTTL:       getEnvDuration("IDEMPOTENCY_LOCK_TTL", 30*time.Second),
+			PurgeInterval: getEnvDuration("IDEMPOTENCY_PURGE_INTERVAL", 10*time.Minute),
+		},
+		Tracing: TracingConfig{
+			Exporter:    getEnv("TRACING_EXPORTER", "none"),
+			ServiceName: getEnv("OTEL_SERVICE_NAME", "spin-head-api"),
+			SampleRatio: getEnvFloat("TRACING_SAMPLE_RATIO", 1.0),
+		},
+		Health: HealthConfig{
+			CheckTimeout: getEnvDuration("HEALTH_CHECK_TIMEOUT", 2*time.Second),
+		},
+		I18n: I18nConfig{
+			DefaultLocale: getEnv("DEFAULT_LOCALE", "en"),
+		},
 	}
 
 	// Load game config
 	if err := viper.UnmarshalKey("game", &cfg.Game); err != nil {
-		log.Printf("[Config] Warning: Could not unmarshal game config: %v", err)
+		slog.Warn("could not unmarshal config", "component", "config", "key", "game", "error", err)
 	}
 
 	// Load pagination config
 	if err := viper.UnmarshalKey("pagination", &cfg.Pagination); err != nil {
-		log.Printf("[Config] Warning: Could not unmarshal pagination config: %v", err)
+		slog.Warn("could not unmarshal config", "component", "config", "key", "pagination", "error", err)
 	}
+	cfg.Pagination.CursorSecret = getEnv("CURSOR_SECRET", defaultCursorSecret)
 
 	// Load rewards config
 	if err := viper.UnmarshalKey("rewards", &cfg.Rewards); err != nil {
-		log.Printf("[Config] Warning: Could not unmarshal rewards config: %v", err)
+		slog.Warn("could not unmarshal config", "component", "config", "key", "rewards", "error", err)
 	}
 
 	// Load validation config
 	if err := viper.UnmarshalKey("validation", &cfg.Validation); err != nil {
-		log.Printf("[Config] Warning: Could not unmarshal validation config: %v", err)
+		slog.Warn("could not unmarshal config", "component", "config", "key", "validation", "error", err)
 	}
 
+	// Load rate limit config
+	if err := viper.UnmarshalKey("ratelimit", &cfg.RateLimit); err != nil {
+		slog.Warn("could not unmarshal config", "component", "config", "key", "ratelimit", "error", err)
+	}
+
+	// Load export config
+	if err := viper.UnmarshalKey("export", &cfg.Export); err != nil {
+		slog.Warn("could not unmarshal config", "component", "config", "key", "export", "error", err)
+	}
+
+	// Load streaks config
+	if err := viper.UnmarshalKey("streaks", &cfg.Streaks); err != nil {
+		slog.Warn("could not unmarshal config", "component", "config", "key", "streaks", "error", err)
+	}
+
+	// Load referrals config
+	if err := viper.UnmarshalKey("referrals", &cfg.Referrals); err != nil {
+		slog.Warn("could not unmarshal config", "component", "config", "key", "referrals", "error", err)
+	}
+
+	// Load players config
+	if err := viper.UnmarshalKey("players", &cfg.Players); err != nil {
+		slog.Warn("could not unmarshal config", "component", "config", "key", "players", "error", err)
+	}
+
+	// Load API versioning config (dates are RFC 3339)
+	if err := viper.UnmarshalKey("api", &cfg.API, viper.DecodeHook(mapstructure.StringToTimeHookFunc(time.RFC3339))); err != nil {
+		slog.Warn("could not unmarshal config", "component", "config", "key", "api", "error", err)
+	}
+
+	// Admin API key is a secret, so it only comes from the environment
+	cfg.Admin.APIKey = getEnv("ADMIN_API_KEY", "")
+
 	// Validate configuration
 	if err := validateConfig(cfg); err != nil {
-		log.Fatalf("[Config] Configuration validation failed: %v", err)
+		slog.Error("configuration validation failed", "component", "config", "error", err)
+		os.Exit(1)
 	}
 
 	config = cfg
-	log.Printf("[Config] ✓ Loaded configuration:")
-	log.Printf("[Config]   DB: %s:%d/%s (sslmode=%s)", cfg.DB.Host, cfg.DB.Port, cfg.DB.Database, cfg.DB.SSLMode)
-	log.Printf("[Config]   Server: Port=%d, Env=%s", cfg.Server.Port, cfg.Server.Env)
-	log.Printf("[Config]   Game: MaxDailySpins=%d, DistributionItems=%d", cfg.Game.Spin.MaxDailySpins, len(cfg.Game.Spin.Distribution))
-	log.Printf("[Config]   Pagination: DefaultLimit=%d, MaxLimit=%d", cfg.Pagination.DefaultLimit, cfg.Pagination.MaxLimit)
-	log.Printf("[Config]   Rewards: Checkpoints=%d", len(cfg.Rewards.Checkpoints))
+	slog.Info("loaded configuration",
+		"component", "config",
+		slog.Group("storage", "driver", cfg.Storage.Driver, "path", cfg.DB.Path),
+		slog.Group("db", "host", cfg.DB.Host, "port", cfg.DB.Port, "name", cfg.DB.Database, "sslmode", cfg.DB.SSLMode,
+			"max_open_conns", cfg.DB.MaxOpenConns, "statement_timeout", cfg.DB.StatementTimeout, "replicas", len(cfg.DB.ReplicaDSNs)),
+		slog.Group("server", "port", cfg.Server.Port, "env", cfg.Server.Env),
+		slog.Group("game", "max_daily_spins", cfg.Game.Spin.MaxDailySpins, "distribution_items", len(cfg.Game.Spin.Distribution)),
+		slog.Group("pagination", "default_limit", cfg.Pagination.DefaultLimit, "max_limit", cfg.Pagination.MaxLimit),
+		slog.Group("rewards", "checkpoints", len(cfg.Rewards.Checkpoints)),
+		slog.Group("ratelimit", "enabled", cfg.RateLimit.Enabled, "store", cfg.RateLimit.Store, "policies", len(cfg.RateLimit.Policies)),
+		slog.Group("log", "level", cfg.Log.Level),
+		slog.Group("i18n", "default_locale", cfg.I18n.DefaultLocale),
+		slog.Group("tracing", "exporter", cfg.Tracing.Exporter, "sample_ratio", cfg.Tracing.SampleRatio),
+	)
 
 	return cfg
 }
 
+// Validate re-checks a loaded configuration (used by the readiness probe)
+func Validate(cfg *Config) error {
+	if cfg == nil {
+		return fmt.Errorf("configuration not loaded")
+	}
+	return validateConfig(cfg)
+}
+
+// defaultCursorSecret is only accepted outside production
+const defaultCursorSecret = "development-cursor-secret"
+
 // validateConfig validates the loaded configuration
 func validateConfig(cfg *Config) error {
+	for i, proxy := range cfg.Server.TrustedProxies {
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			return fmt.Errorf("TRUSTED_PROXIES[%d] must be an IP or CIDR, got %q", i, proxy)
+		}
+	}
+
 	// Validate game config
 	if cfg.Game.Spin.MaxDailySpins <= 0 {
 		return fmt.Errorf("game.spin.max_daily_spins must be positive")
@@ -223,6 +486,189 @@ func validateConfig(cfg *Config) error {
 	if cfg.Pagination.DefaultLimit > cfg.Pagination.MaxLimit {
 		return fmt.Errorf("pagination.default_limit cannot be greater than max_limit")
 	}
+	if cfg.Server.Env == "production" && (cfg.Pagination.CursorSecret == defaultCursorSecret || len(cfg.Pagination.CursorSecret) < 32) {
+		return fmt.Errorf("CURSOR_SECRET must be set to at least 32 characters in production")
+	}
+
+	// Validate idempotency config
+	if cfg.Idempotency.TTL <= 0 {
+		return fmt.Errorf("IDEMPOTENCY_TTL must be positive")
+	}
+	if cfg.Idempotency.LockTTL <= 0 {
+		return fmt.Errorf("IDEMPOTENCY_LOCK_TTL must be positive")
+	}
+
+	// Validate tracing config
+	switch cfg.Tracing.Exporter {
+	case "none", "stdout", "otlp":
+	default:
+		return fmt.Errorf("TRACING_EXPORTER must be none, stdout or otlp")
+	}
+	if cfg.Tracing.SampleRatio < 0 || cfg.Tracing.SampleRatio > 1 {
+		return fmt.Errorf("TRACING_SAMPLE_RATIO must be between 0 and 1")
+	}
+
+	// Validate storage config
+	if cfg.Storage.Driver != StoragePostgres && cfg.Storage.Driver != StorageSQLite && cfg.Storage.Driver != StorageMemory {
+		return fmt.Errorf("STORAGE must be postgres, sqlite or memory")
+	}
+	if cfg.Storage.Driver == StorageSQLite && cfg.DB.Path == "" {
+		return fmt.Errorf("DB_PATH is required when STORAGE=sqlite")
+	}
+	if cfg.Storage.Driver == StorageSQLite && len(cfg.DB.ReplicaDSNs) > 0 {
+		return fmt.Errorf("DB_REPLICA_DSNS needs STORAGE=postgres")
+	}
+
+	// Validate database pool config
+	if cfg.DB.MaxOpenConns < 0 || cfg.DB.MaxIdleConns < 0 {
+		return fmt.Errorf("DB_MAX_OPEN_CONNS and DB_MAX_IDLE_CONNS must not be negative")
+	}
+	if cfg.DB.MaxOpenConns > 0 && cfg.DB.MaxIdleConns > cfg.DB.MaxOpenConns {
+		return fmt.Errorf("DB_MAX_IDLE_CONNS must not exceed DB_MAX_OPEN_CONNS")
+	}
+	if cfg.DB.StatementTimeout < 0 || cfg.DB.SlowQueryThreshold < 0 {
+		return fmt.Errorf("DB_STATEMENT_TIMEOUT and DB_SLOW_QUERY_THRESHOLD must not be negative")
+	}
+	if cfg.DB.ReadYourWritesWindow < 0 {
+		return fmt.Errorf("DB_READ_YOUR_WRITES_WINDOW must not be negative")
+	}
+	if cfg.DB.ConnectRetries < 0 {
+		return fmt.Errorf("DB_CONNECT_RETRIES must not be negative")
+	}
+	if cfg.DB.ConnectRetries > 0 && (cfg.DB.ConnectBackoff <= 0 || cfg.DB.ConnectMaxBackoff < cfg.DB.ConnectBackoff) {
+		return fmt.Errorf("DB_CONNECT_BACKOFF must be positive and not exceed DB_CONNECT_MAX_BACKOFF")
+	}
+
+	// Validate shutdown config
+	if cfg.Server.ShutdownTimeout <= 0 {
+		return fmt.Errorf("SHUTDOWN_TIMEOUT must be positive")
+	}
+	if cfg.Server.ShutdownDrainDelay < 0 {
+		return fmt.Errorf("SHUTDOWN_DRAIN_DELAY must not be negative")
+	}
+
+	// Validate health config
+	if cfg.Health.CheckTimeout <= 0 {
+		return fmt.Errorf("HEALTH_CHECK_TIMEOUT must be positive")
+	}
+
+	// Validate i18n config (the catalog itself is checked when it is loaded)
+	if cfg.I18n.DefaultLocale == "" {
+		return fmt.Errorf("DEFAULT_LOCALE must not be empty")
+	}
+
+	// Validate API versioning config
+	for name, deprecation := range map[string]DeprecationConfig{"api.legacy": cfg.API.Legacy, "api.v1": cfg.API.V1} {
+		if !deprecation.Sunset.IsZero() && deprecation.Sunset.Before(deprecation.DeprecatedAt) {
+			return fmt.Errorf("%s.sunset must not be before deprecated_at", name)
+		}
+		if !deprecation.Sunset.IsZero() && deprecation.DeprecatedAt.IsZero() {
+			return fmt.Errorf("%s.sunset needs deprecated_at", name)
+		}
+	}
+
+	// Validate export config
+	if _, err := time.LoadLocation(cfg.Export.Timezone); err != nil {
+		return fmt.Errorf("export.timezone %q is not a valid timezone", cfg.Export.Timezone)
+	}
+	if cfg.Export.BatchSize <= 0 {
+		return fmt.Errorf("export.batch_size must be positive")
+	}
+	if len(cfg.Export.SpinColumns) == 0 || len(cfg.Export.RewardColumns) == 0 {
+		return fmt.Errorf("export.spin_columns and export.reward_columns must not be empty")
+	}
+
+	// Validate streaks config
+	if _, err := time.LoadLocation(cfg.Streaks.Timezone); err != nil {
+		return fmt.Errorf("streaks.timezone %q is not a valid timezone", cfg.Streaks.Timezone)
+	}
+	if cfg.Streaks.DayStartsAt < 0 || cfg.Streaks.DayStartsAt >= 24*time.Hour {
+		return fmt.Errorf("streaks.day_starts_at must be between 0h and 24h")
+	}
+	if cfg.Streaks.MaxFreezes < 0 {
+		return fmt.Errorf("streaks.max_freezes must not be negative")
+	}
+	for i, reward := range cfg.Streaks.Rewards {
+		if reward.Day <= 0 {
+			return fmt.Errorf("streaks.rewards[%d].day must be positive", i)
+		}
+		switch reward.Type {
+		case "bonus_spins", "points", "freeze":
+			if reward.Amount <= 0 {
+				return fmt.Errorf("streaks.rewards[%d].amount must be positive", i)
+			}
+		case "points_multiplier":
+			if reward.Multiplier <= 1 || reward.Duration <= 0 {
+				return fmt.Errorf("streaks.rewards[%d] needs a multiplier above 1 and a positive duration", i)
+			}
+		default:
+			return fmt.Errorf("streaks.rewards[%d].type must be bonus_spins, points_multiplier, points or freeze", i)
+		}
+	}
+
+	// Validate referrals config
+	if cfg.Referrals.RewardPoints <= 0 {
+		return fmt.Errorf("referrals.reward_points must be positive")
+	}
+	if cfg.Referrals.MilestoneSpins < 0 || cfg.Referrals.MilestonePoints < 0 {
+		return fmt.Errorf("referrals.milestone_spins and referrals.milestone_points must not be negative")
+	}
+	if cfg.Referrals.MilestoneSpins == 0 && cfg.Referrals.MilestonePoints == 0 {
+		return fmt.Errorf("referrals needs milestone_spins or milestone_points")
+	}
+	if cfg.Referrals.MaxPerReferrerPerDay < 0 || cfg.Referrals.MaxPerIPPerDay < 0 {
+		return fmt.Errorf("referrals daily caps must not be negative")
+	}
+	if cfg.Referrals.SummaryLimit <= 0 {
+		return fmt.Errorf("referrals.summary_limit must be positive")
+	}
+
+	// Validate players config
+	if cfg.Players.RenameCooldown < 0 || cfg.Players.NicknameGracePeriod < 0 {
+		return fmt.Errorf("players.rename_cooldown and players.nickname_grace_period must not be negative")
+	}
+	if cfg.Players.NicknameReleaseInterval <= 0 {
+		return fmt.Errorf("players.nickname_release_interval must be positive")
+	}
+	if cfg.Players.UpdateMaxAttempts <= 0 {
+		return fmt.Errorf("players.update_max_attempts must be positive")
+	}
+	if cfg.Players.UpdateRetryBackoff < 0 {
+		return fmt.Errorf("players.update_retry_backoff must not be negative")
+	}
+	if cfg.Admin.APIKey != "" && len(cfg.Admin.APIKey) < 32 {
+		return fmt.Errorf("ADMIN_API_KEY must be at least 32 characters")
+	}
+
+	// Validate rate limit config
+	if cfg.RateLimit.Enabled {
+		if cfg.RateLimit.Store != "memory" && cfg.RateLimit.Store != "postgres" {
+			return fmt.Errorf("ratelimit.store must be memory or postgres")
+		}
+		if cfg.RateLimit.Store == "postgres" && cfg.Storage.Driver != StoragePostgres {
+			return fmt.Errorf("ratelimit.store postgres needs STORAGE=postgres")
+		}
+		for i, policy := range cfg.RateLimit.Policies {
+			if policy.Name == "" {
+				return fmt.Errorf("ratelimit.policies[%d].name is required", i)
+			}
+			if len(policy.Routes) == 0 {
+				return fmt.Errorf("ratelimit.policies[%d].routes must not be empty", i)
+			}
+			if policy.Key != "ip" && policy.Key != "player" && policy.Key != "token" {
+				return fmt.Errorf("ratelimit.policies[%d].key must be ip, player or token", i)
+			}
+			if policy.Algorithm != "token_bucket" && policy.Algorithm != "sliding_window" {
+				return fmt.Errorf("ratelimit.policies[%d].algorithm must be token_bucket or sliding_window", i)
+			}
+			if policy.Limit <= 0 {
+				return fmt.Errorf("ratelimit.policies[%d].limit must be positive", i)
+			}
+			if policy.Window <= 0 {
+				return fmt.Errorf("ratelimit.policies[%d].window must be positive", i)
+			}
+		}
+	}
 
 	return nil
 }
@@ -246,17 +692,49 @@ func getEnvInt(key string, defaultVal int) int {
 	return defaultVal
 }
 
+func getEnvFloat(key string, defaultVal float64) float64 {
+	valStr := os.Getenv(key)
+	if valStr != "" {
+		if val, err := strconv.ParseFloat(valStr, 64); err == nil {
+			return val
+		}
+	}
+	return defaultVal
+}
+
+// getEnvList splits a sep-separated variable, dropping empty entries
+func getEnvList(key, sep string) []string {
+	var values []string
+	for _, v := range strings.Split(os.Getenv(key), sep) {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func getEnvDuration(key string, defaultVal time.Duration) time.Duration {
+	valStr := os.Getenv(key)
+	if valStr != "" {
+		if val, err := time.ParseDuration(valStr); err == nil {
+			return val
+		}
+	}
+	return defaultVal
+}
+
 // GetConfig returns the loaded configuration
 func GetConfig() *Config {
 	if config == nil {
-		log.Fatal("[Config] Configuration not initialized. Call Init() first")
+		slog.Error("configuration not initialized, call Init() first", "component", "config")
+		os.Exit(1)
 	}
 	return config
 }
 
 // GetDSN returns the PostgreSQL Data Source Name (connection string)
 func (c *DBConfig) GetDSN() string {
-	return fmt.Sprintf(
+	dsn := fmt.Sprintf(
 		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
 		c.Host,
 		c.Port,
@@ -265,4 +743,30 @@ func (c *DBConfig) GetDSN() string {
 		c.Database,
 		c.SSLMode,
 	)
+	return c.withSessionParams(dsn)
+}
+
+// GetReplicaDSNs returns the replica DSNs with the same session parameters as the primary
+func (c *DBConfig) GetReplicaDSNs() []string {
+	dsns := make([]string, 0, len(c.ReplicaDSNs))
+	for _, dsn := range c.ReplicaDSNs {
+		dsns = append(dsns, c.withSessionParams(dsn))
+	}
+	return dsns
+}
+
+// withSessionParams appends runtime parameters to a keyword/value or URL DSN
+func (c *DBConfig) withSessionParams(dsn string) string {
+	if c.StatementTimeout <= 0 {
+		return dsn
+	}
+	param := fmt.Sprintf("statement_timeout=%d", c.StatementTimeout.Milliseconds())
+
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		if strings.Contains(dsn, "?") {
+			return dsn + "&" + param
+		}
+		return dsn + "?" + param
+	}
+	return dsn + " " + param
 }
@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 // SpinDistributionItem represents a weighted spin outcome
 type SpinDistributionItem struct {
 	Points int `mapstructure:"points"`
@@ -23,4 +25,86 @@ type PaginationConfig struct {
 	DefaultLimit  int `mapstructure:"default_limit"`
 	MaxLimit      int `mapstructure:"max_limit"`
 	DefaultOffset int `mapstructure:"default_offset"`
+	// CursorSecret signs pagination cursors (CURSOR_SECRET); shared by all instances
+	CursorSecret string `mapstructure:"-"`
+}
+
+// ExportConfig holds history export settings (export.yaml)
+type ExportConfig struct {
+	// Timezone is the IANA zone timestamps are written in unless a request overrides it
+	Timezone  string `mapstructure:"timezone"`
+	BatchSize int    `mapstructure:"batch_size"`
+	// SpinColumns and RewardColumns are the default column sets, in output order
+	SpinColumns   []string `mapstructure:"spin_columns"`
+	RewardColumns []string `mapstructure:"reward_columns"`
+}
+
+// StreaksConfig holds login streak settings (streaks.yaml)
+type StreaksConfig struct {
+	// Timezone and DayStartsAt define the day boundary, e.g. 04:00 Asia/Bangkok
+	Timezone    string        `mapstructure:"timezone"`
+	DayStartsAt time.Duration `mapstructure:"day_starts_at"`
+	// MaxFreezes caps how many streak freezes a player can hold (0 = no cap)
+	MaxFreezes int                  `mapstructure:"max_freezes"`
+	Rewards    []StreakRewardConfig `mapstructure:"rewards"`
+}
+
+// StreakRewardConfig is granted on the Nth consecutive day (every N days when repeat)
+type StreakRewardConfig struct {
+	Day        int           `mapstructure:"day"`
+	Type       string        `mapstructure:"type"` // bonus_spins, points_multiplier, points or freeze
+	Amount     int           `mapstructure:"amount"`
+	Multiplier float64       `mapstructure:"multiplier"`
+	Duration   time.Duration `mapstructure:"duration"`
+	Repeat     bool          `mapstructure:"repeat"`
+}
+
+// ReferralsConfig holds referral program settings (referrals.yaml)
+type ReferralsConfig struct {
+	// RewardPoints are paid to the referrer once the referee reaches the milestone
+	RewardPoints int `mapstructure:"reward_points"`
+	// Milestone: MilestoneSpins spins or MilestonePoints total points, whichever comes first (0 disables one)
+	MilestoneSpins  int `mapstructure:"milestone_spins"`
+	MilestonePoints int `mapstructure:"milestone_points"`
+	// Anti-abuse caps on new referrals within any 24 hours (0 = no cap)
+	MaxPerReferrerPerDay int `mapstructure:"max_per_referrer_per_day"`
+	MaxPerIPPerDay       int `mapstructure:"max_per_ip_per_day"`
+	// SummaryLimit is how many recent referrals GET /players/:id/referrals lists
+	SummaryLimit int `mapstructure:"summary_limit"`
+}
+
+// PlayersConfig holds player account settings (players.yaml)
+type PlayersConfig struct {
+	// RenameCooldown is the minimum time between nickname changes
+	RenameCooldown time.Duration `mapstructure:"rename_cooldown"`
+	// NicknameGracePeriod keeps a deleted player's nickname reserved before it is released
+	NicknameGracePeriod time.Duration `mapstructure:"nickname_grace_period"`
+	// NicknameReleaseInterval is how often due nicknames are released
+	NicknameReleaseInterval time.Duration `mapstructure:"nickname_release_interval"`
+	// UpdateMaxAttempts bounds how often a player update is retried after a concurrent write
+	UpdateMaxAttempts int `mapstructure:"update_max_attempts"`
+	// UpdateRetryBackoff is the wait before the first retry (grows linearly)
+	UpdateRetryBackoff time.Duration `mapstructure:"update_retry_backoff"`
+}
+
+// APIConfig holds API versioning settings (api.yaml)
+type APIConfig struct {
+	// Legacy deprecates the unversioned aliases of v1
+	Legacy DeprecationConfig `mapstructure:"legacy"`
+	// V1 deprecates /v1 once a newer version replaces it
+	V1 DeprecationConfig `mapstructure:"v1"`
+}
+
+// DeprecationConfig announces that a version of the API is deprecated; a
+// zero DeprecatedAt means it is not
+type DeprecationConfig struct {
+	DeprecatedAt time.Time `mapstructure:"deprecated_at"`
+	// Sunset is when the version stops being served (zero when not planned)
+	Sunset time.Time `mapstructure:"sunset"`
+}
+
+// AdminConfig holds admin API settings
+type AdminConfig struct {
+	// APIKey must be sent as X-Admin-Key (ADMIN_API_KEY); admin endpoints are disabled when empty
+	APIKey string `mapstructure:"-"`
 }
@@ -1,57 +1,141 @@
 package database
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
+	"time"
 
 	"backend/internal/infrastructure/config"
+	"backend/internal/infrastructure/logger"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
 type Database struct {
-	db *gorm.DB
+	db       *gorm.DB
+	replicas []*gorm.DB
 }
 
-func New(cfg *config.DBConfig) (*Database, error) {
-	dsn := cfg.GetDSN()
+// New connects to the primary, retrying with exponential backoff so the API
+// survives Postgres starting after it (e.g. in docker-compose). With
+// cfg.Driver sqlite it opens the file at cfg.Path instead (no replicas).
+func New(ctx context.Context, cfg *config.DBConfig) (*Database, error) {
+	if cfg.Driver == config.StorageSQLite {
+		return newSQLite(ctx, cfg)
+	}
+
+	slog.Info("connecting to PostgreSQL", "component", "database", "host", cfg.Host, "port", cfg.Port, "name", cfg.Database)
+
+	db, err := openWithRetry(ctx, cfg.GetDSN(), cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Replicas are opened lazily (no startup ping); the router falls back to
+	// the primary while a replica is unreachable
+	var replicas []*gorm.DB
+	for i, dsn := range cfg.GetReplicaDSNs() {
+		replica, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+			Logger:               logger.NewGormLogger(cfg.SlowQueryThreshold),
+			DisableAutomaticPing: true,
+		})
+		if err != nil {
+			slog.Warn("skipping read replica", "component", "database", "replica", i, "error", err)
+			continue
+		}
+		if err := configurePool(replica, cfg); err != nil {
+			return nil, err
+		}
+		replicas = append(replicas, replica)
+	}
+
+	slog.Info("connected to PostgreSQL", "component", "database", "replicas", len(replicas))
+	return &Database{db: db, replicas: replicas}, nil
+}
 
-	log.Printf("[Database] Connecting to PostgreSQL: %s:%d/%s", cfg.Host, cfg.Port, cfg.Database)
+// openWithRetry opens and pings dsn, retrying up to cfg.ConnectRetries times
+func openWithRetry(ctx context.Context, dsn string, cfg *config.DBConfig) (*gorm.DB, error) {
+	backoff := cfg.ConnectBackoff
+	for attempt := 0; ; attempt++ {
+		db, err := open(ctx, dsn, cfg)
+		if err == nil {
+			return db, nil
+		}
+		if attempt >= cfg.ConnectRetries {
+			return nil, err
+		}
 
-	// Configure GORM logger
-	gormLogger := logger.Default
-	if cfg.SSLMode == "require" {
-		gormLogger = logger.Default.LogMode(logger.Silent) // Reduce log noise in production
+		slog.Warn("database not reachable, retrying",
+			"component", "database", "attempt", attempt+1, "max_retries", cfg.ConnectRetries, "backoff", backoff, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("database connect cancelled: %w", ctx.Err())
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > cfg.ConnectMaxBackoff {
+			backoff = cfg.ConnectMaxBackoff
+		}
 	}
+}
 
+// open opens a GORM connection, applies the pool settings and pings it
+func open(ctx context.Context, dsn string, cfg *config.DBConfig) (*gorm.DB, error) {
+	// SQL lines use the request-scoped logger from ctx; verbosity follows LOG_LEVEL
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: gormLogger,
+		Logger: logger.NewGormLogger(cfg.SlowQueryThreshold),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	// Test the connection
-	sqlDB, err := db.DB()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	if err := configurePool(db, cfg); err != nil {
+		return nil, err
 	}
 
-	if err := sqlDB.Ping(); err != nil {
+	sqlDB, _ := db.DB()
+	if err := sqlDB.PingContext(ctx); err != nil {
+		sqlDB.Close()
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	log.Println("[Database] ✓ Connected to PostgreSQL successfully")
-	return &Database{db: db}, nil
+	return db, nil
+}
+
+// configurePool applies the connection pool settings
+func configurePool(db *gorm.DB, cfg *config.DBConfig) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	}
+
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	return nil
 }
 
 func (d *Database) DB() *gorm.DB {
 	return d.db
 }
 
+// Replicas returns the read replica connections (may be empty)
+func (d *Database) Replicas() []*gorm.DB {
+	return d.replicas
+}
+
 func (d *Database) Close() error {
+	for _, replica := range d.replicas {
+		if sqlDB, err := replica.DB(); err == nil {
+			sqlDB.Close()
+		}
+	}
+
 	sqlDB, err := d.db.DB()
 	if err != nil {
 		return err
@@ -0,0 +1,269 @@
+package database
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"backend/internal/shared/cursor"
+
+	"gorm.io/gorm"
+)
+
+// KeyType tells how a sort key value is stored in a cursor
+type KeyType int
+
+const (
+	KeyTime KeyType = iota
+	KeyInt
+	KeyString
+)
+
+// KeyColumn is one column of a keyset sort; the last column must be unique (e.g. id)
+type KeyColumn struct {
+	Column string // qualified column, e.g. "spin_logs.created_at"
+	Type   KeyType
+	Desc   bool
+}
+
+// Keyset describes a paginated listing: its scope name and sort columns
+type Keyset struct {
+	Scope   string
+	Columns []KeyColumn
+}
+
+// PageRequest is one page of a keyset listing
+type PageRequest struct {
+	Limit  int
+	Cursor string
+	Filter string // canonical filters, bound into issued cursors
+}
+
+// Page is a page of rows plus cursors in both directions
+type Page[T any] struct {
+	Items      []T
+	NextCursor string
+	PrevCursor string
+	HasMore    bool // rows exist after this page
+	HasPrev    bool // rows exist before this page
+}
+
+// Sort returns the canonical sort description bound into cursors
+func (k Keyset) Sort() string {
+	parts := make([]string, len(k.Columns))
+	for i, col := range k.Columns {
+		dir := "asc"
+		if col.Desc {
+			dir = "desc"
+		}
+		parts[i] = col.Column + ":" + dir
+	}
+	return strings.Join(parts, ",")
+}
+
+// Position is a verified starting point decoded from a page request
+type Position struct {
+	after     []any // nil on the first page
+	direction cursor.Direction
+}
+
+// Position verifies the request cursor against this keyset. Call it before
+// querying so invalid cursors are reported without touching the database.
+func (k Keyset) Position(codec *cursor.Codec, req PageRequest) (Position, error) {
+	if req.Cursor == "" {
+		return Position{direction: cursor.Next}, nil
+	}
+
+	c, err := codec.Decode(req.Cursor)
+	if err != nil {
+		return Position{}, err
+	}
+	if err := c.Check(k.Scope, req.Filter, k.Sort()); err != nil {
+		return Position{}, err
+	}
+	after, err := k.parseKey(c.Key)
+	if err != nil {
+		return Position{}, err
+	}
+	return Position{after: after, direction: c.Direction}, nil
+}
+
+// Paginate runs query as a keyset page starting at pos. keyOf returns the
+// sort key values of a row in Columns order (time.Time, int or string).
+// Issued cursors are signed by codec and bound to the scope, sort and filters.
+func Paginate[T any](query *gorm.DB, codec *cursor.Codec, ks Keyset, req PageRequest, pos Position, keyOf func(*T) []any) (*Page[T], error) {
+	after := pos.after
+
+	// Going backwards flips every comparison and the order, then the rows are reversed
+	reverse := pos.direction == cursor.Prev
+	if after != nil {
+		cond, args := ks.seek(after, reverse)
+		query = query.Where(cond, args...)
+	}
+	for _, col := range ks.Columns {
+		desc := col.Desc != reverse
+		if desc {
+			query = query.Order(col.Column + " DESC")
+		} else {
+			query = query.Order(col.Column + " ASC")
+		}
+	}
+
+	// Fetch limit+1 to check if there are more results
+	var rows []T
+	if err := query.Limit(req.Limit + 1).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return newPage(codec, ks, req, pos, rows, keyOf), nil
+}
+
+// PaginateSlice is Paginate over rows already in memory (in any order), for
+// repositories without SQL. Pages and cursors match Paginate's, so a cursor
+// issued by one is accepted by the other.
+func PaginateSlice[T any](rows []T, codec *cursor.Codec, ks Keyset, req PageRequest, pos Position, keyOf func(*T) []any) *Page[T] {
+	reverse := pos.direction == cursor.Prev
+	sorted := make([]T, 0, len(rows))
+	for i := range rows {
+		if pos.after == nil || ks.compare(keyOf(&rows[i]), pos.after, reverse) > 0 {
+			sorted = append(sorted, rows[i])
+		}
+	}
+	slices.SortFunc(sorted, func(a, b T) int {
+		return ks.compare(keyOf(&a), keyOf(&b), reverse)
+	})
+
+	if len(sorted) > req.Limit+1 {
+		sorted = sorted[:req.Limit+1]
+	}
+	return newPage(codec, ks, req, pos, sorted, keyOf)
+}
+
+// newPage turns up to limit+1 rows in seek order into a page with cursors
+func newPage[T any](codec *cursor.Codec, ks Keyset, req PageRequest, pos Position, rows []T, keyOf func(*T) []any) *Page[T] {
+	reverse := pos.direction == cursor.Prev
+	more := len(rows) > req.Limit
+	if more {
+		rows = rows[:req.Limit]
+	}
+	if reverse {
+		slices.Reverse(rows)
+	}
+
+	page := &Page[T]{Items: rows}
+	if reverse {
+		page.HasPrev = more
+		page.HasMore = true // we came back from a later page
+	} else {
+		page.HasMore = more
+		page.HasPrev = pos.after != nil
+	}
+
+	if len(rows) > 0 {
+		if page.HasMore {
+			page.NextCursor = ks.encode(codec, req.Filter, cursor.Next, keyOf(&rows[len(rows)-1]))
+		}
+		if page.HasPrev {
+			page.PrevCursor = ks.encode(codec, req.Filter, cursor.Prev, keyOf(&rows[0]))
+		}
+	}
+	return page
+}
+
+// compare orders two sort keys the way the seek order does (reversed when
+// going backwards)
+func (k Keyset) compare(a, b []any, reverse bool) int {
+	for i, col := range k.Columns {
+		var c int
+		switch av := a[i].(type) {
+		case time.Time:
+			c = av.Compare(b[i].(time.Time))
+		case int:
+			c = cmp.Compare(av, b[i].(int))
+		default:
+			c = strings.Compare(fmt.Sprint(av), fmt.Sprint(b[i]))
+		}
+		if col.Desc != reverse {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// seek builds the lexicographic "row after key" condition:
+// (c1 > v1) OR (c1 = v1 AND c2 > v2) OR ... with > or < per column direction
+func (k Keyset) seek(key []any, reverse bool) (string, []any) {
+	var clauses []string
+	var args []any
+	for i, col := range k.Columns {
+		var parts []string
+		for j := 0; j < i; j++ {
+			parts = append(parts, k.Columns[j].Column+" = ?")
+			args = append(args, key[j])
+		}
+		op := ">"
+		if col.Desc != reverse {
+			op = "<"
+		}
+		parts = append(parts, col.Column+" "+op+" ?")
+		args = append(args, key[i])
+		clauses = append(clauses, "("+strings.Join(parts, " AND ")+")")
+	}
+	return "(" + strings.Join(clauses, " OR ") + ")", args
+}
+
+func (k Keyset) encode(codec *cursor.Codec, filter string, direction cursor.Direction, values []any) string {
+	key := make([]string, len(values))
+	for i, v := range values {
+		switch v := v.(type) {
+		case time.Time:
+			key[i] = v.UTC().Format(time.RFC3339Nano)
+		case int:
+			key[i] = strconv.Itoa(v)
+		default:
+			key[i] = fmt.Sprint(v)
+		}
+	}
+	return codec.Encode(cursor.Cursor{
+		Scope:     k.Scope,
+		Filter:    filter,
+		Sort:      k.Sort(),
+		Direction: direction,
+		Key:       key,
+	})
+}
+
+func (k Keyset) parseKey(key []string) ([]any, error) {
+	if len(key) != len(k.Columns) {
+		return nil, cursor.ErrInvalid
+	}
+
+	values := make([]any, len(key))
+	for i, col := range k.Columns {
+		switch col.Type {
+		case KeyTime:
+			t, err := time.Parse(time.RFC3339Nano, key[i])
+			if err != nil {
+				return nil, cursor.ErrInvalid
+			}
+			values[i] = t
+		case KeyInt:
+			n, err := strconv.Atoi(key[i])
+			if err != nil {
+				return nil, cursor.ErrInvalid
+			}
+			values[i] = n
+		case KeyString:
+			values[i] = key[i]
+		default:
+			return nil, errors.New("unknown key type")
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,150 @@
+package database_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"backend/internal/infrastructure/database"
+	"backend/internal/shared/contracttest"
+	"backend/internal/shared/cursor"
+)
+
+type item struct {
+	ID    string
+	Score int
+}
+
+// items sort by score descending, ties broken by id ascending:
+// a b c | d e f | g in pages of three
+var items = []item{{"g", 1}, {"c", 4}, {"e", 3}, {"a", 5}, {"f", 2}, {"b", 5}, {"d", 3}}
+
+var itemKeyset = database.Keyset{
+	Scope: "test.items",
+	Columns: []database.KeyColumn{
+		{Column: "score", Type: database.KeyInt, Desc: true},
+		{Column: "id", Type: database.KeyString},
+	},
+}
+
+func itemKey(it *item) []any { return []any{it.Score, it.ID} }
+
+// openItems returns a database with items in an items table
+func openItems(t *testing.T) *gorm.DB {
+	t.Helper()
+	db := contracttest.OpenSQLite(t)
+	if err := db.Exec("CREATE TABLE items (id TEXT PRIMARY KEY, score INTEGER NOT NULL)").Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Table("items").Create(&items).Error; err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func ids(page *database.Page[item]) string {
+	var s []string
+	for _, it := range page.Items {
+		s = append(s, it.ID)
+	}
+	return strings.Join(s, "")
+}
+
+func TestPaginateBothWays(t *testing.T) {
+	db := openItems(t)
+	codec := cursor.NewCodec("secret")
+
+	paginators := map[string]func(req database.PageRequest, pos database.Position) (*database.Page[item], error){
+		"sql": func(req database.PageRequest, pos database.Position) (*database.Page[item], error) {
+			return database.Paginate(db.Table("items"), codec, itemKeyset, req, pos, itemKey)
+		},
+		"slice": func(req database.PageRequest, pos database.Position) (*database.Page[item], error) {
+			return database.PaginateSlice(slices.Clone(items), codec, itemKeyset, req, pos, itemKey), nil
+		},
+	}
+	for name, paginate := range paginators {
+		t.Run(name, func(t *testing.T) {
+			page := func(token string) *database.Page[item] {
+				t.Helper()
+				req := database.PageRequest{Limit: 3, Cursor: token, Filter: "all"}
+				pos, err := itemKeyset.Position(codec, req)
+				if err != nil {
+					t.Fatal(err)
+				}
+				p, err := paginate(req, pos)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return p
+			}
+
+			steps := []struct {
+				move             string // how the page was reached
+				want             string
+				hasPrev, hasMore bool
+			}{
+				{"first", "abc", false, true},
+				{"next", "def", true, true},
+				{"next", "g", true, false},
+				{"prev", "def", true, true},
+				{"prev", "abc", false, true},
+			}
+			var current *database.Page[item]
+			for i, step := range steps {
+				switch step.move {
+				case "first":
+					current = page("")
+				case "next":
+					current = page(current.NextCursor)
+				case "prev":
+					current = page(current.PrevCursor)
+				}
+				if got := ids(current); got != step.want || current.HasPrev != step.hasPrev || current.HasMore != step.hasMore {
+					t.Fatalf("step %d (%s): items %q prev %v more %v; want %q %v %v",
+						i, step.move, got, current.HasPrev, current.HasMore, step.want, step.hasPrev, step.hasMore)
+				}
+				if (current.PrevCursor != "") != step.hasPrev || (current.NextCursor != "") != step.hasMore {
+					t.Fatalf("step %d (%s): cursors prev %q next %q do not match prev %v more %v",
+						i, step.move, current.PrevCursor, current.NextCursor, step.hasPrev, step.hasMore)
+				}
+			}
+		})
+	}
+}
+
+func TestPositionRejectsForeignCursors(t *testing.T) {
+	db := openItems(t)
+	codec := cursor.NewCodec("secret")
+
+	// A real cursor from the first page of "all"
+	first, err := database.Paginate(db.Table("items"), codec, itemKeyset, database.PageRequest{Limit: 3, Filter: "all"}, database.Position{}, itemKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKeyset := database.Keyset{Scope: "test.other", Columns: itemKeyset.Columns}
+
+	tests := []struct {
+		name    string
+		decoder *cursor.Codec
+		ks      database.Keyset
+		req     database.PageRequest
+		want    error
+	}{
+		{"other filter", codec, itemKeyset, database.PageRequest{Limit: 3, Cursor: first.NextCursor, Filter: "none"}, cursor.ErrMismatch},
+		{"other listing", codec, otherKeyset, database.PageRequest{Limit: 3, Cursor: first.NextCursor, Filter: "all"}, cursor.ErrMismatch},
+		{"other secret", cursor.NewCodec("other-secret"), itemKeyset, database.PageRequest{Limit: 3, Cursor: first.NextCursor, Filter: "all"}, cursor.ErrInvalid},
+		{"key of another shape", codec, itemKeyset, database.PageRequest{Limit: 3, Cursor: codec.Encode(cursor.Cursor{
+			Scope: itemKeyset.Scope, Filter: "all", Sort: itemKeyset.Sort(), Direction: cursor.Next, Key: []string{"not-a-number", "a"},
+		}), Filter: "all"}, cursor.ErrInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.ks.Position(tt.decoder, tt.req); !errors.Is(err, tt.want) {
+				t.Fatalf("Position = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
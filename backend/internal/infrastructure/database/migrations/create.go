@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+var migrationNamePattern = regexp.MustCompile(`^[a-z0-9]+(_[a-z0-9]+)*$`)
+
+// Create writes an empty timestamped up/down migration pair into dir, and a
+// matching pair into dir/sqlite for the SQLite version of the migration
+func Create(dir, name string, now time.Time) (paths []string, err error) {
+	if !migrationNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid migration name %q: use lower_snake_case", name)
+	}
+
+	version := now.UTC().Format("20060102150405")
+	for _, target := range []string{dir, filepath.Join(dir, "sqlite")} {
+		paths = append(paths,
+			filepath.Join(target, fmt.Sprintf("%s_%s.up.sql", version, name)),
+			filepath.Join(target, fmt.Sprintf("%s_%s.down.sql", version, name)),
+		)
+	}
+
+	for _, path := range paths {
+		// O_EXCL: never overwrite an existing migration
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", path, err)
+		}
+		if _, err := fmt.Fprintf(f, "-- %s\n", filepath.Base(path)); err != nil {
+			f.Close()
+			return nil, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		if err := f.Close(); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	return paths, nil
+}
@@ -2,56 +2,80 @@ package migrations
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 
-	"backend/internal/infrastructure/config"
+	"backend/internal/infrastructure/database"
+	sqlmigrations "backend/migrations"
 
 	"github.com/golang-migrate/migrate/v4"
+	migratedb "github.com/golang-migrate/migrate/v4/database"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/golang-migrate/migrate/v4/database/sqlite"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
 	"gorm.io/gorm"
 )
 
 // Migrator handles database migrations
 type Migrator struct {
 	migrate *migrate.Migrate
+	source  source.Driver // separate handle used to list and read migrations
 }
 
-// NewMigrator creates a new migrator instance
-func NewMigrator(db *gorm.DB, cfg *config.Config) (*Migrator, error) {
+// NewMigrator creates a migrator backed by the SQL files embedded in the
+// binary; SQLite databases get the SQLite set from migrations/sqlite
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
 	// Get underlying sql.DB from GORM
 	sqlDB, err := db.DB()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get sql.DB from GORM: %w", err)
 	}
 
-	// Create postgres driver instance
-	driver, err := postgres.WithInstance(sqlDB, &postgres.Config{
-		MigrationsTable: "schema_migrations",
-	})
+	// Create the driver instance and pick the matching migration set
+	var (
+		driver  migratedb.Driver
+		name    string
+		setPath = "."
+	)
+	if database.IsSQLite(db) {
+		name, setPath = "sqlite", "sqlite"
+		driver, err = sqlite.WithInstance(sqlDB, &sqlite.Config{
+			MigrationsTable: "schema_migrations",
+		})
+	} else {
+		name = "postgres"
+		driver, err = postgres.WithInstance(sqlDB, &postgres.Config{
+			MigrationsTable: "schema_migrations",
+		})
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to create postgres driver: %w", err)
+		return nil, fmt.Errorf("failed to create %s driver: %w", name, err)
 	}
 
-	// Create migrate instance with file source
-	migrationPath := "file://./migrations"
-	if cfg.Server.Env == "production" {
-		migrationPath = "file://./migrations"
+	// Create migrate instance with the embedded source
+	src, err := iofs.New(sqlmigrations.FS, setPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open embedded migrations: %w", err)
 	}
-
-	m, err := migrate.NewWithDatabaseInstance(migrationPath, "postgres", driver)
+	m, err := migrate.NewWithInstance("iofs", src, name, driver)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create migrate instance: %w", err)
 	}
 
+	planSource, err := iofs.New(sqlmigrations.FS, setPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open embedded migrations: %w", err)
+	}
+
 	return &Migrator{
 		migrate: m,
+		source:  planSource,
 	}, nil
 }
 
 // Up runs all pending migrations
 func (m *Migrator) Up() error {
-	log.Println("[Migrator] Running migrations up...")
+	slog.Info("running migrations up", "component", "migrator")
 
 	if err := m.migrate.Up(); err != nil && err != migrate.ErrNoChange {
 		return fmt.Errorf("migration up failed: %w", err)
@@ -59,13 +83,13 @@ func (m *Migrator) Up() error {
 
 	version, dirty, err := m.migrate.Version()
 	if err != nil {
-		log.Printf("[Migrator] ✓ Migrations completed (version check failed: %v)", err)
+		slog.Warn("migrations completed, version check failed", "component", "migrator", "error", err)
 	} else {
 		status := "clean"
 		if dirty {
 			status = "dirty"
 		}
-		log.Printf("[Migrator] ✓ Migrations completed (version: %d, status: %s)", version, status)
+		slog.Info("migrations completed", "component", "migrator", "version", version, "status", status)
 	}
 
 	return nil
@@ -73,7 +97,7 @@ func (m *Migrator) Up() error {
 
 // Down rolls back the last migration
 func (m *Migrator) Down() error {
-	log.Println("[Migrator] Rolling back last migration...")
+	slog.Info("rolling back last migration", "component", "migrator")
 
 	if err := m.migrate.Steps(-1); err != nil {
 		return fmt.Errorf("migration down failed: %w", err)
@@ -81,13 +105,13 @@ func (m *Migrator) Down() error {
 
 	version, dirty, err := m.migrate.Version()
 	if err != nil {
-		log.Printf("[Migrator] ✓ Rollback completed (version check failed: %v)", err)
+		slog.Warn("rollback completed, version check failed", "component", "migrator", "error", err)
 	} else {
 		status := "clean"
 		if dirty {
 			status = "dirty"
 		}
-		log.Printf("[Migrator] ✓ Rollback completed (version: %d, status: %s)", version, status)
+		slog.Info("rollback completed", "component", "migrator", "version", version, "status", status)
 	}
 
 	return nil
@@ -95,31 +119,21 @@ func (m *Migrator) Down() error {
 
 // Reset drops all tables and runs all migrations again
 func (m *Migrator) Reset() error {
-	log.Println("[Migrator] Resetting database...")
-
-	// Get current version
-	version, _, err := m.migrate.Version()
-	if err != nil {
-		log.Printf("[Migrator] Warning: Could not get current version: %v", err)
-		version = 4 // Assume max version if we can't get it
-	}
+	slog.Info("resetting database", "component", "migrator")
 
-	// Rollback all migrations (4 steps down)
-	if version > 0 {
-		log.Printf("[Migrator] Rolling back %d migrations...", version)
-		if err := m.migrate.Steps(-int(version)); err != nil {
-			return fmt.Errorf("migration reset (down) failed: %w", err)
-		}
+	// Rollback every applied migration (versions may be timestamps, so no step arithmetic)
+	if err := m.migrate.Down(); err != nil && err != migrate.ErrNoChange {
+		return fmt.Errorf("migration reset (down) failed: %w", err)
 	}
 
-	log.Println("[Migrator] ✓ All migrations rolled back, running migrations up...")
+	slog.Info("all migrations rolled back, running migrations up", "component", "migrator")
 
 	// Run all migrations up
 	if err := m.Up(); err != nil {
 		return fmt.Errorf("migration reset (up) failed: %w", err)
 	}
 
-	log.Println("[Migrator] ✓ Database reset completed")
+	slog.Info("database reset completed", "component", "migrator")
 	return nil
 }
 
@@ -137,13 +151,23 @@ func (m *Migrator) createSchemaMigrationsTable() error {
 
 	// For now, let's try to force the version to 0 and then run up
 	if err := m.migrate.Force(0); err != nil {
-		log.Printf("[Migrator] Warning: Could not force version to 0: %v", err)
+		slog.Warn("could not force version to 0", "component", "migrator", "error", err)
 		// Continue anyway
 	}
 
 	return nil
 }
 
+// Goto migrates up or down to the given version
+func (m *Migrator) Goto(version uint) error {
+	slog.Info("migrating to version", "component", "migrator", "version", version)
+
+	if err := m.migrate.Migrate(version); err != nil && err != migrate.ErrNoChange {
+		return fmt.Errorf("migration goto failed: %w", err)
+	}
+	return nil
+}
+
 // Version returns the current migration version
 func (m *Migrator) Version() (uint, bool, error) {
 	return m.migrate.Version()
@@ -162,7 +186,7 @@ func (m *Migrator) Force(version string) error {
 		return fmt.Errorf("failed to force version: %w", err)
 	}
 
-	log.Printf("[Migrator] ✓ Forced migration version to %d", v)
+	slog.Info("forced migration version", "component", "migrator", "version", v)
 	return nil
 }
 
@@ -172,7 +196,7 @@ func (m *Migrator) Drop() error {
 		return fmt.Errorf("failed to drop database: %w", err)
 	}
 
-	log.Println("[Migrator] ✓ Dropped all tables and migration tracking")
+	slog.Info("dropped all tables and migration tracking", "component", "migrator")
 	return nil
 }
 
@@ -0,0 +1,84 @@
+package migrations_test
+
+import (
+	"io/fs"
+	"slices"
+	"testing"
+
+	"backend/internal/infrastructure/database/migrations"
+	"backend/internal/shared/contracttest"
+	sqlmigrations "backend/migrations"
+)
+
+// Every Postgres migration needs a SQLite twin with the same version and name
+func TestSQLiteMigrationsMatchPostgres(t *testing.T) {
+	postgres, err := fs.Glob(sqlmigrations.FS, "*.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqliteFS, err := fs.Sub(sqlmigrations.FS, "sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlite, err := fs.Glob(sqliteFS, "*.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range postgres {
+		if !slices.Contains(sqlite, name) {
+			t.Errorf("migrations/sqlite is missing %s", name)
+		}
+	}
+	for _, name := range sqlite {
+		if !slices.Contains(postgres, name) {
+			t.Errorf("migrations/sqlite/%s has no Postgres counterpart", name)
+		}
+	}
+}
+
+func TestSQLiteMigrationsRollBackAndReapply(t *testing.T) {
+	db := contracttest.OpenSQLite(t)
+	migrator, err := migrations.NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Rows in every table make the rebuilds in the down migrations copy data
+	for _, stmt := range []string{
+		"INSERT INTO players (id, nickname) VALUES ('p1', 'alice'), ('p2', 'bob')",
+		"INSERT INTO spin_logs (id, player_id, points_gained, source) VALUES ('s1', 'p1', 10, 'GAME')",
+		"INSERT INTO reward_transactions (id, player_id, checkpoint_val) VALUES ('r1', 'p1', 100)",
+		"INSERT INTO referrals (id, referrer_id, referee_id, invite_code) VALUES ('f1', 'p1', 'p2', 'ABCDEFGH')",
+	} {
+		if err := db.Exec(stmt).Error; err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+
+	// Down to before the foreign keys were made restrictive, and back up
+	if err := migrator.Goto(11); err != nil {
+		t.Fatalf("goto 11: %v", err)
+	}
+	var spins int64
+	if err := db.Table("spin_logs").Count(&spins).Error; err != nil || spins != 1 {
+		t.Fatalf("spin_logs after down = %d, %v; want 1 row kept", spins, err)
+	}
+	if err := migrator.Up(); err != nil {
+		t.Fatalf("up: %v", err)
+	}
+	if err := db.Exec("DELETE FROM players WHERE id = 'p1'").Error; err == nil {
+		t.Fatal("deleting a player with spins succeeded; want the foreign key to refuse")
+	}
+
+	if err := migrator.Reset(); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	version, dirty, list, err := migrator.Status()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest := list[len(list)-1].Version; dirty || version != latest {
+		t.Fatalf("version after reset = %d (dirty %v), want %d", version, dirty, latest)
+	}
+}
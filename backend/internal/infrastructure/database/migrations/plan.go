@@ -0,0 +1,223 @@
+package migrations
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/golang-migrate/migrate/v4"
+)
+
+// Direction values for a planned step
+const (
+	DirectionUp   = "up"
+	DirectionDown = "down"
+)
+
+// Migration is one embedded migration and whether it is applied
+type Migration struct {
+	Version uint
+	Name    string
+	Applied bool
+}
+
+// Step is a migration that would run, with the SQL it would execute
+type Step struct {
+	Version   uint
+	Name      string
+	Direction string
+	SQL       string
+}
+
+// Status lists every embedded migration and marks those already applied
+func (m *Migrator) Status() (current uint, dirty bool, list []Migration, err error) {
+	current, dirty, applied, err := m.current()
+	if err != nil {
+		return 0, false, nil, err
+	}
+
+	versions, err := m.versions()
+	if err != nil {
+		return 0, false, nil, err
+	}
+
+	for _, v := range versions {
+		name, err := m.name(v)
+		if err != nil {
+			return 0, false, nil, err
+		}
+		list = append(list, Migration{Version: v, Name: name, Applied: applied && v <= current})
+	}
+	return current, dirty, list, nil
+}
+
+// PlanUp returns the pending migrations that Up would apply
+func (m *Migrator) PlanUp() ([]Step, error) {
+	current, dirty, applied, err := m.current()
+	if err != nil {
+		return nil, err
+	}
+	if dirty {
+		return nil, fmt.Errorf("database is dirty at version %d, fix it with force first", current)
+	}
+
+	versions, err := m.versions()
+	if err != nil {
+		return nil, err
+	}
+
+	var steps []Step
+	for _, v := range versions {
+		if applied && v <= current {
+			continue
+		}
+		step, err := m.step(v, DirectionUp)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// PlanDown returns the single migration that Down would roll back
+func (m *Migrator) PlanDown() ([]Step, error) {
+	current, dirty, applied, err := m.current()
+	if err != nil {
+		return nil, err
+	}
+	if dirty {
+		return nil, fmt.Errorf("database is dirty at version %d, fix it with force first", current)
+	}
+	if !applied {
+		return nil, nil
+	}
+
+	step, err := m.step(current, DirectionDown)
+	if err != nil {
+		return nil, err
+	}
+	return []Step{step}, nil
+}
+
+// PlanGoto returns the migrations Goto would apply or roll back to reach target
+func (m *Migrator) PlanGoto(target uint) ([]Step, error) {
+	current, dirty, applied, err := m.current()
+	if err != nil {
+		return nil, err
+	}
+	if dirty {
+		return nil, fmt.Errorf("database is dirty at version %d, fix it with force first", current)
+	}
+
+	versions, err := m.versions()
+	if err != nil {
+		return nil, err
+	}
+	if !containsVersion(versions, target) {
+		return nil, fmt.Errorf("migration version %d does not exist", target)
+	}
+
+	var steps []Step
+	if !applied || target > current {
+		for _, v := range versions {
+			if (applied && v <= current) || v > target {
+				continue
+			}
+			step, err := m.step(v, DirectionUp)
+			if err != nil {
+				return nil, err
+			}
+			steps = append(steps, step)
+		}
+		return steps, nil
+	}
+
+	for i := len(versions) - 1; i >= 0; i-- {
+		v := versions[i]
+		if v > current || v <= target {
+			continue
+		}
+		step, err := m.step(v, DirectionDown)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// current returns the applied version; applied is false on an empty database
+func (m *Migrator) current() (version uint, dirty bool, applied bool, err error) {
+	version, dirty, err = m.migrate.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, false, nil
+	}
+	if err != nil {
+		return 0, false, false, fmt.Errorf("failed to read migration version: %w", err)
+	}
+	return version, dirty, true, nil
+}
+
+// versions lists embedded migration versions in ascending order
+func (m *Migrator) versions() ([]uint, error) {
+	v, err := m.source.First()
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+
+	versions := []uint{v}
+	for {
+		v, err = m.source.Next(v)
+		if errors.Is(err, os.ErrNotExist) {
+			return versions, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list migrations: %w", err)
+		}
+		versions = append(versions, v)
+	}
+}
+
+// name returns the identifier of a migration (file name without version and direction)
+func (m *Migrator) name(version uint) (string, error) {
+	r, identifier, err := m.source.ReadUp(version)
+	if err != nil {
+		return "", fmt.Errorf("failed to read migration %d: %w", version, err)
+	}
+	r.Close()
+	return identifier, nil
+}
+
+// step reads the SQL of one migration in the given direction
+func (m *Migrator) step(version uint, direction string) (Step, error) {
+	read := m.source.ReadUp
+	if direction == DirectionDown {
+		read = m.source.ReadDown
+	}
+
+	r, identifier, err := read(version)
+	if err != nil {
+		return Step{}, fmt.Errorf("failed to read %s migration %d: %w", direction, version, err)
+	}
+	defer r.Close()
+
+	sql, err := io.ReadAll(r)
+	if err != nil {
+		return Step{}, fmt.Errorf("failed to read %s migration %d: %w", direction, version, err)
+	}
+	return Step{Version: version, Name: identifier, Direction: direction, SQL: string(sql)}, nil
+}
+
+func containsVersion(versions []uint, target uint) bool {
+	for _, v := range versions {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
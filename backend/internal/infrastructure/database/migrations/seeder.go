@@ -2,12 +2,17 @@ package migrations
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
+	"time"
 
 	"backend/internal/infrastructure/config"
+	"backend/internal/shared/constants"
+	"backend/internal/shared/i18n"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // Seeder handles database seeding
@@ -25,33 +30,51 @@ func NewSeeder(db *gorm.DB, cfg *config.Config) *Seeder {
 }
 
 // SeedRewardConfig seeds the reward_config table with data from YAML config
+// and the reward translations in the message catalogs
 func (s *Seeder) SeedRewardConfig(ctx context.Context) error {
-	log.Println("[Seeder] Starting reward config seeding...")
+	slog.InfoContext(ctx, "starting reward config seeding", "component", "seeder")
 
 	if len(s.config.Rewards.Checkpoints) == 0 {
-		log.Println("[Seeder] No reward checkpoints configured, skipping seed")
+		slog.InfoContext(ctx, "no reward checkpoints configured, skipping seed", "component", "seeder")
 		return nil
 	}
 
-	// Use raw SQL for upsert to handle conflicts
-	for _, checkpoint := range s.config.Rewards.Checkpoints {
-		query := `
-			INSERT INTO reward_config (checkpoint_val, reward_name, reward_description, created_at)
-			VALUES ($1, $2, $3, NOW())
-			ON CONFLICT (checkpoint_val)
-			DO UPDATE SET
-				reward_name = EXCLUDED.reward_name,
-				reward_description = EXCLUDED.reward_description
-		`
+	bundle, err := i18n.Load(s.config.I18n.DefaultLocale)
+	if err != nil {
+		return fmt.Errorf("failed to load message catalogs: %w", err)
+	}
 
-		if err := s.db.Exec(query, checkpoint.CheckpointVal, checkpoint.RewardName, checkpoint.RewardDescription).Error; err != nil {
+	// Upsert through GORM clauses so the same code runs on Postgres and SQLite
+	upsert := clause.OnConflict{
+		Columns:   []clause.Column{{Name: "checkpoint_val"}},
+		DoUpdates: clause.AssignmentColumns([]string{"reward_name", "reward_description", "reward_name_i18n", "reward_description_i18n"}),
+	}
+	for _, checkpoint := range s.config.Rewards.Checkpoints {
+		names, descriptions := bundle.RewardTranslations(checkpoint.CheckpointVal)
+		namesJSON, err := json.Marshal(names)
+		if err != nil {
+			return err
+		}
+		descriptionsJSON, err := json.Marshal(descriptions)
+		if err != nil {
+			return err
+		}
+		row := map[string]any{
+			"checkpoint_val":          checkpoint.CheckpointVal,
+			"reward_name":             checkpoint.RewardName,
+			"reward_description":      checkpoint.RewardDescription,
+			"reward_name_i18n":        string(namesJSON),
+			"reward_description_i18n": string(descriptionsJSON),
+			"created_at":              time.Now(),
+		}
+		if err := s.db.WithContext(ctx).Table(constants.TableRewardConfig).Clauses(upsert).Create(row).Error; err != nil {
 			return fmt.Errorf("failed to seed checkpoint %d: %w", checkpoint.CheckpointVal, err)
 		}
 
-		log.Printf("[Seeder] ✓ Seeded checkpoint: %d - %s", checkpoint.CheckpointVal, checkpoint.RewardName)
+		slog.DebugContext(ctx, "seeded checkpoint", "component", "seeder", "checkpoint_val", checkpoint.CheckpointVal, "reward_name", checkpoint.RewardName)
 	}
 
-	log.Printf("[Seeder] ✓ Successfully seeded %d reward checkpoints", len(s.config.Rewards.Checkpoints))
+	slog.InfoContext(ctx, "seeded reward checkpoints", "component", "seeder", "count", len(s.config.Rewards.Checkpoints))
 	return nil
 }
 
@@ -61,6 +84,6 @@ func (s *Seeder) SeedAll(ctx context.Context) error {
 		return fmt.Errorf("reward config seeding failed: %w", err)
 	}
 
-	log.Println("[Seeder] ✓ All seeding completed successfully")
+	slog.InfoContext(ctx, "all seeding completed", "component", "seeder")
 	return nil
 }
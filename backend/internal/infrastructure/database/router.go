@@ -0,0 +1,165 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// replicaCooldown is how long a failed replica is skipped before it is tried again
+const replicaCooldown = 30 * time.Second
+
+type primaryKey struct{}
+
+// WithPrimary forces reads made with ctx to the primary (read-your-writes)
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+func usePrimary(ctx context.Context) bool {
+	v, _ := ctx.Value(primaryKey{}).(bool)
+	return v
+}
+
+type replica struct {
+	db        *gorm.DB
+	downUntil atomic.Int64 // unix nanos
+}
+
+// Router sends writes to the primary and read-only queries to replicas,
+// falling back to the primary when a replica fails. Players who just wrote
+// are pinned to the primary for a short window to hide replication lag.
+// Pins are per process, so they assume sticky routing or a short lag.
+type Router struct {
+	primary  *gorm.DB
+	replicas []*replica
+	next     atomic.Uint64
+
+	pinTTL    time.Duration
+	mu        sync.Mutex
+	pins      map[string]time.Time
+	lastPurge time.Time
+}
+
+// NewRouter creates a router; with no replicas every read goes to the primary
+func NewRouter(primary *gorm.DB, replicas []*gorm.DB, pinTTL time.Duration) *Router {
+	r := &Router{
+		primary: primary,
+		pinTTL:  pinTTL,
+		pins:    make(map[string]time.Time),
+	}
+	for _, db := range replicas {
+		r.replicas = append(r.replicas, &replica{db: db})
+	}
+	return r
+}
+
+// Primary returns the read-write connection
+func (r *Router) Primary() *gorm.DB {
+	return r.primary
+}
+
+// Read runs a read-only query on a replica, or on the primary when ctx is pinned
+func (r *Router) Read(ctx context.Context, fn func(db *gorm.DB) error) error {
+	return r.ReadFor(ctx, "", fn)
+}
+
+// ReadFor is Read for queries scoped to one player; pinned players read from the primary
+func (r *Router) ReadFor(ctx context.Context, playerID string, fn func(db *gorm.DB) error) error {
+	// Reads inside a transaction must see its writes
+	if tx, ok := transaction(ctx); ok {
+		return fn(tx)
+	}
+	if usePrimary(ctx) || (playerID != "" && r.IsPinned(playerID)) {
+		return fn(r.primary)
+	}
+
+	rep := r.pickReplica()
+	if rep == nil {
+		return fn(r.primary)
+	}
+
+	err := fn(rep.db)
+	if err == nil || !isReplicaFailure(ctx, err) {
+		return err
+	}
+
+	rep.downUntil.Store(time.Now().Add(replicaCooldown).UnixNano())
+	slog.WarnContext(ctx, "replica query failed, falling back to primary", "component", "database", "error", err)
+	return fn(r.primary)
+}
+
+// Stream picks a connection for a long read that cannot be retried once it has
+// produced output (exports). It prefers a healthy replica and never falls back.
+func (r *Router) Stream(ctx context.Context) *gorm.DB {
+	if usePrimary(ctx) {
+		return r.primary
+	}
+	if rep := r.pickReplica(); rep != nil {
+		return rep.db
+	}
+	return r.primary
+}
+
+// Pin routes the player's reads to the primary for the read-your-writes window
+func (r *Router) Pin(playerID string) {
+	if r.pinTTL <= 0 || len(r.replicas) == 0 {
+		return
+	}
+
+	now := time.Now()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pins[playerID] = now.Add(r.pinTTL)
+
+	// Drop expired pins at most once per window
+	if now.Sub(r.lastPurge) > r.pinTTL {
+		for id, until := range r.pins {
+			if now.After(until) {
+				delete(r.pins, id)
+			}
+		}
+		r.lastPurge = now
+	}
+}
+
+// IsPinned returns true while the player is inside the read-your-writes window
+func (r *Router) IsPinned(playerID string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	until, ok := r.pins[playerID]
+	return ok && time.Now().Before(until)
+}
+
+// pickReplica returns the next healthy replica in round-robin order
+func (r *Router) pickReplica() *replica {
+	n := len(r.replicas)
+	if n == 0 {
+		return nil
+	}
+
+	now := time.Now().UnixNano()
+	start := r.next.Add(1)
+	for i := 0; i < n; i++ {
+		rep := r.replicas[(start+uint64(i))%uint64(n)]
+		if rep.downUntil.Load() <= now {
+			return rep
+		}
+	}
+	return nil
+}
+
+// isReplicaFailure reports whether err should be retried on the primary
+func isReplicaFailure(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	return !errors.Is(err, gorm.ErrRecordNotFound)
+}
@@ -0,0 +1,126 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"backend/internal/infrastructure/config"
+	"backend/internal/infrastructure/logger"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+	_ "modernc.org/sqlite" // pure Go driver, so builds need no cgo
+)
+
+// sqliteDriverName is the database/sql name modernc.org/sqlite registers
+const sqliteDriverName = "sqlite"
+
+// IsSQLite reports whether db talks to SQLite rather than Postgres
+func IsSQLite(db *gorm.DB) bool {
+	return db.Dialector.Name() == "sqlite"
+}
+
+// newSQLite opens the SQLite file at cfg.Path. Foreign keys are enforced,
+// LIKE is case-sensitive as in Postgres, and transactions take the write lock
+// up front so concurrent writers wait on busy_timeout instead of failing.
+func newSQLite(ctx context.Context, cfg *config.DBConfig) (*Database, error) {
+	slog.Info("opening SQLite database", "component", "database", "path", cfg.Path)
+
+	db, err := OpenSQLite(cfg.Path, logger.NewGormLogger(cfg.SlowQueryThreshold))
+	if err != nil {
+		return nil, err
+	}
+	if err := configurePool(db, cfg); err != nil {
+		return nil, err
+	}
+
+	sqlDB, _ := db.DB()
+	if err := sqlDB.PingContext(ctx); err != nil {
+		sqlDB.Close()
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	slog.Info("opened SQLite database", "component", "database", "path", cfg.Path)
+	return &Database{db: db}, nil
+}
+
+// OpenSQLite opens (creating if needed) the SQLite file at path with the
+// connection settings the repositories rely on
+func OpenSQLite(path string, log gormlogger.Interface) (*gorm.DB, error) {
+	params := url.Values{
+		"_pragma": {
+			"foreign_keys(1)",
+			"case_sensitive_like(1)",
+			"journal_mode(WAL)",
+			"busy_timeout(5000)",
+		},
+		"_txlock": {"immediate"},
+		// SQLite has no timestamp type and compares the stored text, so every
+		// time is written in one zone and one sortable format
+		"_timezone":    {"UTC"},
+		"_time_format": {"sqlite"},
+	}
+	dsn := "file:" + path + "?" + params.Encode()
+
+	db, err := gorm.Open(sqlite.New(sqlite.Config{DriverName: sqliteDriverName, DSN: dsn}), &gorm.Config{Logger: log})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	return db, nil
+}
+
+// NullTime scans a nullable timestamp from either driver. SQLite returns
+// computed columns such as MIN(created_at) as text, which sql.NullTime
+// cannot read.
+type NullTime struct {
+	Time  time.Time
+	Valid bool
+}
+
+// Scan implements sql.Scanner
+func (t *NullTime) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*t = NullTime{}
+		return nil
+	case time.Time:
+		*t = NullTime{Time: v, Valid: true}
+		return nil
+	case string:
+		return t.parse(v)
+	case []byte:
+		return t.parse(string(v))
+	}
+	return fmt.Errorf("cannot scan %T into NullTime", value)
+}
+
+// Value implements driver.Valuer
+func (t NullTime) Value() (driver.Value, error) {
+	if !t.Valid {
+		return nil, nil
+	}
+	return t.Time, nil
+}
+
+// Ptr returns the time, or nil when it is NULL
+func (t NullTime) Ptr() *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+func (t *NullTime) parse(s string) error {
+	for _, layout := range []string{"2006-01-02 15:04:05.999999999-07:00", "2006-01-02 15:04:05.999999999", time.DateOnly} {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			*t = NullTime{Time: parsed, Valid: true}
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot parse %q as a timestamp", s)
+}
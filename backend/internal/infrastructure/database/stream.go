@@ -0,0 +1,109 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// streamCursorName is the server-side cursor used by Stream; cursors are
+// scoped to their transaction, so one name is safe for concurrent streams
+const streamCursorName = "export_stream"
+
+// Stream runs query through a server-side cursor and hands rows to fn in
+// batches of batchSize, so memory stays flat however many rows match.
+// Preloads are not applied; select or join what fn needs. Stream stops at the
+// first error returned by fn.
+func Stream[T any](ctx context.Context, query *gorm.DB, batchSize int, fn func(batch []T) error) error {
+	if batchSize <= 0 {
+		return fmt.Errorf("batch size must be positive")
+	}
+	if IsSQLite(query) {
+		return streamRows(ctx, query, batchSize, fn)
+	}
+
+	// Render the statement without running it to get SQL and bind vars
+	var probe []T
+	dry := query.Session(&gorm.Session{DryRun: true}).Find(&probe)
+	if dry.Error != nil {
+		return dry.Error
+	}
+	stmt := dry.Statement
+	declare := fmt.Sprintf("DECLARE %s NO SCROLL CURSOR FOR %s", streamCursorName, stmt.SQL.String())
+	fetch := fmt.Sprintf("FETCH FORWARD %d FROM %s", batchSize, streamCursorName)
+
+	conn := query.Session(&gorm.Session{NewDB: true, Context: ctx})
+	return conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(declare, stmt.Vars...).Error; err != nil {
+			return err
+		}
+
+		for {
+			batch := make([]T, 0, batchSize)
+			if err := tx.Raw(fetch).Scan(&batch).Error; err != nil {
+				return err
+			}
+			if len(batch) == 0 {
+				return nil
+			}
+			if err := fn(batch); err != nil {
+				return err
+			}
+			if len(batch) < batchSize {
+				return nil
+			}
+		}
+	}, &sql.TxOptions{ReadOnly: true})
+}
+
+// streamRows is Stream for SQLite, which has no server-side cursors: the
+// driver already steps through the result one row at a time
+func streamRows[T any](ctx context.Context, query *gorm.DB, batchSize int, fn func(batch []T) error) error {
+	db := query.WithContext(ctx)
+	rows, err := db.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	batch := make([]T, 0, batchSize)
+	for rows.Next() {
+		var row T
+		if err := db.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		batch = append(batch, row)
+		if len(batch) == batchSize {
+			if err := fn(batch); err != nil {
+				return err
+			}
+			batch = make([]T, 0, batchSize)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(batch) > 0 {
+		return fn(batch)
+	}
+	return nil
+}
+
+// StreamSlice is Stream over rows already in memory, for repositories without
+// SQL; it hands rows to fn in batches of batchSize in the given order
+func StreamSlice[T any](ctx context.Context, rows []T, batchSize int, fn func(batch []T) error) error {
+	if batchSize <= 0 {
+		return fmt.Errorf("batch size must be positive")
+	}
+	for start := 0; start < len(rows); start += batchSize {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(rows[start:min(start+batchSize, len(rows))]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package database
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+// Transactor implements domain.Transactor with a database transaction
+type Transactor struct {
+	db *gorm.DB
+}
+
+func NewTransactor(db *gorm.DB) *Transactor {
+	return &Transactor{db: db}
+}
+
+// WithinTransaction runs fn in a transaction that repositories called with
+// fn's ctx join (see Conn); nested calls use a savepoint
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return Conn(ctx, t.db).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// Conn returns the transaction ctx carries, or db outside a transaction
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := transaction(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// transaction returns the transaction ctx carries, if any
+func transaction(ctx context.Context) (*gorm.DB, bool) {
+	tx, ok := ctx.Value(txKey{}).(*gorm.DB)
+	return tx, ok
+}
+
+// NoTransactor runs fn directly, for stores without transactions
+// (STORAGE=memory); writes made before a failure are kept
+type NoTransactor struct{}
+
+// WithinTransaction implements domain.Transactor
+func (NoTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
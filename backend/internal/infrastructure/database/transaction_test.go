@@ -0,0 +1,74 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"backend/internal/infrastructure/database"
+	"backend/internal/shared/contracttest"
+)
+
+func countPlayers(t *testing.T, db *gorm.DB) int64 {
+	t.Helper()
+	var n int64
+	if err := db.Table("players").Count(&n).Error; err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestTransactorCommitsOrRollsBackTogether(t *testing.T) {
+	db := contracttest.OpenSQLite(t)
+	tx := database.NewTransactor(db)
+	router := database.NewRouter(db, nil, 0)
+	ctx := context.Background()
+
+	insert := func(ctx context.Context, id, nickname string) error {
+		return database.Conn(ctx, db).Exec("INSERT INTO players (id, nickname) VALUES (?, ?)", id, nickname).Error
+	}
+
+	// A failure rolls back every write made with the transaction's ctx
+	boom := errors.New("boom")
+	err := tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := insert(ctx, "p1", "alice"); err != nil {
+			return err
+		}
+		// Reads inside the transaction see its writes
+		var seen int64
+		if err := router.ReadFor(ctx, "p1", func(db *gorm.DB) error {
+			return db.WithContext(ctx).Table("players").Count(&seen).Error
+		}); err != nil || seen != 1 {
+			t.Errorf("players seen in transaction = %d, %v; want 1", seen, err)
+		}
+		return boom
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("err = %v, want %v", err, boom)
+	}
+	if n := countPlayers(t, db); n != 0 {
+		t.Fatalf("players after rollback = %d, want 0", n)
+	}
+
+	// A nested failure only undoes its own writes
+	err = tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := insert(ctx, "p1", "alice"); err != nil {
+			return err
+		}
+		_ = tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := insert(ctx, "p2", "bob"); err != nil {
+				return err
+			}
+			return boom
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := countPlayers(t, db); n != 1 {
+		t.Fatalf("players after commit = %d, want 1", n)
+	}
+}
@@ -0,0 +1,44 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// DatabasePing checks that the database answers a ping
+func DatabasePing(db *sql.DB) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		return db.PingContext(ctx)
+	})
+}
+
+// MigrationVersioner reports the current schema version (implemented by migrations.Migrator)
+type MigrationVersioner interface {
+	Version() (uint, bool, error)
+}
+
+// MigrationsClean checks that migrations were applied and are not dirty
+func MigrationsClean(m MigrationVersioner) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		version, dirty, err := m.Version()
+		if err != nil {
+			return fmt.Errorf("failed to read migration version: %w", err)
+		}
+		if dirty {
+			return fmt.Errorf("migration version %d is dirty", version)
+		}
+		return nil
+	})
+}
+
+// ConfigValid checks the loaded configuration with the given validator
+func ConfigValid(validate func() error) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		if err := validate(); err != nil {
+			return errors.New("invalid configuration: " + err.Error())
+		}
+		return nil
+	})
+}
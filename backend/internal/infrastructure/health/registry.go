@@ -0,0 +1,198 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Status values
+const (
+	StatusOK       = "ok"
+	StatusFail     = "fail"
+	StatusDraining = "draining"
+)
+
+// Kind selects which probe a checker belongs to
+type Kind int
+
+const (
+	// Liveness checks fail only when the process must be restarted
+	Liveness Kind = iota
+	// Readiness checks fail when the instance must not receive traffic
+	Readiness
+)
+
+// Checker reports the health of one component
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc adapts a function to Checker
+type CheckerFunc func(ctx context.Context) error
+
+// Check implements Checker
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// ComponentStatus is the result of one checker
+type ComponentStatus struct {
+	Status    string `json:"status"`
+	LatencyMS int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Report is the probe response body
+type Report struct {
+	Status     string                     `json:"status"`
+	Components map[string]ComponentStatus `json:"components"`
+}
+
+type registration struct {
+	name    string
+	kind    Kind
+	checker Checker
+}
+
+// Registry holds the registered checkers and the draining flag
+type Registry struct {
+	mu       sync.RWMutex
+	checks   []registration
+	timeout  time.Duration
+	draining atomic.Bool
+}
+
+// NewRegistry creates a registry; each check is cancelled after timeout
+func NewRegistry(timeout time.Duration) *Registry {
+	return &Registry{timeout: timeout}
+}
+
+// Register adds a checker for the given probe
+func (r *Registry) Register(name string, kind Kind, checker Checker) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks = append(r.checks, registration{name: name, kind: kind, checker: checker})
+}
+
+// SetDraining marks the instance as shutting down; readiness fails from now on
+func (r *Registry) SetDraining(draining bool) {
+	r.draining.Store(draining)
+}
+
+// IsDraining returns true once shutdown has started
+func (r *Registry) IsDraining() bool {
+	return r.draining.Load()
+}
+
+// Run executes all checkers of the given kind concurrently
+func (r *Registry) Run(ctx context.Context, kind Kind) Report {
+	r.mu.RLock()
+	var selected []registration
+	for _, reg := range r.checks {
+		if reg.kind == kind {
+			selected = append(selected, reg)
+		}
+	}
+	r.mu.RUnlock()
+
+	report := Report{
+		Status:     StatusOK,
+		Components: make(map[string]ComponentStatus, len(selected)),
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	for _, reg := range selected {
+		wg.Add(1)
+		go func(reg registration) {
+			defer wg.Done()
+			status := r.runOne(ctx, reg.checker)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Components[reg.name] = status
+			if status.Status != StatusOK {
+				report.Status = StatusFail
+			}
+		}(reg)
+	}
+	wg.Wait()
+
+	if kind == Readiness && r.IsDraining() {
+		report.Status = StatusDraining
+	}
+
+	return report
+}
+
+func (r *Registry) runOne(ctx context.Context, checker Checker) ComponentStatus {
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	err := checker.Check(ctx)
+	status := ComponentStatus{
+		Status:    StatusOK,
+		LatencyMS: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		status.Status = StatusFail
+		status.Error = err.Error()
+	}
+	return status
+}
+
+// LivenessHandler serves GET /livez
+func (r *Registry) LivenessHandler() fiber.Handler {
+	return r.handler(Liveness)
+}
+
+// ReadinessHandler serves GET /readyz
+func (r *Registry) ReadinessHandler() fiber.Handler {
+	return r.handler(Readiness)
+}
+
+// ComponentHandler serves the status of a single component (e.g. /health/db)
+func (r *Registry) ComponentHandler(name string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		r.mu.RLock()
+		var found *registration
+		for i := range r.checks {
+			if r.checks[i].name == name {
+				found = &r.checks[i]
+				break
+			}
+		}
+		r.mu.RUnlock()
+
+		if found == nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"status": StatusFail, "error": "unknown component"})
+		}
+
+		status := r.runOne(c.UserContext(), found.checker)
+		code := fiber.StatusOK
+		if status.Status != StatusOK {
+			code = fiber.StatusServiceUnavailable
+		}
+		return c.Status(code).JSON(status)
+	}
+}
+
+func (r *Registry) handler(kind Kind) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		report := r.Run(c.UserContext(), kind)
+		code := fiber.StatusOK
+		if report.Status != StatusOK {
+			code = fiber.StatusServiceUnavailable
+		}
+		c.Set(fiber.HeaderCacheControl, "no-store")
+		return c.Status(code).JSON(report)
+	}
+}
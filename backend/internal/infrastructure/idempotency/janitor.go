@@ -0,0 +1,33 @@
+package idempotency
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// RunJanitor periodically purges expired keys and returns once ctx is cancelled
+func RunJanitor(ctx context.Context, store Store, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			removed, err := store.DeleteExpired(ctx)
+			if err != nil {
+				slog.WarnContext(ctx, "failed to purge expired idempotency keys", "error", err)
+				continue
+			}
+			if removed > 0 {
+				slog.DebugContext(ctx, "purged expired idempotency keys", "count", removed)
+			}
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps idempotency keys in process memory (single instance only)
+type MemoryStore struct {
+	mu      sync.Mutex
+	records map[string]*Record
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		records: make(map[string]*Record),
+	}
+}
+
+// Acquire locks the key unless an unexpired record holds it
+func (s *MemoryStore) Acquire(ctx context.Context, key, scope, requestHash string, lockTTL time.Duration) (*Record, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	id := scope + "\x00" + key
+	if existing, ok := s.records[id]; ok && !existing.ExpiresAt.Before(now) {
+		record := *existing
+		return &record, false, nil
+	}
+
+	s.records[id] = &Record{
+		Key:         key,
+		Scope:       scope,
+		RequestHash: requestHash,
+		Status:      StatusProcessing,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(lockTTL),
+	}
+	return nil, true, nil
+}
+
+// Complete stores the response and extends the key lifetime to ttl
+func (s *MemoryStore) Complete(ctx context.Context, key, scope string, resp Response, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[scope+"\x00"+key]
+	if !ok || record.Status != StatusProcessing {
+		return nil
+	}
+	record.Status = StatusCompleted
+	record.StatusCode = resp.StatusCode
+	record.ContentType = resp.ContentType
+	record.Body = resp.Body
+	record.ExpiresAt = time.Now().Add(ttl)
+	return nil
+}
+
+// Release deletes a PROCESSING key
+func (s *MemoryStore) Release(ctx context.Context, key, scope string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := scope + "\x00" + key
+	if record, ok := s.records[id]; ok && record.Status == StatusProcessing {
+		delete(s.records, id)
+	}
+	return nil
+}
+
+// DeleteExpired removes all expired keys
+func (s *MemoryStore) DeleteExpired(ctx context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	var removed int64
+	for id, record := range s.records {
+		if record.ExpiresAt.Before(now) {
+			delete(s.records, id)
+			removed++
+		}
+	}
+	return removed, nil
+}
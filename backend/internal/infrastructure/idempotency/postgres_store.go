@@ -0,0 +1,130 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"backend/internal/shared/constants"
+
+	"gorm.io/gorm"
+)
+
+// IdempotencyKeyModel is the GORM database model
+type IdempotencyKeyModel struct {
+	IdempotencyKey      string    `gorm:"type:varchar(255);primaryKey"`
+	Scope               string    `gorm:"type:varchar(64);primaryKey"`
+	RequestHash         string    `gorm:"type:char(64);not null"`
+	Status              string    `gorm:"type:varchar(20);not null"`
+	ResponseStatus      *int      `gorm:"type:integer"`
+	ResponseContentType *string   `gorm:"type:varchar(255)"`
+	ResponseBody        []byte    `gorm:"type:bytea"`
+	CreatedAt           time.Time `gorm:"not null"`
+	ExpiresAt           time.Time `gorm:"not null;index:idx_idempotency_keys_expires_at"`
+}
+
+func (IdempotencyKeyModel) TableName() string {
+	return constants.TableIdempotencyKeys
+}
+
+// PostgresStore implements Store on the idempotency_keys table (Postgres or SQLite)
+type PostgresStore struct {
+	db *gorm.DB
+}
+
+// NewPostgresStore creates a new Postgres-backed store
+func NewPostgresStore(db *gorm.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// Acquire inserts a PROCESSING row, or takes over an expired one.
+// The conflict clause only fires for expired rows, so the primary key
+// guarantees a single winner for concurrent requests.
+func (s *PostgresStore) Acquire(ctx context.Context, key, scope, requestHash string, lockTTL time.Duration) (*Record, bool, error) {
+	// now is bound rather than NOW() so the statement also runs on SQLite
+	query := `
+		INSERT INTO idempotency_keys (idempotency_key, scope, request_hash, status, created_at, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (idempotency_key, scope)
+		DO UPDATE SET
+			request_hash = EXCLUDED.request_hash,
+			status = EXCLUDED.status,
+			response_status = NULL,
+			response_content_type = NULL,
+			response_body = NULL,
+			created_at = EXCLUDED.created_at,
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at < ?
+	`
+
+	now := time.Now()
+	result := s.db.WithContext(ctx).Exec(query, key, scope, requestHash, StatusProcessing, now, now.Add(lockTTL), now)
+	if result.Error != nil {
+		return nil, false, result.Error
+	}
+	if result.RowsAffected == 1 {
+		return nil, true, nil
+	}
+
+	var model IdempotencyKeyModel
+	err := s.db.WithContext(ctx).
+		Where("idempotency_key = ? AND scope = ?", key, scope).
+		First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Row was purged between the insert and the read; let the client retry
+			return nil, false, ErrRequestInProgress
+		}
+		return nil, false, err
+	}
+
+	return s.toRecord(&model), false, nil
+}
+
+// Complete stores the response and extends the key lifetime to ttl
+func (s *PostgresStore) Complete(ctx context.Context, key, scope string, resp Response, ttl time.Duration) error {
+	return s.db.WithContext(ctx).
+		Model(&IdempotencyKeyModel{}).
+		Where("idempotency_key = ? AND scope = ? AND status = ?", key, scope, StatusProcessing).
+		Updates(map[string]interface{}{
+			"status":                StatusCompleted,
+			"response_status":       resp.StatusCode,
+			"response_content_type": resp.ContentType,
+			"response_body":         resp.Body,
+			"expires_at":            time.Now().Add(ttl),
+		}).Error
+}
+
+// Release deletes a PROCESSING key
+func (s *PostgresStore) Release(ctx context.Context, key, scope string) error {
+	return s.db.WithContext(ctx).
+		Where("idempotency_key = ? AND scope = ? AND status = ?", key, scope, StatusProcessing).
+		Delete(&IdempotencyKeyModel{}).Error
+}
+
+// DeleteExpired removes all expired keys
+func (s *PostgresStore) DeleteExpired(ctx context.Context) (int64, error) {
+	result := s.db.WithContext(ctx).
+		Where("expires_at < ?", time.Now()).
+		Delete(&IdempotencyKeyModel{})
+	return result.RowsAffected, result.Error
+}
+
+func (s *PostgresStore) toRecord(model *IdempotencyKeyModel) *Record {
+	record := &Record{
+		Key:         model.IdempotencyKey,
+		Scope:       model.Scope,
+		RequestHash: model.RequestHash,
+		Status:      model.Status,
+		Body:        model.ResponseBody,
+		CreatedAt:   model.CreatedAt,
+		ExpiresAt:   model.ExpiresAt,
+	}
+	if model.ResponseStatus != nil {
+		record.StatusCode = *model.ResponseStatus
+	}
+	if model.ResponseContentType != nil {
+		record.ContentType = *model.ResponseContentType
+	}
+	return record
+}
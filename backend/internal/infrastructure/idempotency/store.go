@@ -0,0 +1,65 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Record status values
+const (
+	StatusProcessing = "PROCESSING"
+	StatusCompleted  = "COMPLETED"
+)
+
+var (
+	// ErrRequestMismatch is returned when a key is reused with a different request body
+	ErrRequestMismatch = errors.New("idempotency key reused with a different request")
+
+	// ErrRequestInProgress is returned when another request with the same key is still running
+	ErrRequestInProgress = errors.New("request with this idempotency key is in progress")
+)
+
+// Record is a stored idempotency key and (once completed) its response
+type Record struct {
+	Key         string
+	Scope       string
+	RequestHash string
+	Status      string
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// IsCompleted returns true if the record holds a replayable response
+func (r *Record) IsCompleted() bool {
+	return r.Status == StatusCompleted
+}
+
+// Response is the captured response saved for a key
+type Response struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+// Store persists idempotency keys
+//
+// Acquire must be atomic: of several concurrent callers with the same key and
+// scope, exactly one gets acquired=true. The others receive the existing record.
+type Store interface {
+	// Acquire locks the key for processing until lockTTL elapses
+	// Returns acquired=false and the existing record if the key is already taken
+	Acquire(ctx context.Context, key, scope, requestHash string, lockTTL time.Duration) (existing *Record, acquired bool, err error)
+
+	// Complete saves the response for a locked key and keeps it for ttl
+	Complete(ctx context.Context, key, scope string, resp Response, ttl time.Duration) error
+
+	// Release removes a locked key so the request can be retried
+	Release(ctx context.Context, key, scope string) error
+
+	// DeleteExpired purges expired keys and returns the number removed
+	DeleteExpired(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,115 @@
+package legacyimport
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	playerdomain "backend/internal/modules/player/domain"
+)
+
+// timestampLayout matches the legacy export, e.g. "2025-06-19 01:00:12.367 +0700"
+const timestampLayout = "2006-01-02 15:04:05.999 -0700"
+
+var expectedHeader = []string{"nickname", "point", "datetime"}
+
+// Row is a valid spin from the legacy export
+type Row struct {
+	Line     int
+	Nickname string
+	Points   int
+	SpunAt   time.Time
+}
+
+// RowError is a row rejected during validation
+type RowError struct {
+	Line   int
+	Record []string
+	Reason string
+}
+
+// Parse reads the legacy CSV and splits it into valid rows and rejected rows.
+// Nicknames are validated with the same rules as PlayerFactory.
+func Parse(r io.Reader, factory *playerdomain.PlayerFactory) ([]Row, []RowError, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 // column count is validated per row
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	for i, name := range expectedHeader {
+		if i >= len(header) || !strings.EqualFold(strings.TrimSpace(header[i]), name) {
+			return nil, nil, fmt.Errorf("unexpected header %v, want %v", header, expectedHeader)
+		}
+	}
+
+	var rows []Row
+	var rejected []RowError
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			rejected = append(rejected, RowError{Line: line, Record: record, Reason: err.Error()})
+			continue
+		}
+
+		row, err := parseRecord(line, record, factory)
+		if err != nil {
+			rejected = append(rejected, RowError{Line: line, Record: record, Reason: err.Error()})
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, rejected, nil
+}
+
+func parseRecord(line int, record []string, factory *playerdomain.PlayerFactory) (Row, error) {
+	if len(record) != len(expectedHeader) {
+		return Row{}, fmt.Errorf("expected %d columns, got %d", len(expectedHeader), len(record))
+	}
+
+	nickname := strings.TrimSpace(record[0])
+	if _, err := playerdomain.NewNickname(nickname, factory.NicknameMinLen, factory.NicknameMaxLen); err != nil {
+		return Row{}, err
+	}
+
+	points, err := strconv.Atoi(strings.TrimSpace(record[1]))
+	if err != nil {
+		return Row{}, fmt.Errorf("invalid points %q", record[1])
+	}
+	if points <= 0 {
+		return Row{}, errors.New("points must be positive")
+	}
+
+	spunAt, err := time.Parse(timestampLayout, strings.TrimSpace(record[2]))
+	if err != nil {
+		return Row{}, fmt.Errorf("invalid datetime %q", record[2])
+	}
+
+	return Row{Line: line, Nickname: nickname, Points: points, SpunAt: spunAt}, nil
+}
+
+// WriteErrors writes rejected rows as CSV (line, original columns, reason)
+func WriteErrors(w io.Writer, rejected []RowError) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"line", "nickname", "point", "datetime", "error"}); err != nil {
+		return err
+	}
+	for _, r := range rejected {
+		record := make([]string, len(expectedHeader))
+		copy(record, r.Record)
+		if err := writer.Write(append([]string{strconv.Itoa(r.Line)}, append(record, r.Reason)...)); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
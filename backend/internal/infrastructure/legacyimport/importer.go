@@ -0,0 +1,230 @@
+package legacyimport
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	historyrepo "backend/internal/modules/history/adapter/repository"
+	playerrepo "backend/internal/modules/player/adapter/repository"
+	playerdomain "backend/internal/modules/player/domain"
+	"backend/internal/shared/constants"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// spinLogNamespace derives stable spin log IDs from row content so re-runs
+// insert nothing new (duplicate detection via primary key)
+var spinLogNamespace = uuid.MustParse("6f1c3a52-4d0e-4c1e-9a7b-2f5d8e9c0b14")
+
+// Report summarizes an import (or a dry run)
+type Report struct {
+	Rows           int
+	Rejected       int
+	PlayersCreated int
+	SpinsInserted  int
+	Duplicates     int
+	DryRun         bool
+}
+
+// Importer loads legacy spins into players and spin_logs
+type Importer struct {
+	db        *gorm.DB
+	factory   *playerdomain.PlayerFactory
+	batchSize int
+}
+
+// New creates an importer; batchSize bounds rows per INSERT
+func New(db *gorm.DB, factory *playerdomain.PlayerFactory, batchSize int) *Importer {
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+	return &Importer{db: db, factory: factory, batchSize: batchSize}
+}
+
+// Run creates missing players, inserts spins with their original timestamps and
+// adds the inserted spins' points to total_points. Points earned outside
+// spin_logs (e.g. referral payouts) are kept. With dryRun nothing is written.
+func (im *Importer) Run(ctx context.Context, rows []Row, dryRun bool) (*Report, error) {
+	report := &Report{Rows: len(rows), DryRun: dryRun}
+
+	// Resolve players by nickname
+	nicknames := uniqueNicknames(rows)
+	playerIDs, err := im.existingPlayers(ctx, nicknames)
+	if err != nil {
+		return nil, err
+	}
+
+	var newPlayers []*playerdomain.Player
+	for _, nickname := range nicknames {
+		if _, ok := playerIDs[nickname]; ok {
+			continue
+		}
+		player, err := im.factory.CreateNewPlayer(nickname)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create player %q: %w", nickname, err)
+		}
+		player.ClearEvents()
+		playerIDs[nickname] = player.ID().String()
+		newPlayers = append(newPlayers, player)
+	}
+	report.PlayersCreated = len(newPlayers)
+
+	// Build spin logs with deterministic IDs; identical rows in the file collapse to one
+	models := make([]*historyrepo.SpinLogModel, 0, len(rows))
+	seen := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		id := spinLogID(row)
+		if seen[id] {
+			report.Duplicates++
+			continue
+		}
+		seen[id] = true
+		models = append(models, &historyrepo.SpinLogModel{
+			ID:           id,
+			PlayerID:     playerIDs[row.Nickname],
+			PointsGained: row.Points,
+			Source:       string(constants.SpinSourceGame),
+			CreatedAt:    row.SpunAt,
+		})
+	}
+
+	if dryRun {
+		existing, err := im.countExisting(ctx, models)
+		if err != nil {
+			return nil, err
+		}
+		report.Duplicates += existing
+		report.SpinsInserted = len(models) - existing
+		return report, nil
+	}
+
+	err = im.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, player := range newPlayers {
+			model := &playerrepo.PlayerModel{
+				ID:          player.ID().String(),
+				Nickname:    player.Nickname().String(),
+				InviteCode:  player.InviteCode().String(),
+				TotalPoints: 0,
+				CreatedAt:   player.CreatedAt(),
+				UpdatedAt:   player.UpdatedAt(),
+				Version:     player.Version(),
+			}
+			if err := tx.Create(model).Error; err != nil {
+				return fmt.Errorf("failed to create player %q: %w", model.Nickname, err)
+			}
+		}
+
+		// Only spins not imported before add to the players' totals
+		gained := make(map[string]int)
+		for start := 0; start < len(models); start += im.batchSize {
+			end := min(start+im.batchSize, len(models))
+			existing, err := existingIDs(tx, models[start:end])
+			if err != nil {
+				return err
+			}
+			fresh := make([]*historyrepo.SpinLogModel, 0, end-start)
+			for _, m := range models[start:end] {
+				if !existing[m.ID] {
+					fresh = append(fresh, m)
+					gained[m.PlayerID] += m.PointsGained
+				}
+			}
+			report.Duplicates += len(existing)
+
+			if len(fresh) > 0 {
+				result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(fresh)
+				if result.Error != nil {
+					return fmt.Errorf("failed to insert spin logs %d-%d: %w", start, end, result.Error)
+				}
+				if int(result.RowsAffected) != len(fresh) {
+					return fmt.Errorf("spin logs %d-%d were imported concurrently; run the import again", start, end)
+				}
+			}
+			report.SpinsInserted += len(fresh)
+			slog.InfoContext(ctx, "imported batch", "component", "import", "rows", end-start, "inserted", len(fresh))
+		}
+
+		for playerID, points := range gained {
+			err := tx.Exec(`UPDATE players SET total_points = total_points + ?, version = version + 1 WHERE id = ?`, points, playerID).Error
+			if err != nil {
+				return fmt.Errorf("failed to add imported points to player %s: %w", playerID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// existingPlayers maps nickname to player ID for players already stored
+func (im *Importer) existingPlayers(ctx context.Context, nicknames []string) (map[string]string, error) {
+	ids := make(map[string]string, len(nicknames))
+	if len(nicknames) == 0 {
+		return ids, nil
+	}
+
+	var models []playerrepo.PlayerModel
+	if err := im.db.WithContext(ctx).Where("nickname IN ? AND nickname_released_at IS NULL", nicknames).Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("failed to load players: %w", err)
+	}
+	for _, m := range models {
+		ids[m.Nickname] = m.ID
+	}
+	return ids, nil
+}
+
+// countExisting counts spin logs already imported by a previous run
+func (im *Importer) countExisting(ctx context.Context, models []*historyrepo.SpinLogModel) (int, error) {
+	total := 0
+	for start := 0; start < len(models); start += im.batchSize {
+		end := min(start+im.batchSize, len(models))
+		existing, err := existingIDs(im.db.WithContext(ctx), models[start:end])
+		if err != nil {
+			return 0, err
+		}
+		total += len(existing)
+	}
+	return total, nil
+}
+
+// existingIDs returns the IDs of models already in spin_logs
+func existingIDs(db *gorm.DB, models []*historyrepo.SpinLogModel) (map[string]bool, error) {
+	ids := make([]string, 0, len(models))
+	for _, m := range models {
+		ids = append(ids, m.ID)
+	}
+
+	var found []string
+	if err := db.Model(&historyrepo.SpinLogModel{}).Where("id IN ?", ids).Pluck("id", &found).Error; err != nil {
+		return nil, fmt.Errorf("failed to check existing spin logs: %w", err)
+	}
+	existing := make(map[string]bool, len(found))
+	for _, id := range found {
+		existing[id] = true
+	}
+	return existing, nil
+}
+
+func uniqueNicknames(rows []Row) []string {
+	seen := make(map[string]bool)
+	var nicknames []string
+	for _, row := range rows {
+		if !seen[row.Nickname] {
+			seen[row.Nickname] = true
+			nicknames = append(nicknames, row.Nickname)
+		}
+	}
+	return nicknames
+}
+
+func spinLogID(row Row) string {
+	key := row.Nickname + "|" + strconv.Itoa(row.Points) + "|" + row.SpunAt.UTC().Format("2006-01-02T15:04:05.000000Z")
+	return uuid.NewSHA1(spinLogNamespace, []byte(key)).String()
+}
@@ -0,0 +1,70 @@
+package legacyimport
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	playerrepo "backend/internal/modules/player/adapter/repository"
+	playerdomain "backend/internal/modules/player/domain"
+	"backend/internal/shared/contracttest"
+	shared "backend/internal/shared/domain"
+)
+
+func TestImportAddsToPointsEarnedOutsideSpins(t *testing.T) {
+	db := contracttest.OpenSQLite(t)
+	ctx := context.Background()
+	factory := playerdomain.NewPlayerFactory(3, 20)
+	players := playerrepo.NewPlayerRepositoryGorm(db, factory)
+
+	// alice was paid 100 points for a referral, which has no spin log
+	alice, _ := factory.CreateNewPlayer("alice")
+	payout, _ := shared.NewPoints(100)
+	if err := alice.AddPoints(payout); err != nil {
+		t.Fatal(err)
+	}
+	if err := players.Store(ctx, alice); err != nil {
+		t.Fatal(err)
+	}
+
+	spunAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	rows := []Row{
+		{Line: 2, Nickname: "alice", Points: 50, SpunAt: spunAt},
+		{Line: 3, Nickname: "alice", Points: 30, SpunAt: spunAt.Add(time.Minute)},
+		{Line: 4, Nickname: "bob", Points: 20, SpunAt: spunAt},
+	}
+	importer := New(db, factory, 2)
+
+	points := func(nickname string) int {
+		t.Helper()
+		name, _ := playerdomain.NewNickname(nickname, 3, 20)
+		player, err := players.FindByNickname(ctx, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return player.TotalPoints().Value()
+	}
+
+	report, err := importer.Run(ctx, rows, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.SpinsInserted != 3 || report.PlayersCreated != 1 {
+		t.Fatalf("report = %+v, want 3 spins and 1 player", report)
+	}
+	if got := points("alice"); got != 180 {
+		t.Fatalf("alice has %d points, want 180", got)
+	}
+
+	// A re-run inserts nothing and adds nothing
+	report, err = importer.Run(ctx, rows, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.SpinsInserted != 0 || report.Duplicates != 3 {
+		t.Fatalf("re-run report = %+v, want 0 spins and 3 duplicates", report)
+	}
+	if got, gotBob := points("alice"), points("bob"); got != 180 || gotBob != 20 {
+		t.Fatalf("after re-run alice has %d and bob %d points, want 180 and 20", got, gotBob)
+	}
+}
@@ -0,0 +1,79 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"backend/internal/shared/logging"
+
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// GormLogger routes GORM logs to the request-scoped slog logger so SQL
+// lines carry the same request_id as the handler and use case lines
+type GormLogger struct {
+	slowThreshold time.Duration
+	level         gormlogger.LogLevel
+}
+
+// NewGormLogger creates a GORM logger; queries slower than slowThreshold are logged as warnings
+func NewGormLogger(slowThreshold time.Duration) *GormLogger {
+	return &GormLogger{
+		slowThreshold: slowThreshold,
+		level:         gormlogger.Info,
+	}
+}
+
+// LogMode implements gormlogger.Interface
+func (l *GormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	clone := *l
+	clone.level = level
+	return &clone
+}
+
+// Info implements gormlogger.Interface
+func (l *GormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Info {
+		logging.FromContext(ctx).InfoContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+// Warn implements gormlogger.Interface
+func (l *GormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Warn {
+		logging.FromContext(ctx).WarnContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+// Error implements gormlogger.Interface
+func (l *GormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Error {
+		logging.FromContext(ctx).ErrorContext(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+// Trace implements gormlogger.Interface
+func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+
+	log := logging.FromContext(ctx)
+	elapsed := time.Since(begin)
+
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && l.level >= gormlogger.Error:
+		sql, rows := fc()
+		log.ErrorContext(ctx, "sql query failed", "sql", sql, "rows", rows, "duration_ms", elapsed.Milliseconds(), "error", err)
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold && l.level >= gormlogger.Warn:
+		sql, rows := fc()
+		log.WarnContext(ctx, "slow sql query", "sql", sql, "rows", rows, "duration_ms", elapsed.Milliseconds(), "threshold_ms", l.slowThreshold.Milliseconds())
+	case l.level >= gormlogger.Info && log.Enabled(ctx, slog.LevelDebug):
+		sql, rows := fc()
+		log.DebugContext(ctx, "sql query", "sql", sql, "rows", rows, "duration_ms", elapsed.Milliseconds())
+	}
+}
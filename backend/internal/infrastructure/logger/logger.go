@@ -0,0 +1,37 @@
+package logger
+
+import (
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// New creates the application logger: JSON in production, text otherwise
+func New(level string, env string) *slog.Logger {
+	opts := &slog.HandlerOptions{
+		Level: ParseLevel(level),
+	}
+
+	var handler slog.Handler
+	if env == "production" {
+		handler = slog.NewJSONHandler(os.Stdout, opts)
+	} else {
+		handler = slog.NewTextHandler(os.Stdout, opts)
+	}
+
+	return slog.New(handler)
+}
+
+// ParseLevel maps LOG_LEVEL values to slog levels (defaults to info)
+func ParseLevel(level string) slog.Level {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
@@ -0,0 +1,25 @@
+package metrics
+
+import (
+	"context"
+	"strconv"
+
+	gamedomain "backend/internal/modules/game/domain"
+	playerdomain "backend/internal/modules/player/domain"
+	rewarddomain "backend/internal/modules/reward/domain"
+	shared "backend/internal/shared/domain"
+)
+
+// HandleEvent updates business counters from published domain events
+func (m *Metrics) HandleEvent(ctx context.Context, event shared.DomainEvent) {
+	switch e := event.(type) {
+	case *gamedomain.SpinExecutedEvent:
+		m.Spins.WithLabelValues(strconv.Itoa(e.PointsGained)).Inc()
+	case *gamedomain.DailyLimitReachedEvent:
+		m.DailyLimitRejections.Inc()
+	case *rewarddomain.RewardClaimedEvent:
+		m.RewardClaims.WithLabelValues(strconv.Itoa(e.CheckpointVal)).Inc()
+	case *playerdomain.PlayerCreatedEvent:
+		m.PlayersCreated.Inc()
+	}
+}
@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"database/sql"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "spinhead"
+
+// Metrics holds the Prometheus registry and all application collectors
+type Metrics struct {
+	registry *prometheus.Registry
+
+	HTTPRequests        *prometheus.CounterVec
+	HTTPRequestDuration *prometheus.HistogramVec
+
+	Spins                *prometheus.CounterVec
+	RewardClaims         *prometheus.CounterVec
+	DailyLimitRejections prometheus.Counter
+	PlayersCreated       prometheus.Counter
+
+	DBQueryDuration *prometheus.HistogramVec
+}
+
+// New creates and registers all collectors on a dedicated registry
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+
+		HTTPRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by method, route template and status code.",
+		}, []string{"method", "route", "status"}),
+
+		HTTPRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by method, route template and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+
+		Spins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "spins_total",
+			Help:      "Executed spins by points gained.",
+		}, []string{"points_gained"}),
+
+		RewardClaims: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reward_claims_total",
+			Help:      "Claimed rewards by checkpoint.",
+		}, []string{"checkpoint"}),
+
+		DailyLimitRejections: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "daily_limit_rejections_total",
+			Help:      "Spins rejected because the player reached the daily limit.",
+		}),
+
+		PlayersCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "players_created_total",
+			Help:      "Newly created players.",
+		}),
+
+		DBQueryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "db_query_duration_seconds",
+			Help:      "GORM query duration by operation and table.",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"operation", "table"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.HTTPRequests,
+		m.HTTPRequestDuration,
+		m.Spins,
+		m.RewardClaims,
+		m.DailyLimitRejections,
+		m.PlayersCreated,
+		m.DBQueryDuration,
+	)
+
+	return m
+}
+
+// RegisterDBStats exports sql.DB pool statistics (open, in use, idle, waits)
+func (m *Metrics) RegisterDBStats(db *sql.DB, dbName string) {
+	m.registry.MustRegister(collectors.NewDBStatsCollector(db, dbName))
+}
+
+// Handler serves the registry in Prometheus text format
+func (m *Metrics) Handler() fiber.Handler {
+	return adaptor.HTTPHandler(promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+}
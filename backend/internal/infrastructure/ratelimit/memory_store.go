@@ -0,0 +1,59 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryBucket struct {
+	state     State
+	expiresAt time.Time
+}
+
+// MemoryStore keeps buckets in process memory (single instance only)
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*memoryBucket
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: make(map[string]*memoryBucket),
+	}
+}
+
+// Take applies the policy to the bucket under a single lock
+func (s *MemoryStore) Take(ctx context.Context, key string, policy Policy, now time.Time) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &memoryBucket{}
+		s.buckets[key] = bucket
+	}
+
+	state, result := policy.Apply(bucket.state, now)
+	bucket.state = state
+	bucket.expiresAt = policy.ExpiresAt(state)
+
+	return result, nil
+}
+
+// DeleteExpired drops buckets past their expiry
+func (s *MemoryStore) DeleteExpired(ctx context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	var removed int64
+	for key, bucket := range s.buckets {
+		if bucket.expiresAt.Before(now) {
+			delete(s.buckets, key)
+			removed++
+		}
+	}
+	return removed, nil
+}
@@ -0,0 +1,157 @@
+package ratelimit
+
+import (
+	"math"
+	"time"
+)
+
+// Algorithm names
+const (
+	AlgorithmTokenBucket   = "token_bucket"
+	AlgorithmSlidingWindow = "sliding_window"
+)
+
+// Policy describes how many requests are allowed per window
+type Policy struct {
+	Name      string
+	Algorithm string
+	Limit     int
+	Window    time.Duration
+}
+
+// Result is the outcome of taking one request from a bucket
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	ResetAfter time.Duration // until the bucket is full again / the window ends
+	RetryAfter time.Duration // only set when not allowed
+}
+
+// State is the persisted bucket state shared by both algorithms
+type State struct {
+	// Token bucket
+	Tokens float64
+
+	// Sliding window
+	Count       int
+	PrevCount   int
+	WindowStart time.Time
+
+	UpdatedAt time.Time
+}
+
+// IsNew returns true if the bucket has never been used
+func (s State) IsNew() bool {
+	return s.UpdatedAt.IsZero()
+}
+
+// Apply takes one request from the bucket at now and returns the new state.
+// Storage implementations persist the returned state atomically.
+func (p Policy) Apply(state State, now time.Time) (State, Result) {
+	if p.Algorithm == AlgorithmSlidingWindow {
+		return p.applySlidingWindow(state, now)
+	}
+	return p.applyTokenBucket(state, now)
+}
+
+// ExpiresAt returns when a bucket in this state can be dropped
+func (p Policy) ExpiresAt(state State) time.Time {
+	if p.Algorithm == AlgorithmSlidingWindow {
+		return state.WindowStart.Add(2 * p.Window)
+	}
+	return state.UpdatedAt.Add(p.timeToRefill(state.Tokens, float64(p.Limit)))
+}
+
+// applyTokenBucket refills Limit tokens per Window, capacity Limit
+func (p Policy) applyTokenBucket(state State, now time.Time) (State, Result) {
+	capacity := float64(p.Limit)
+
+	tokens := capacity
+	if !state.IsNew() {
+		elapsed := now.Sub(state.UpdatedAt)
+		if elapsed < 0 {
+			elapsed = 0
+		}
+		tokens = math.Min(capacity, state.Tokens+elapsed.Seconds()*p.refillRate())
+	}
+
+	result := Result{Limit: p.Limit}
+	if tokens >= 1 {
+		tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = p.timeToRefill(tokens, 1)
+	}
+	result.Remaining = int(math.Floor(tokens))
+	result.ResetAfter = p.timeToRefill(tokens, capacity)
+
+	return State{Tokens: tokens, UpdatedAt: now}, result
+}
+
+// applySlidingWindow approximates a sliding window by weighting the
+// previous fixed window by how much of it still overlaps the current one
+func (p Policy) applySlidingWindow(state State, now time.Time) (State, Result) {
+	windowStart := now.Truncate(p.Window)
+
+	count, prevCount := state.Count, state.PrevCount
+	if state.IsNew() || !state.WindowStart.Equal(windowStart) {
+		if !state.IsNew() && state.WindowStart.Add(p.Window).Equal(windowStart) {
+			prevCount = count
+		} else {
+			prevCount = 0
+		}
+		count = 0
+	}
+
+	elapsed := now.Sub(windowStart)
+	overlap := 1 - float64(elapsed)/float64(p.Window)
+	estimated := float64(prevCount)*overlap + float64(count)
+
+	result := Result{Limit: p.Limit, ResetAfter: p.Window - elapsed}
+	if estimated+1 <= float64(p.Limit) {
+		count++
+		estimated++
+		result.Allowed = true
+	} else {
+		result.RetryAfter = p.slidingRetryAfter(prevCount, count, elapsed)
+	}
+	result.Remaining = p.Limit - int(math.Ceil(estimated))
+	if result.Remaining < 0 {
+		result.Remaining = 0
+	}
+
+	return State{Count: count, PrevCount: prevCount, WindowStart: windowStart, UpdatedAt: now}, result
+}
+
+// slidingRetryAfter estimates when one more request fits in the window
+func (p Policy) slidingRetryAfter(prevCount, count int, elapsed time.Duration) time.Duration {
+	untilWindowEnd := p.Window - elapsed
+	if prevCount == 0 || count+1 > p.Limit {
+		return untilWindowEnd
+	}
+
+	// Solve prevCount*(1 - (elapsed+t)/window) + count + 1 <= limit for t
+	needed := float64(prevCount+count+1-p.Limit) / float64(prevCount)
+	wait := time.Duration(needed*float64(p.Window)) - elapsed
+	if wait < 0 {
+		return 0
+	}
+	if wait > untilWindowEnd {
+		return untilWindowEnd
+	}
+	return wait
+}
+
+func (p Policy) refillRate() float64 {
+	return float64(p.Limit) / p.Window.Seconds()
+}
+
+func (p Policy) timeToRefill(tokens, target float64) time.Duration {
+	missing := target - tokens
+	if missing <= 0 {
+		return 0
+	}
+	// Round up so clients are never told to retry before a token is back
+	return time.Duration(math.Ceil(missing / p.refillRate() * float64(time.Second)))
+}
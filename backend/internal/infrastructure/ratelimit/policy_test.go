@@ -0,0 +1,67 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPolicyApply(t *testing.T) {
+	start := time.Unix(1_000_000_000, 0) // on a 10s window boundary
+
+	type step struct {
+		at         time.Duration // since start
+		allowed    bool
+		remaining  int
+		retryAfter time.Duration
+	}
+	tests := []struct {
+		name   string
+		policy Policy
+		steps  []step
+	}{
+		{
+			name:   "token bucket refills limit per window",
+			policy: Policy{Name: "tb", Algorithm: AlgorithmTokenBucket, Limit: 2, Window: 10 * time.Second},
+			steps: []step{
+				{0, true, 1, 0},
+				{0, true, 0, 0},
+				// One token refills every 5s
+				{0, false, 0, 5 * time.Second},
+				{2 * time.Second, false, 0, 3 * time.Second},
+				{5 * time.Second, true, 0, 0},
+				// Never more than the limit, however long it was idle
+				{time.Hour, true, 1, 0},
+			},
+		},
+		{
+			name:   "sliding window weights the previous window",
+			policy: Policy{Name: "sw", Algorithm: AlgorithmSlidingWindow, Limit: 2, Window: 10 * time.Second},
+			steps: []step{
+				{0, true, 1, 0},
+				{time.Second, true, 0, 0},
+				{2 * time.Second, false, 0, 8 * time.Second},
+				// The previous window still counts fully at its end, half way at 15s
+				{10 * time.Second, false, 0, 5 * time.Second},
+				{15 * time.Second, true, 0, 0},
+				// Two windows later nothing is left of it
+				{40 * time.Second, true, 1, 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var state State
+			for i, s := range tt.steps {
+				var result Result
+				state, result = tt.policy.Apply(state, start.Add(s.at))
+				if result.Allowed != s.allowed || result.Remaining != s.remaining || result.RetryAfter != s.retryAfter {
+					t.Fatalf("step %d at +%s: allowed %v remaining %d retry %s; want %v %d %s",
+						i, s.at, result.Allowed, result.Remaining, result.RetryAfter, s.allowed, s.remaining, s.retryAfter)
+				}
+				if result.Limit != tt.policy.Limit {
+					t.Fatalf("step %d: limit %d, want %d", i, result.Limit, tt.policy.Limit)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"backend/internal/shared/constants"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RateLimitBucketModel is the GORM database model
+type RateLimitBucketModel struct {
+	BucketKey   string     `gorm:"type:varchar(255);primaryKey"`
+	Tokens      float64    `gorm:"type:double precision;not null"`
+	Count       int        `gorm:"type:integer;not null"`
+	PrevCount   int        `gorm:"type:integer;not null"`
+	WindowStart *time.Time `gorm:"type:timestamptz"`
+	UpdatedAt   *time.Time `gorm:"type:timestamptz;autoUpdateTime:false"`
+	ExpiresAt   time.Time  `gorm:"not null;index:idx_rate_limit_buckets_expires_at"`
+}
+
+func (RateLimitBucketModel) TableName() string {
+	return constants.TableRateLimitBuckets
+}
+
+// PostgresStore shares buckets between instances via row locks
+type PostgresStore struct {
+	db *gorm.DB
+}
+
+// NewPostgresStore creates a new Postgres-backed store
+func NewPostgresStore(db *gorm.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// Take locks the bucket row, applies the policy and writes the new state
+func (s *PostgresStore) Take(ctx context.Context, key string, policy Policy, now time.Time) (Result, error) {
+	var result Result
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Make sure the row exists so it can be locked
+		if err := tx.Exec(
+			`INSERT INTO rate_limit_buckets (bucket_key, expires_at) VALUES ($1, $2) ON CONFLICT (bucket_key) DO NOTHING`,
+			key, now,
+		).Error; err != nil {
+			return err
+		}
+
+		var model RateLimitBucketModel
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("bucket_key = ?", key).
+			First(&model).Error; err != nil {
+			return err
+		}
+
+		state := State{
+			Tokens:    model.Tokens,
+			Count:     model.Count,
+			PrevCount: model.PrevCount,
+		}
+		if model.WindowStart != nil {
+			state.WindowStart = *model.WindowStart
+		}
+		if model.UpdatedAt != nil {
+			state.UpdatedAt = *model.UpdatedAt
+		}
+
+		newState, res := policy.Apply(state, now)
+		result = res
+
+		return tx.Model(&RateLimitBucketModel{}).
+			Where("bucket_key = ?", key).
+			Updates(map[string]interface{}{
+				"tokens":       newState.Tokens,
+				"count":        newState.Count,
+				"prev_count":   newState.PrevCount,
+				"window_start": nullableTime(newState.WindowStart),
+				"updated_at":   newState.UpdatedAt,
+				"expires_at":   policy.ExpiresAt(newState),
+			}).Error
+	})
+
+	return result, err
+}
+
+// DeleteExpired removes buckets past their expiry
+func (s *PostgresStore) DeleteExpired(ctx context.Context) (int64, error) {
+	result := s.db.WithContext(ctx).
+		Where("expires_at < ?", time.Now()).
+		Delete(&RateLimitBucketModel{})
+	return result.RowsAffected, result.Error
+}
+
+func nullableTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,43 @@
+package ratelimit
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Store names
+const (
+	StoreMemory   = "memory"
+	StorePostgres = "postgres"
+)
+
+// Store keeps bucket state for rate limiting
+type Store interface {
+	// Take consumes one request from the bucket identified by key
+	Take(ctx context.Context, key string, policy Policy, now time.Time) (Result, error)
+
+	// DeleteExpired removes buckets that are no longer needed
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+// RunJanitor periodically purges expired buckets and returns once ctx is cancelled
+func RunJanitor(ctx context.Context, store Store, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := store.DeleteExpired(ctx); err != nil {
+				slog.WarnContext(ctx, "failed to purge expired rate limit buckets", "error", err)
+			}
+		}
+	}
+}
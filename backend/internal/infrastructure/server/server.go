@@ -0,0 +1,70 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"time"
+
+	"backend/internal/infrastructure/health"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ShutdownConfig controls how the server drains on shutdown
+type ShutdownConfig struct {
+	// DrainDelay keeps serving after readiness fails so load balancers can deregister the instance
+	DrainDelay time.Duration
+	// Timeout bounds how long in-flight requests may run after the listener closes
+	Timeout time.Duration
+}
+
+// TrustProxies makes c.IP() the client address from header on requests
+// coming from the trusted proxies, so per-IP limits see clients rather than
+// the load balancer. Other requests, and every request when trusted is empty,
+// keep the peer address.
+func TrustProxies(cfg fiber.Config, trusted []string, header string) fiber.Config {
+	if len(trusted) == 0 {
+		return cfg
+	}
+	cfg.ProxyHeader = header
+	cfg.EnableTrustedProxyCheck = true
+	cfg.TrustedProxies = trusted
+	// Take the first valid address of a list like X-Forwarded-For
+	cfg.EnableIPValidation = true
+	return cfg
+}
+
+// Serve runs app on ln until ctx is cancelled, then flips readiness to
+// draining, stops accepting connections and waits for in-flight requests
+func Serve(ctx context.Context, app *fiber.App, ln net.Listener, healthRegistry *health.Registry, cfg ShutdownConfig) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- app.Listener(ln)
+	}()
+
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("server failed: %w", err)
+	case <-ctx.Done():
+	}
+
+	slog.Info("shutdown started, draining", "component", "server", "drain_delay", cfg.DrainDelay, "timeout", cfg.Timeout)
+	healthRegistry.SetDraining(true)
+
+	if cfg.DrainDelay > 0 {
+		time.Sleep(cfg.DrainDelay)
+	}
+
+	if err := app.ShutdownWithTimeout(cfg.Timeout); err != nil {
+		return fmt.Errorf("graceful shutdown failed: %w", err)
+	}
+
+	if err := <-serveErr; err != nil {
+		return fmt.Errorf("server failed: %w", err)
+	}
+
+	slog.Info("server stopped", "component", "server")
+	return nil
+}
@@ -0,0 +1,147 @@
+package server
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"backend/internal/infrastructure/health"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestServeDrainsInFlightRequestOnShutdown(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Get("/slow", func(c *fiber.Ctx) error {
+		close(started)
+		<-release
+		return c.SendString("done")
+	})
+
+	registry := health.NewRegistry(time.Second)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- Serve(ctx, app, ln, registry, ShutdownConfig{Timeout: 5 * time.Second})
+	}()
+
+	type result struct {
+		status int
+		body   string
+		err    error
+	}
+	resCh := make(chan result, 1)
+	go func() {
+		resp, err := http.Get("http://" + ln.Addr().String() + "/slow")
+		if err != nil {
+			resCh <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		resCh <- result{status: resp.StatusCode, body: string(body), err: err}
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("request never reached the handler")
+	}
+
+	// Shutdown begins while the request is still in flight
+	cancel()
+	deadline := time.Now().Add(2 * time.Second)
+	for !registry.IsDraining() {
+		if time.Now().After(deadline) {
+			t.Fatal("readiness was not flipped to draining")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if report := registry.Run(context.Background(), health.Readiness); report.Status != health.StatusDraining {
+		t.Fatalf("readiness status = %q, want %q", report.Status, health.StatusDraining)
+	}
+
+	// Serve must wait for the in-flight handler
+	select {
+	case err := <-serveErr:
+		t.Fatalf("Serve returned before in-flight request finished: %v", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	close(release)
+
+	res := <-resCh
+	if res.err != nil {
+		t.Fatalf("in-flight request failed: %v", res.err)
+	}
+	if res.status != fiber.StatusOK || res.body != "done" {
+		t.Fatalf("got %d %q, want 200 \"done\"", res.status, res.body)
+	}
+
+	select {
+	case err := <-serveErr:
+		if err != nil {
+			t.Fatalf("Serve returned error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve did not return after draining")
+	}
+
+	// New connections are refused once the listener is closed
+	if _, err := net.DialTimeout("tcp", ln.Addr().String(), time.Second); err == nil {
+		t.Fatal("listener still accepting connections after shutdown")
+	}
+}
+
+func TestServeReturnsErrorWhenDeadlineExceeded(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Get("/stuck", func(c *fiber.Ctx) error {
+		close(started)
+		<-release
+		return nil
+	})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- Serve(ctx, app, ln, health.NewRegistry(time.Second), ShutdownConfig{Timeout: 200 * time.Millisecond})
+	}()
+
+	go func() {
+		resp, err := http.Get("http://" + ln.Addr().String() + "/stuck")
+		if err == nil {
+			resp.Body.Close()
+		}
+	}()
+	<-started
+	cancel()
+
+	select {
+	case err := <-serveErr:
+		if err == nil {
+			t.Fatal("expected an error when the shutdown deadline is exceeded")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve did not honour the shutdown deadline")
+	}
+}
@@ -0,0 +1,42 @@
+package tracing
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+
+	"backend/internal/shared/contracttest"
+)
+
+func TestGormSpansNameTheDatabaseSystem(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	db := contracttest.OpenSQLite(t)
+	if err := db.Use(NewGormPlugin()); err != nil {
+		t.Fatal(err)
+	}
+	var n int64
+	if err := db.Table("players").Count(&n).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) == 0 {
+		t.Fatal("no span recorded")
+	}
+	for _, attr := range spans[len(spans)-1].Attributes() {
+		if attr.Key == semconv.DBSystemKey {
+			if attr != semconv.DBSystemSqlite {
+				t.Fatalf("%s = %q, want %q", attr.Key, attr.Value.AsString(), semconv.DBSystemSqlite.Value.AsString())
+			}
+			return
+		}
+	}
+	t.Fatalf("span has no %s attribute", semconv.DBSystemKey)
+}
@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"backend/internal/modules/game/application"
+	"backend/internal/shared/constants"
+	shared "backend/internal/shared/domain"
+	httputil "backend/internal/shared/http"
+)
+
+// RegisterErrors maps the game module's errors to HTTP responses
+func RegisterErrors(r *httputil.ErrorRegistry) {
+	r.Register(shared.ErrDailyLimitExceeded, constants.StatusTooManyRequests, constants.ErrCodeDailyLimitExceeded, "Daily spin limit reached")
+}
+
+// writeLegacyError writes errors of the unversioned spin route in its old
+// shape, which reported no spins left when the daily limit was reached
+func writeLegacyError(c *fiber.Ctx, status int, body httputil.ErrorBody) error {
+	resp := application.SpinErrorResponse{Code: body.Code, Message: body.Message}
+	if body.Code == constants.ErrCodeDailyLimitExceeded {
+		remaining := 0
+		resp.RemainingSpins = &remaining
+	}
+	return c.Status(status).JSON(resp)
+}
@@ -3,6 +3,8 @@ package handler
 import (
 	"backend/internal/modules/game/application"
 	"backend/internal/modules/game/application/spin"
+	shared "backend/internal/shared/domain"
+	httputil "backend/internal/shared/http"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -26,46 +28,25 @@ func NewGameHandler(executeSpinUC *spin.ExecuteSpinUseCase) *GameHandler {
 // @Accept       json
 // @Produce      json
 // @Param        request body application.SpinRequest true "Spin request"
-// @Success      200 {object} application.SpinResponse
-// @Failure      400 {object} application.SpinErrorResponse "Invalid request"
-// @Failure      404 {object} application.SpinErrorResponse "Player not found"
-// @Failure      429 {object} application.SpinErrorResponse "Daily limit exceeded"
-// @Failure      500 {object} application.SpinErrorResponse "Internal server error"
+// @Param        Idempotency-Key header string false "Replays the first response when a spin is retried with the same key"
+// @Success      200 {object} httputil.Response{data=application.SpinResponse}
+// @Failure      400 {object} object "Invalid request"
+// @Failure      403 {object} object "Player suspended, banned or deleted"
+// @Failure      404 {object} object "Player not found"
+// @Failure      409 {object} object "Idempotency-Key reused with a different body or still in progress, or player updated concurrently"
+// @Failure      429 {object} object "Daily limit exceeded"
+// @Failure      500 {object} object "Internal server error"
 // @Router       /game/spin [post]
 func (h *GameHandler) Spin(c *fiber.Ctx) error {
 	var req application.SpinRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(application.SpinErrorResponse{
-			Code:    "INVALID_REQUEST",
-			Message: err.Error(),
-		})
+		return shared.NewValidationError("Invalid request body")
 	}
 
-	resp, err := h.executeSpinUC.Execute(c.Context(), req)
+	resp, err := h.executeSpinUC.Execute(c.UserContext(), req)
 	if err != nil {
-		// Handle specific errors
-		if err.Error() == "player not found" {
-			return c.Status(fiber.StatusNotFound).JSON(application.SpinErrorResponse{
-				Code:    "PLAYER_NOT_FOUND",
-				Message: "Player not found",
-			})
-		}
-		if err == spin.ErrDailyLimitExceeded {
-			return c.Status(fiber.StatusTooManyRequests).JSON(application.SpinErrorResponse{
-				Code:           "DAILY_LIMIT_EXCEEDED",
-				Message:        "Daily spin limit reached",
-				RemainingSpins: intPtr(0),
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(application.SpinErrorResponse{
-			Code:    "INTERNAL_ERROR",
-			Message: err.Error(),
-		})
+		return err
 	}
 
-	return c.Status(fiber.StatusOK).JSON(resp)
-}
-
-func intPtr(val int) *int {
-	return &val
+	return httputil.Success(c, fiber.StatusOK, resp)
 }
@@ -2,6 +2,8 @@ package handler
 
 import (
 	"github.com/gofiber/fiber/v2"
+
+	httputil "backend/internal/shared/http"
 )
 
 // RegisterRoutes registers game routes
@@ -9,3 +11,9 @@ func RegisterRoutes(router fiber.Router, handler *GameHandler) {
 	game := router.Group("/game")
 	game.Post("/spin", handler.Spin)
 }
+
+// RegisterLegacyErrors keeps the unversioned spin route's error shape on a
+// router serving the legacy aliases
+func RegisterLegacyErrors(router fiber.Router) {
+	router.Use("/game/spin", httputil.LegacyErrors(writeLegacyError))
+}
@@ -7,12 +7,15 @@ type SpinRequest struct {
 
 // SpinResponse successful spin response
 type SpinResponse struct {
-	SpinID           string `json:"spin_id" example:"uuid-456"`
-	PointsGained     int    `json:"points_gained" example:"500"`
-	TotalPointsAfter int    `json:"total_points_after" example:"1500"`
+	SpinID           string  `json:"spin_id" example:"uuid-456"`
+	PointsGained     int     `json:"points_gained" example:"500"`
+	TotalPointsAfter int     `json:"total_points_after" example:"1500"`
+	Source           string  `json:"source" example:"GAME"`
+	Multiplier       float64 `json:"multiplier" example:"1"`
+	BonusSpinsLeft   int     `json:"bonus_spins_left" example:"0"`
 }
 
-// SpinErrorResponse error response with remaining spins
+// SpinErrorResponse is the error shape of the unversioned /game/spin route
 type SpinErrorResponse struct {
 	Code           string `json:"code" example:"DAILY_LIMIT_EXCEEDED"`
 	Message        string `json:"message" example:"Daily spin limit reached"`
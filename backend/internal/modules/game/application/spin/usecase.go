@@ -2,17 +2,20 @@ package spin
 
 import (
 	"context"
-	"errors"
+	"math"
+	"time"
 
 	"backend/internal/modules/game/application"
 	gamedomain "backend/internal/modules/game/domain"
 	historydomain "backend/internal/modules/history/domain"
 	playerdomain "backend/internal/modules/player/domain"
 	"backend/internal/shared/constants"
-)
-
-var (
-	ErrDailyLimitExceeded = errors.New("daily spin limit exceeded")
+	shared "backend/internal/shared/domain"
+	"backend/internal/shared/events"
+	"backend/internal/shared/i18n"
+	"backend/internal/shared/logging"
+	"backend/internal/shared/retry"
+	"backend/internal/shared/tracing"
 )
 
 // SpinLogDailyLimitChecker adapter to check daily spins
@@ -36,6 +39,8 @@ type ExecuteSpinUseCase struct {
 	spinLogRepo historydomain.SpinLogRepository
 	spinService *gamedomain.SpinDomainService
 	dailyLimit  *gamedomain.DailyLimitSpec
+	publisher   events.Publisher
+	retry       retry.Policy
 }
 
 // NewExecuteSpinUseCase creates a new use case
@@ -44,83 +49,161 @@ func NewExecuteSpinUseCase(
 	spinLogRepo historydomain.SpinLogRepository,
 	spinService *gamedomain.SpinDomainService,
 	dailyLimit *gamedomain.DailyLimitSpec,
+	publisher events.Publisher,
+	retryPolicy retry.Policy,
 ) *ExecuteSpinUseCase {
 	return &ExecuteSpinUseCase{
 		playerRepo:  playerRepo,
 		spinLogRepo: spinLogRepo,
 		spinService: spinService,
 		dailyLimit:  dailyLimit,
+		publisher:   publisher,
+		retry:       retryPolicy,
 	}
 }
 
+// spinOutcome is the stored result of steps 2-6
+type spinOutcome struct {
+	player       *playerdomain.Player
+	pointsGained *shared.Points
+	source       constants.SpinSource
+	multiplier   float64
+}
+
 // Execute performs a spin for the player
 // 1. Parse player ID
-// 2. Get player
-// 3. Check daily limit
-// 4. Execute spin (weighted random)
+// 2. Get player (suspended, banned and deleted players cannot spin)
+// 3. Check daily limit (a bonus spin is used once it is reached)
+// 4. Execute spin (weighted random) and apply any streak multiplier
 // 5. Add points to player
-// 6. Update player
+// 6. Update player (steps 2-6 are retried when a concurrent update wins)
 // 7. Create spin log
-// 8. Return result
-func (uc *ExecuteSpinUseCase) Execute(ctx context.Context, req application.SpinRequest) (*application.SpinResponse, error) {
+// 8. Publish domain events
+// 9. Return result
+func (uc *ExecuteSpinUseCase) Execute(ctx context.Context, req application.SpinRequest) (_ *application.SpinResponse, err error) {
+	ctx, span := tracing.Start(ctx, "game.spin.Execute")
+	defer func() { tracing.End(span, err) }()
+
 	// 1. Parse player ID
 	playerID, err := playerdomain.NewPlayerID(req.PlayerID)
 	if err != nil {
-		return nil, errors.New("invalid player ID")
+		return nil, shared.NewValidationError("invalid player ID")
 	}
 
-	// 2. Get player
-	player, err := uc.playerRepo.FindByID(ctx, playerID)
+	// 2-6. Spin and store the player's new total
+	var outcome *spinOutcome
+	err = uc.retry.Do(ctx, func(ctx context.Context) error {
+		var err error
+		outcome, err = uc.spin(ctx, playerID)
+		return err
+	})
 	if err != nil {
-		return nil, errors.New("player not found")
+		return nil, err
 	}
+	player, pointsGained, source, multiplier := outcome.player, outcome.pointsGained, outcome.source, outcome.multiplier
 
-	// 3. Check daily limit
-	canSpin, err := uc.dailyLimit.IsSatisfied(ctx, playerID.String())
+	// 7. Create spin log
+	spinLog, err := historydomain.NewSpinLog(
+		playerID.String(),
+		pointsGained.Value(),
+		source,
+	)
 	if err != nil {
 		return nil, err
 	}
-	if !canSpin {
-		return nil, ErrDailyLimitExceeded
-	}
 
-	// 4. Execute spin (weighted random)
-	pointsGained, err := uc.spinService.Spin()
+	err = uc.spinLogRepo.Store(ctx, spinLog)
 	if err != nil {
 		return nil, err
 	}
 
-	// 5. Add points to player
-	err = player.AddPoints(pointsGained)
+	// 8. Publish domain events
+	uc.publisher.Publish(ctx, player.DomainEvents()...)
+	player.ClearEvents()
+	uc.publisher.Publish(ctx, gamedomain.NewSpinExecutedEvent(
+		playerID.String(),
+		pointsGained.Value(),
+		player.TotalPoints().Value(),
+		string(source),
+	))
+
+	logging.FromContext(ctx).InfoContext(ctx, "spin executed",
+		"player_id", playerID.String(),
+		"spin_id", spinLog.ID().String(),
+		"points_gained", pointsGained.Value(),
+		"total_points_after", player.TotalPoints().Value(),
+		"source", source,
+		"multiplier", multiplier,
+	)
+
+	// 9. Return result
+	return &application.SpinResponse{
+		SpinID:           spinLog.ID().String(),
+		PointsGained:     pointsGained.Value(),
+		TotalPointsAfter: player.TotalPoints().Value(),
+		Source:           string(source),
+		Multiplier:       multiplier,
+		BonusSpinsLeft:   player.Boosts().BonusSpins(),
+	}, nil
+}
+
+// spin loads the player, spins and stores the new total (steps 2-6)
+func (uc *ExecuteSpinUseCase) spin(ctx context.Context, playerID *playerdomain.PlayerID) (*spinOutcome, error) {
+	// 2. Get player (its preferred locale wins over Accept-Language)
+	player, err := uc.playerRepo.FindByID(ctx, playerID)
 	if err != nil {
 		return nil, err
 	}
+	i18n.Prefer(ctx, player.Locale())
+	if err := player.CheckActive(time.Now()); err != nil {
+		return nil, err
+	}
 
-	// 6. Update player
-	err = uc.playerRepo.Update(ctx, player)
+	// 3. Check daily limit
+	canSpin, err := uc.dailyLimit.IsSatisfied(ctx, playerID.String())
 	if err != nil {
 		return nil, err
 	}
+	source := constants.SpinSourceGame
+	if !canSpin {
+		if player.Boosts().BonusSpins() == 0 {
+			logging.FromContext(ctx).InfoContext(ctx, "daily spin limit reached", "player_id", playerID.String())
+			uc.publisher.Publish(ctx, gamedomain.NewDailyLimitReachedEvent(playerID.String(), uc.dailyLimit.MaxDailySpins()))
+			return nil, shared.ErrDailyLimitExceeded
+		}
+		if err := player.UseBonusSpin(); err != nil {
+			return nil, err
+		}
+		source = constants.SpinSourceBonus
+	}
 
-	// 7. Create spin log
-	spinLog, err := historydomain.NewSpinLog(
-		playerID.String(),
-		pointsGained.Value(),
-		constants.SpinSourceGame,
-	)
+	// 4. Execute spin (weighted random)
+	pointsGained, err := uc.spinService.Spin()
 	if err != nil {
 		return nil, err
 	}
+	multiplier := player.Boosts().MultiplierAt(time.Now())
+	if multiplier != 1 {
+		pointsGained, err = shared.NewPoints(int(math.Round(float64(pointsGained.Value()) * multiplier)))
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	err = uc.spinLogRepo.Store(ctx, spinLog)
-	if err != nil {
+	// 5. Add points to player
+	if err := player.AddPoints(pointsGained); err != nil {
 		return nil, err
 	}
 
-	// 8. Return result
-	return &application.SpinResponse{
-		SpinID:           spinLog.ID().String(),
-		PointsGained:     pointsGained.Value(),
-		TotalPointsAfter: player.TotalPoints().Value(),
+	// 6. Update player (shared.ErrConcurrentModification when another update won)
+	if err := uc.playerRepo.Update(ctx, player); err != nil {
+		return nil, err
+	}
+
+	return &spinOutcome{
+		player:       player,
+		pointsGained: pointsGained,
+		source:       source,
+		multiplier:   multiplier,
 	}, nil
 }
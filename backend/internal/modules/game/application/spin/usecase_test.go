@@ -0,0 +1,224 @@
+package spin
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"backend/internal/modules/game/application"
+	gamedomain "backend/internal/modules/game/domain"
+	historydomain "backend/internal/modules/history/domain"
+	playerdomain "backend/internal/modules/player/domain"
+	shared "backend/internal/shared/domain"
+	"backend/internal/shared/retry"
+)
+
+// versionedPlayerRepo keeps one stored copy per player and, like the Gorm
+// repository, only accepts updates made from the latest version
+type versionedPlayerRepo struct {
+	playerdomain.PlayerRepository
+
+	mu      sync.Mutex
+	factory *playerdomain.PlayerFactory
+	rows    map[string]*playerdomain.Player
+	updates int
+
+	// beforeUpdate runs before each update is checked (to interleave writers)
+	beforeUpdate func(attempt int)
+}
+
+func newVersionedPlayerRepo() *versionedPlayerRepo {
+	return &versionedPlayerRepo{
+		factory: playerdomain.NewPlayerFactory(3, 50),
+		rows:    map[string]*playerdomain.Player{},
+	}
+}
+
+// clone returns an independent copy, as a fresh database read would
+func (r *versionedPlayerRepo) clone(row *playerdomain.Player) *playerdomain.Player {
+	copied, err := r.factory.ReconstructPlayer(
+		row.ID().String(),
+		row.Nickname().String(),
+		row.InviteCode().String(),
+		row.TotalPoints().Value(),
+		row.Streak(),
+		row.Boosts(),
+		row.Lifecycle(),
+		row.Locale(),
+		row.SignupIP(),
+		row.CreatedAt(),
+		row.UpdatedAt(),
+		row.Version(),
+	)
+	if err != nil {
+		panic(err)
+	}
+	return copied
+}
+
+func (r *versionedPlayerRepo) Store(ctx context.Context, player *playerdomain.Player) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rows[player.ID().String()] = r.clone(player)
+	return nil
+}
+
+func (r *versionedPlayerRepo) FindByID(ctx context.Context, id *playerdomain.PlayerID) (*playerdomain.Player, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	row, ok := r.rows[id.String()]
+	if !ok {
+		return nil, shared.ErrPlayerNotFound
+	}
+	return r.clone(row), nil
+}
+
+func (r *versionedPlayerRepo) Update(ctx context.Context, player *playerdomain.Player) error {
+	r.mu.Lock()
+	r.updates++
+	attempt := r.updates
+	hook := r.beforeUpdate
+	r.mu.Unlock()
+	if hook != nil {
+		hook(attempt)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.rows[player.ID().String()]
+	if !ok {
+		return shared.ErrPlayerNotFound
+	}
+	if stored.Version() != player.Version() {
+		return shared.ErrConcurrentModification
+	}
+	player.SetVersion(player.Version() + 1)
+	r.rows[player.ID().String()] = r.clone(player)
+	return nil
+}
+
+// spinLogStub stores nothing and never reaches the daily limit
+type spinLogStub struct {
+	historydomain.SpinLogRepository
+}
+
+func (spinLogStub) Store(ctx context.Context, spinLog *historydomain.SpinLog) error {
+	return nil
+}
+
+func (spinLogStub) CountTodayByPlayer(ctx context.Context, playerID string) (int, error) {
+	return 0, nil
+}
+
+type noopPublisher struct{}
+
+func (noopPublisher) Publish(ctx context.Context, events ...shared.DomainEvent) {}
+
+type fixedRandom struct{}
+
+func (fixedRandom) Intn(n int) int { return 0 }
+
+// newTestUseCase spins a fixed 300 points per spin
+func newTestUseCase(t *testing.T, repo *versionedPlayerRepo, attempts int) *ExecuteSpinUseCase {
+	t.Helper()
+	dist, err := gamedomain.NewSpinDistribution([]gamedomain.SpinDistributionItem{{Points: 300, Weight: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	logs := spinLogStub{}
+	return NewExecuteSpinUseCase(
+		repo,
+		logs,
+		gamedomain.NewSpinDomainService(dist, fixedRandom{}),
+		gamedomain.NewDailyLimitSpec(100, NewSpinLogDailyLimitChecker(logs)),
+		noopPublisher{},
+		retry.Policy{MaxAttempts: attempts},
+	)
+}
+
+func storePlayer(t *testing.T, repo *versionedPlayerRepo) *playerdomain.Player {
+	t.Helper()
+	player, err := repo.factory.CreateNewPlayer("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Store(context.Background(), player); err != nil {
+		t.Fatal(err)
+	}
+	return player
+}
+
+// creditConcurrently plays another request: load a second copy and store
+// points before the spin's own update lands
+func creditConcurrently(t *testing.T, repo *versionedPlayerRepo, id *playerdomain.PlayerID, amount int) {
+	t.Helper()
+	other, err := repo.FindByID(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	points, _ := shared.NewPoints(amount)
+	if err := other.AddPoints(points); err != nil {
+		t.Fatal(err)
+	}
+	hook := repo.beforeUpdate
+	repo.beforeUpdate = nil
+	defer func() { repo.beforeUpdate = hook }()
+	if err := repo.Update(context.Background(), other); err != nil {
+		t.Fatalf("concurrent update: %v", err)
+	}
+}
+
+func TestSpinRetriesAfterConcurrentUpdate(t *testing.T) {
+	repo := newVersionedPlayerRepo()
+	player := storePlayer(t, repo)
+	uc := newTestUseCase(t, repo, 3)
+
+	// Another writer credits 500 points between the spin's load and update
+	repo.beforeUpdate = func(attempt int) {
+		if attempt == 1 {
+			creditConcurrently(t, repo, player.ID(), 500)
+		}
+	}
+
+	resp, err := uc.Execute(context.Background(), application.SpinRequest{PlayerID: player.ID().String()})
+	if err != nil {
+		t.Fatalf("spin: %v", err)
+	}
+
+	// Neither write is lost
+	if resp.TotalPointsAfter != 800 {
+		t.Fatalf("total points after spin = %d, want 800", resp.TotalPointsAfter)
+	}
+	stored, _ := repo.FindByID(context.Background(), player.ID())
+	if got := stored.TotalPoints().Value(); got != 800 {
+		t.Fatalf("stored total points = %d, want 800", got)
+	}
+}
+
+func TestSpinGivesUpAfterMaxAttempts(t *testing.T) {
+	repo := newVersionedPlayerRepo()
+	player := storePlayer(t, repo)
+	uc := newTestUseCase(t, repo, 2)
+
+	// Every attempt loses against another writer
+	credits := 0
+	repo.beforeUpdate = func(int) {
+		credits++
+		creditConcurrently(t, repo, player.ID(), 100)
+	}
+
+	_, err := uc.Execute(context.Background(), application.SpinRequest{PlayerID: player.ID().String()})
+	if !errors.Is(err, shared.ErrConcurrentModification) {
+		t.Fatalf("spin error = %v, want ErrConcurrentModification", err)
+	}
+	if credits != 2 {
+		t.Fatalf("spin attempted %d times, want 2", credits)
+	}
+
+	// Only the other writer's points were stored
+	stored, _ := repo.FindByID(context.Background(), player.ID())
+	if got := stored.TotalPoints().Value(); got != 200 {
+		t.Fatalf("stored total points = %d, want 200", got)
+	}
+}
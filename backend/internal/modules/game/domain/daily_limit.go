@@ -43,3 +43,8 @@ func (s *DailyLimitSpec) RemainingSpins(ctx context.Context, playerID string) (i
 	}
 	return remaining, nil
 }
+
+// MaxDailySpins returns the configured daily limit
+func (s *DailyLimitSpec) MaxDailySpins() int {
+	return s.maxDailySpins
+}
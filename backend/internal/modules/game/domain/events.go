@@ -37,3 +37,26 @@ func NewSpinExecutedEvent(
 func (e *SpinExecutedEvent) EventType() string {
 	return "game.spin_executed"
 }
+
+// DailyLimitReachedEvent fired when a spin is rejected by the daily limit
+type DailyLimitReachedEvent struct {
+	shared.BaseEvent
+	PlayerID      string
+	MaxDailySpins int
+	RejectedAt    time.Time
+}
+
+// NewDailyLimitReachedEvent creates a new daily limit reached event
+func NewDailyLimitReachedEvent(playerID string, maxDailySpins int) *DailyLimitReachedEvent {
+	return &DailyLimitReachedEvent{
+		BaseEvent:     shared.NewBaseEvent(playerID),
+		PlayerID:      playerID,
+		MaxDailySpins: maxDailySpins,
+		RejectedAt:    time.Now(),
+	}
+}
+
+// EventType returns the event type
+func (e *DailyLimitReachedEvent) EventType() string {
+	return "game.daily_limit_reached"
+}
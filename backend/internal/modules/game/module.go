@@ -1,7 +1,7 @@
 package game
 
 import (
-	"database/sql"
+	"context"
 
 	"backend/internal/infrastructure/config"
 	"backend/internal/modules/game/adapter/handler"
@@ -9,6 +9,10 @@ import (
 	"backend/internal/modules/game/domain"
 	historydomain "backend/internal/modules/history/domain"
 	playerdomain "backend/internal/modules/player/domain"
+	shared "backend/internal/shared/domain"
+	"backend/internal/shared/events"
+	httputil "backend/internal/shared/http"
+	"backend/internal/shared/retry"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -21,9 +25,9 @@ type Module struct {
 // NewModule initializes game module
 func NewModule(
 	cfg *config.Config,
-	db *sql.DB,
 	playerRepo playerdomain.PlayerRepository,
 	spinLogRepo historydomain.SpinLogRepository,
+	publisher events.Publisher,
 ) (*Module, error) {
 	// Convert config items to domain items (keeps domain pure)
 	domainItems := make([]domain.SpinDistributionItem, len(cfg.Game.Spin.Distribution))
@@ -51,7 +55,8 @@ func NewModule(
 	dailyLimit := domain.NewDailyLimitSpec(cfg.Game.Spin.MaxDailySpins, limitChecker)
 
 	// Create use case
-	executeSpinUC := spin.NewExecuteSpinUseCase(playerRepo, spinLogRepo, spinService, dailyLimit)
+	retryPolicy := retry.Policy{MaxAttempts: cfg.Players.UpdateMaxAttempts, Backoff: cfg.Players.UpdateRetryBackoff}
+	executeSpinUC := spin.NewExecuteSpinUseCase(playerRepo, spinLogRepo, spinService, dailyLimit, publisher, retryPolicy)
 
 	// Create handler
 	gameHandler := handler.NewGameHandler(executeSpinUC)
@@ -61,7 +66,28 @@ func NewModule(
 	}, nil
 }
 
-// RegisterRoutes registers game routes
+// RegisterRoutes registers game routes on a versioned API router
 func (m *Module) RegisterRoutes(router fiber.Router) {
 	handler.RegisterRoutes(router, m.Handler)
 }
+
+// RegisterReadPinning calls pin with the player of every executed spin so
+// their next reads see it (read-your-writes)
+func (m *Module) RegisterReadPinning(bus *events.Bus, pin func(playerID string)) {
+	bus.Subscribe("game.spin_executed", func(ctx context.Context, event shared.DomainEvent) {
+		if e, ok := event.(*domain.SpinExecutedEvent); ok {
+			pin(e.PlayerID)
+		}
+	})
+}
+
+// RegisterLegacyErrors keeps the unversioned game routes' error shapes; call
+// it on the legacy router before RegisterRoutes
+func (m *Module) RegisterLegacyErrors(router fiber.Router) {
+	handler.RegisterLegacyErrors(router)
+}
+
+// RegisterErrors registers the game module's errors for the HTTP error handler
+func (m *Module) RegisterErrors(r *httputil.ErrorRegistry) {
+	handler.RegisterErrors(r)
+}
@@ -0,0 +1,160 @@
+package game_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"backend/internal/infrastructure/config"
+	"backend/internal/modules/game"
+	historyrepo "backend/internal/modules/history/adapter/repository"
+	playerrepo "backend/internal/modules/player/adapter/repository"
+	playerdomain "backend/internal/modules/player/domain"
+	"backend/internal/shared/cursor"
+	"backend/internal/shared/events"
+	httputil "backend/internal/shared/http"
+)
+
+func TestSpinUsesEnvelope(t *testing.T) {
+	cfg := &config.Config{
+		Game: config.GameConfig{Spin: config.SpinConfig{
+			MaxDailySpins: 10,
+			Distribution:  []config.SpinDistributionItem{{Points: 50, Weight: 1}},
+		}},
+		Players: config.PlayersConfig{UpdateMaxAttempts: 1},
+	}
+	factory := playerdomain.NewPlayerFactory(3, 20)
+	players := playerrepo.NewPlayerRepositoryMemory(factory)
+	m, err := game.NewModule(cfg, players, historyrepo.NewSpinLogRepositoryMemory(players, cursor.NewCodec("secret")), events.NewBus())
+	if err != nil {
+		t.Fatal(err)
+	}
+	player, _ := factory.CreateNewPlayer("alice")
+	if err := players.Store(context.Background(), player); err != nil {
+		t.Fatal(err)
+	}
+
+	registry := httputil.NewErrorRegistry()
+	m.RegisterErrors(registry)
+	app := fiber.New(fiber.Config{ErrorHandler: httputil.ErrorHandler(registry)})
+	m.RegisterRoutes(app.Group("/v1"))
+
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/game/spin", strings.NewReader(`{"player_id":"`+player.ID().String()+`"}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var env struct {
+		Success bool `json:"success"`
+		Data    struct {
+			PointsGained int `json:"points_gained"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&env); err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != fiber.StatusOK || !env.Success || env.Data.PointsGained != 50 {
+		t.Fatalf("status %d, body %+v; want 200 with 50 points in data", res.StatusCode, env)
+	}
+}
+
+func TestLegacySpinKeepsOldShapes(t *testing.T) {
+	cfg := &config.Config{
+		Game: config.GameConfig{Spin: config.SpinConfig{
+			MaxDailySpins: 1,
+			Distribution:  []config.SpinDistributionItem{{Points: 50, Weight: 1}},
+		}},
+		Players: config.PlayersConfig{UpdateMaxAttempts: 1},
+	}
+	factory := playerdomain.NewPlayerFactory(3, 20)
+	players := playerrepo.NewPlayerRepositoryMemory(factory)
+	m, err := game.NewModule(cfg, players, historyrepo.NewSpinLogRepositoryMemory(players, cursor.NewCodec("secret")), events.NewBus())
+	if err != nil {
+		t.Fatal(err)
+	}
+	player, _ := factory.CreateNewPlayer("alice")
+	if err := players.Store(context.Background(), player); err != nil {
+		t.Fatal(err)
+	}
+
+	registry := httputil.NewErrorRegistry()
+	m.RegisterErrors(registry)
+	app := fiber.New(fiber.Config{ErrorHandler: httputil.ErrorHandler(registry)})
+	app.Use("/game", httputil.Legacy())
+	m.RegisterLegacyErrors(app)
+	m.RegisterRoutes(app)
+
+	spin := func(status int) map[string]any {
+		t.Helper()
+		req := httptest.NewRequest(fiber.MethodPost, "/game/spin", strings.NewReader(`{"player_id":"`+player.ID().String()+`"}`))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		res, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		raw, _ := io.ReadAll(res.Body)
+		var body map[string]any
+		if err := json.Unmarshal(raw, &body); err != nil {
+			t.Fatalf("decode %s: %v", raw, err)
+		}
+		if res.StatusCode != status {
+			t.Fatalf("status %d, want %d (%s)", res.StatusCode, status, raw)
+		}
+		return body
+	}
+
+	// Successes are the bare SpinResponse
+	if body := spin(fiber.StatusOK); body["points_gained"] != float64(50) || body["success"] != nil {
+		t.Fatalf("spin body = %v, want a bare SpinResponse", body)
+	}
+	// Errors are the flat SpinErrorResponse
+	body := spin(fiber.StatusTooManyRequests)
+	if body["code"] != "DAILY_LIMIT_EXCEEDED" || body["remaining_spins"] != float64(0) || body["error"] != nil {
+		t.Fatalf("limit body = %v, want a flat SpinErrorResponse", body)
+	}
+}
+
+func TestSpinPinsPlayerReads(t *testing.T) {
+	cfg := &config.Config{
+		Game: config.GameConfig{Spin: config.SpinConfig{
+			MaxDailySpins: 10,
+			Distribution:  []config.SpinDistributionItem{{Points: 50, Weight: 1}},
+		}},
+		Players: config.PlayersConfig{UpdateMaxAttempts: 1},
+	}
+	factory := playerdomain.NewPlayerFactory(3, 20)
+	players := playerrepo.NewPlayerRepositoryMemory(factory)
+	bus := events.NewBus()
+	m, err := game.NewModule(cfg, players, historyrepo.NewSpinLogRepositoryMemory(players, cursor.NewCodec("secret")), bus)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pinned []string
+	m.RegisterReadPinning(bus, func(playerID string) { pinned = append(pinned, playerID) })
+
+	player, _ := factory.CreateNewPlayer("alice")
+	if err := players.Store(context.Background(), player); err != nil {
+		t.Fatal(err)
+	}
+	app := fiber.New(fiber.Config{ErrorHandler: httputil.ErrorHandler(httputil.NewErrorRegistry())})
+	m.RegisterRoutes(app.Group("/v1"))
+
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/game/spin", strings.NewReader(`{"player_id":"`+player.ID().String()+`"}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != fiber.StatusOK {
+		t.Fatalf("spin status = %d, want 200", res.StatusCode)
+	}
+	if len(pinned) != 1 || pinned[0] != player.ID().String() {
+		t.Fatalf("pinned %v, want [%s]", pinned, player.ID())
+	}
+}
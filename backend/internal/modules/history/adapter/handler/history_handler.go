@@ -2,9 +2,10 @@ package handler
 
 import (
 	"backend/internal/modules/history/application"
+	"backend/internal/modules/history/application/export_spins"
 	"backend/internal/modules/history/application/get_global"
 	"backend/internal/modules/history/application/get_personal"
-	"backend/internal/shared/constants"
+	shared "backend/internal/shared/domain"
 	httputil "backend/internal/shared/http"
 
 	"github.com/gofiber/fiber/v2"
@@ -13,15 +14,18 @@ import (
 type HistoryHandler struct {
 	getGlobalUC   *get_global.UseCase
 	getPersonalUC *get_personal.UseCase
+	exportUC      *export_spins.UseCase
 }
 
 func NewHistoryHandler(
 	getGlobalUC *get_global.UseCase,
 	getPersonalUC *get_personal.UseCase,
+	exportUC *export_spins.UseCase,
 ) *HistoryHandler {
 	return &HistoryHandler{
 		getGlobalUC:   getGlobalUC,
 		getPersonalUC: getPersonalUC,
+		exportUC:      exportUC,
 	}
 }
 
@@ -32,23 +36,29 @@ func NewHistoryHandler(
 // @Accept json
 // @Produce json
 // @Param limit query int false "Number of items per page" default(20)
-// @Param cursor query string false "Cursor for next page (from previous response)"
-// @Success 200 {object} application.GlobalHistoryResponse
+// @Param cursor query string false "next_cursor or prev_cursor from a previous response (signed; carries the filters)"
+// @Param from query string false "Only entries at or after this RFC3339 timestamp"
+// @Param to query string false "Only entries before this RFC3339 timestamp"
+// @Param source query string false "Spin source" Enums(GAME, BONUS, ADMIN)
+// @Param min_points query int false "Minimum points gained"
+// @Param max_points query int false "Maximum points gained"
+// @Param nickname_prefix query string false "Only players whose nickname starts with this prefix"
+// @Success 200 {object} httputil.Response{data=application.GlobalHistoryResponse}
 // @Failure 400 {object} object
 // @Failure 500 {object} object
 // @Router /history/global [get]
 func (h *HistoryHandler) GetGlobal(c *fiber.Ctx) error {
 	var req application.GetGlobalRequest
 	if err := c.QueryParser(&req); err != nil {
-		return httputil.BadRequest(c, constants.ErrCodeValidationFailed, err.Error())
+		return shared.Invalid(err)
 	}
 
-	resp, err := h.getGlobalUC.Execute(c.Context(), req)
+	resp, err := h.getGlobalUC.Execute(c.UserContext(), req)
 	if err != nil {
-		return httputil.BadRequest(c, constants.ErrCodeValidationFailed, err.Error())
+		return err
 	}
 
-	return c.JSON(resp)
+	return httputil.Success(c, fiber.StatusOK, resp)
 }
 
 // GetPersonal handles GET /history/:player_id (cursor-based)
@@ -59,8 +69,13 @@ func (h *HistoryHandler) GetGlobal(c *fiber.Ctx) error {
 // @Produce json
 // @Param player_id path string true "Player ID"
 // @Param limit query int false "Number of items per page" default(20)
-// @Param cursor query string false "Cursor for next page (from previous response)"
-// @Success 200 {object} application.PersonalHistoryResponse
+// @Param cursor query string false "next_cursor or prev_cursor from a previous response (signed; carries the filters)"
+// @Param from query string false "Only entries at or after this RFC3339 timestamp"
+// @Param to query string false "Only entries before this RFC3339 timestamp"
+// @Param source query string false "Spin source" Enums(GAME, BONUS, ADMIN)
+// @Param min_points query int false "Minimum points gained"
+// @Param max_points query int false "Maximum points gained"
+// @Success 200 {object} httputil.Response{data=application.PersonalHistoryResponse}
 // @Failure 400 {object} object
 // @Failure 404 {object} object
 // @Failure 500 {object} object
@@ -69,13 +84,48 @@ func (h *HistoryHandler) GetPersonal(c *fiber.Ctx) error {
 	var req application.GetPersonalRequest
 	req.PlayerID = c.Params("player_id")
 	if err := c.QueryParser(&req); err != nil {
-		return httputil.BadRequest(c, constants.ErrCodeValidationFailed, err.Error())
+		return shared.Invalid(err)
 	}
 
-	resp, err := h.getPersonalUC.Execute(c.Context(), req)
+	resp, err := h.getPersonalUC.Execute(c.UserContext(), req)
 	if err != nil {
-		return httputil.BadRequest(c, constants.ErrCodeValidationFailed, err.Error())
+		return err
 	}
 
-	return c.JSON(resp)
+	return httputil.Success(c, fiber.StatusOK, resp)
+}
+
+// Export handles GET /admin/history/export
+// @Summary Export spin history (admin)
+// @Description Stream all spins matching the filters as CSV or NDJSON, oldest first. Columns and timezone default to export.yaml.
+// @Tags Admin
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param X-Admin-Key header string true "Admin API key"
+// @Param format query string false "Output format" Enums(csv, ndjson) default(csv)
+// @Param columns query string false "Comma-separated columns (id, player_id, nickname, points_gained, source, created_at)"
+// @Param tz query string false "IANA timezone for timestamps, e.g. Asia/Bangkok"
+// @Param player_id query string false "Only this player's spins"
+// @Param from query string false "Only entries at or after this RFC3339 timestamp"
+// @Param to query string false "Only entries before this RFC3339 timestamp"
+// @Param source query string false "Spin source" Enums(GAME, BONUS, ADMIN)
+// @Param min_points query int false "Minimum points gained"
+// @Param max_points query int false "Maximum points gained"
+// @Param nickname_prefix query string false "Only players whose nickname starts with this prefix"
+// @Success 200 {file} file
+// @Failure 400 {object} object
+// @Failure 401 {object} object "Missing or invalid admin key"
+// @Router /admin/history/export [get]
+func (h *HistoryHandler) Export(c *fiber.Ctx) error {
+	var req application.ExportRequest
+	if err := c.QueryParser(&req); err != nil {
+		return shared.Invalid(err)
+	}
+
+	job, err := h.exportUC.Prepare(req)
+	if err != nil {
+		return err
+	}
+
+	return httputil.Export(c, job)
 }
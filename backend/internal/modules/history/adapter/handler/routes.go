@@ -2,8 +2,8 @@ package handler
 
 import "github.com/gofiber/fiber/v2"
 
-func (h *HistoryHandler) RegisterRoutes(app *fiber.App) {
-	history := app.Group("/history")
+func (h *HistoryHandler) RegisterRoutes(router fiber.Router) {
+	history := router.Group("/history")
 
 	// Global history (cursor-based)
 	history.Get("/global", h.GetGlobal)
@@ -11,3 +11,9 @@ func (h *HistoryHandler) RegisterRoutes(app *fiber.App) {
 	// Personal history (cursor-based) - must be after /global to avoid conflicts
 	history.Get("/:player_id", h.GetPersonal)
 }
+
+// RegisterAdminRoutes registers history admin routes on an authenticated router
+func (h *HistoryHandler) RegisterAdminRoutes(admin fiber.Router) {
+	// Streaming CSV/NDJSON export of every player's spins
+	admin.Get("/history/export", h.Export)
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"sync"
+	"testing"
+
+	"backend/internal/shared/contracttest"
+	"backend/internal/shared/cursor"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func TestSpinLogRepositoryMemoryContract(t *testing.T) {
+	contracttest.SpinLogRepository(t, func(t *testing.T) contracttest.SpinLogFixture {
+		players := &nicknames{byID: make(map[string]string)}
+		return contracttest.SpinLogFixture{
+			Repo:      NewSpinLogRepositoryMemory(players, cursor.NewCodec("contract-test")),
+			AddPlayer: players.add,
+		}
+	})
+}
+
+func TestSpinLogRepositoryGormContract(t *testing.T) {
+	testSpinLogRepositoryGorm(t, contracttest.OpenPostgres(t))
+}
+
+func TestSpinLogRepositorySQLiteContract(t *testing.T) {
+	testSpinLogRepositoryGorm(t, contracttest.OpenSQLite(t))
+}
+
+func testSpinLogRepositoryGorm(t *testing.T, db *gorm.DB) {
+	contracttest.SpinLogRepository(t, func(t *testing.T) contracttest.SpinLogFixture {
+		return contracttest.SpinLogFixture{
+			Repo: NewSpinLogRepositoryGorm(db, nil, cursor.NewCodec("contract-test")),
+			AddPlayer: func(nickname string) string {
+				id := uuid.NewString()
+				if err := db.Exec("INSERT INTO players (id, nickname) VALUES (?, ?)", id, nickname).Error; err != nil {
+					t.Fatalf("insert player: %v", err)
+				}
+				t.Cleanup(func() {
+					db.Exec("DELETE FROM spin_logs WHERE player_id = ?", id)
+					db.Exec("DELETE FROM players WHERE id = ?", id)
+				})
+				return id
+			},
+		}
+	})
+}
+
+// nicknames stands in for the player repository
+type nicknames struct {
+	mu   sync.Mutex
+	byID map[string]string
+}
+
+func (n *nicknames) add(nickname string) string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	id := uuid.NewString()
+	n.byID[id] = nickname
+	return id
+}
+
+func (n *nicknames) Nickname(playerID string) string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.byID[playerID]
+}
@@ -1,166 +1,230 @@
 package repository
 
 import (
+	"backend/internal/infrastructure/database"
 	"backend/internal/modules/history/domain"
+	"backend/internal/shared/constants"
+	"backend/internal/shared/cursor"
 	shared "backend/internal/shared/domain"
 	"context"
 	"errors"
+	"net/url"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
 )
 
 type SpinLogRepositoryGorm struct {
-	db *gorm.DB
+	db      *gorm.DB
+	reads   *database.Router // history listings may be served by replicas
+	cursors *cursor.Codec
 }
 
-func NewSpinLogRepositoryGorm(db *gorm.DB) *SpinLogRepositoryGorm {
-	return &SpinLogRepositoryGorm{db: db}
+func NewSpinLogRepositoryGorm(db *gorm.DB, reads *database.Router, cursors *cursor.Codec) *SpinLogRepositoryGorm {
+	if reads == nil {
+		reads = database.NewRouter(db, nil, 0)
+	}
+	return &SpinLogRepositoryGorm{db: db, reads: reads, cursors: cursors}
 }
 
 func (r *SpinLogRepositoryGorm) Store(ctx context.Context, spinLog *domain.SpinLog) error {
-	model := r.toModel(spinLog)
-	return r.db.WithContext(ctx).Create(model).Error
+	model := toSpinLogModel(spinLog)
+	return database.Conn(ctx, r.db).Create(model).Error
 }
 
 func (r *SpinLogRepositoryGorm) FindByID(ctx context.Context, id *domain.SpinLogID) (*domain.SpinLog, error) {
 	var model SpinLogModel
-	err := r.db.WithContext(ctx).Where("id = ?", id.String()).First(&model).Error
+	err := database.Conn(ctx, r.db).Where("id = ?", id.String()).First(&model).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("spin log not found")
 		}
 		return nil, err
 	}
-	return r.toDomain(&model)
+	return toSpinLogDomain(&model)
 }
 
+// CountTodayByPlayer counts GAME spins only; bonus spins and streak rewards
+// do not use up the daily limit
 func (r *SpinLogRepositoryGorm) CountTodayByPlayer(ctx context.Context, playerID string) (int, error) {
 	var count int64
 	today := time.Now().Truncate(24 * time.Hour)
 	tomorrow := today.Add(24 * time.Hour)
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Model(&SpinLogModel{}).
-		Where("player_id = ? AND created_at >= ? AND created_at < ?", playerID, today, tomorrow).
+		Where("player_id = ? AND source = ? AND created_at >= ? AND created_at < ?",
+			playerID, string(constants.SpinSourceGame), today, tomorrow).
 		Count(&count).Error
 	return int(count), err
 }
 
 // ========== Cursor-based Pagination Methods ==========
 
-func (r *SpinLogRepositoryGorm) ListAllCursor(ctx context.Context, params shared.CursorParams) (*domain.SpinLogCursorResult, error) {
-	var models []*SpinLogModel
+// Keysets for history listings (newest first, id breaks ties)
+var (
+	globalHistoryKeyset = database.Keyset{
+		Scope: "history.global",
+		Columns: []database.KeyColumn{
+			{Column: "spin_logs.created_at", Type: database.KeyTime, Desc: true},
+			{Column: "spin_logs.id", Type: database.KeyString, Desc: true},
+		},
+	}
+	personalHistoryKeyset = database.Keyset{
+		Scope:   "history.personal",
+		Columns: globalHistoryKeyset.Columns,
+	}
+)
 
-	query := r.db.WithContext(ctx).
-		Preload("Player").
-		Order("created_at DESC, id DESC")
+func spinLogKey(m *SpinLogModel) []any {
+	return []any{m.CreatedAt, m.ID}
+}
 
-	// Apply cursor filter if provided
-	cursorData, err := shared.DecodeCursor(params.Cursor)
+func (r *SpinLogRepositoryGorm) ListAllCursor(ctx context.Context, params shared.CursorParams, filter domain.SpinLogFilter) (*domain.SpinLogCursorResult, error) {
+	req := database.PageRequest{Limit: params.Limit, Cursor: params.Cursor, Filter: filter.Encode()}
+	pos, err := globalHistoryKeyset.Position(r.cursors, req)
 	if err != nil {
 		return nil, err
 	}
-	if cursorData != nil {
-		// Seek to position after cursor using composite comparison
-		query = query.Where(
-			"(created_at < ?) OR (created_at = ? AND id < ?)",
-			cursorData.CreatedAt, cursorData.CreatedAt, cursorData.ID,
-		)
+
+	var page *database.Page[SpinLogModel]
+	err = r.reads.Read(ctx, func(db *gorm.DB) error {
+		query := db.WithContext(ctx).Preload("Player")
+		if filter.NicknamePrefix != "" {
+			query = query.
+				Joins("JOIN players ON players.id = spin_logs.player_id").
+				Where("players.nickname LIKE ? ESCAPE '\\'", escapeLike(filter.NicknamePrefix)+"%")
+		}
+		query = applyFilter(query, filter)
+
+		page, err = database.Paginate(query, r.cursors, globalHistoryKeyset, req, pos, spinLogKey)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Fetch limit+1 to check if there are more results
-	err = query.Limit(params.Limit + 1).Find(&models).Error
+	return toSpinLogCursorResult(page, true)
+}
+
+func (r *SpinLogRepositoryGorm) ListByPlayerCursor(ctx context.Context, playerID string, params shared.CursorParams, filter domain.SpinLogFilter) (*domain.SpinLogCursorResult, error) {
+	// The player is part of the listing, so a cursor cannot be replayed for another player
+	boundFilter := url.Values{"player_id": {playerID}}.Encode()
+	if encoded := filter.Encode(); encoded != "" {
+		boundFilter += "&" + encoded
+	}
+	req := database.PageRequest{Limit: params.Limit, Cursor: params.Cursor, Filter: boundFilter}
+	pos, err := personalHistoryKeyset.Position(r.cursors, req)
 	if err != nil {
 		return nil, err
 	}
 
-	hasMore := len(models) > params.Limit
-	if hasMore {
-		models = models[:params.Limit] // Trim extra item
+	var page *database.Page[SpinLogModel]
+	err = r.reads.ReadFor(ctx, playerID, func(db *gorm.DB) error {
+		query := db.WithContext(ctx).Where("spin_logs.player_id = ?", playerID)
+		query = applyFilter(query, filter)
+
+		page, err = database.Paginate(query, r.cursors, personalHistoryKeyset, req, pos, spinLogKey)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	data := make([]*domain.SpinLogWithPlayer, len(models))
-	var nextCursor string
-	for i, model := range models {
-		spinLog, err := r.toDomain(model)
+	return toSpinLogCursorResult(page, false)
+}
+
+func toSpinLogCursorResult(page *database.Page[SpinLogModel], withNickname bool) (*domain.SpinLogCursorResult, error) {
+	data := make([]*domain.SpinLogWithPlayer, len(page.Items))
+	for i := range page.Items {
+		model := &page.Items[i]
+		spinLog, err := toSpinLogDomain(model)
 		if err != nil {
 			return nil, err
 		}
 		nickname := ""
-		if model.Player != nil {
+		if withNickname && model.Player != nil {
 			nickname = model.Player.Nickname
 		}
 		data[i] = &domain.SpinLogWithPlayer{
 			SpinLog:        spinLog,
 			PlayerNickname: nickname,
 		}
-		// Generate cursor from last item
-		if i == len(models)-1 && hasMore {
-			nextCursor = shared.EncodeCursor(model.CreatedAt, model.ID)
-		}
 	}
 
 	return &domain.SpinLogCursorResult{
 		Data:       data,
-		NextCursor: nextCursor,
-		HasMore:    hasMore,
+		NextCursor: page.NextCursor,
+		PrevCursor: page.PrevCursor,
+		HasMore:    page.HasMore,
+		HasPrev:    page.HasPrev,
 	}, nil
 }
 
-func (r *SpinLogRepositoryGorm) ListByPlayerCursor(ctx context.Context, playerID string, params shared.CursorParams) (*domain.SpinLogCursorResult, error) {
-	var models []*SpinLogModel
+// ========== Export ==========
 
-	query := r.db.WithContext(ctx).
-		Where("player_id = ?", playerID).
-		Order("created_at DESC, id DESC")
+// spinLogExportRow is a spin log with its player's nickname, read by exports
+type spinLogExportRow struct {
+	ID           string
+	PlayerID     string
+	Nickname     string
+	PointsGained int
+	Source       string
+	CreatedAt    time.Time
+}
 
-	// Apply cursor filter if provided
-	cursorData, err := shared.DecodeCursor(params.Cursor)
-	if err != nil {
-		return nil, err
+func (r *SpinLogRepositoryGorm) StreamForExport(ctx context.Context, playerID string, filter domain.SpinLogFilter, batchSize int, fn func([]*domain.SpinLogWithPlayer) error) error {
+	query := r.reads.Stream(ctx).
+		Model(&SpinLogModel{}).
+		Select("spin_logs.id, spin_logs.player_id, COALESCE(players.nickname, '') AS nickname, spin_logs.points_gained, spin_logs.source, spin_logs.created_at").
+		Joins("LEFT JOIN players ON players.id = spin_logs.player_id")
+	if playerID != "" {
+		query = query.Where("spin_logs.player_id = ?", playerID)
 	}
-	if cursorData != nil {
-		query = query.Where(
-			"(created_at < ?) OR (created_at = ? AND id < ?)",
-			cursorData.CreatedAt, cursorData.CreatedAt, cursorData.ID,
-		)
+	if filter.NicknamePrefix != "" {
+		query = query.Where("players.nickname LIKE ? ESCAPE '\\'", escapeLike(filter.NicknamePrefix)+"%")
 	}
+	query = applyFilter(query, filter).Order("spin_logs.created_at ASC, spin_logs.id ASC")
+
+	return database.Stream(ctx, query, batchSize, func(batch []spinLogExportRow) error {
+		items := make([]*domain.SpinLogWithPlayer, len(batch))
+		for i, row := range batch {
+			spinLog, err := domain.ReconstructSpinLog(row.ID, row.PlayerID, row.PointsGained, row.Source, row.CreatedAt)
+			if err != nil {
+				return err
+			}
+			items[i] = &domain.SpinLogWithPlayer{SpinLog: spinLog, PlayerNickname: row.Nickname}
+		}
+		return fn(items)
+	})
+}
 
-	// Fetch limit+1 to check if there are more results
-	err = query.Limit(params.Limit + 1).Find(&models).Error
-	if err != nil {
-		return nil, err
+// applyFilter adds the spin_logs conditions of filter to query
+func applyFilter(query *gorm.DB, filter domain.SpinLogFilter) *gorm.DB {
+	if filter.From != nil {
+		query = query.Where("spin_logs.created_at >= ?", *filter.From)
 	}
-
-	hasMore := len(models) > params.Limit
-	if hasMore {
-		models = models[:params.Limit]
+	if filter.To != nil {
+		query = query.Where("spin_logs.created_at < ?", *filter.To)
 	}
-
-	data := make([]*domain.SpinLogWithPlayer, len(models))
-	var nextCursor string
-	for i, model := range models {
-		spinLog, err := r.toDomain(model)
-		if err != nil {
-			return nil, err
-		}
-		data[i] = &domain.SpinLogWithPlayer{
-			SpinLog:        spinLog,
-			PlayerNickname: "",
-		}
-		if i == len(models)-1 && hasMore {
-			nextCursor = shared.EncodeCursor(model.CreatedAt, model.ID)
-		}
+	if filter.Source != "" {
+		query = query.Where("spin_logs.source = ?", string(filter.Source))
+	}
+	if filter.MinPoints != nil {
+		query = query.Where("spin_logs.points_gained >= ?", *filter.MinPoints)
 	}
+	if filter.MaxPoints != nil {
+		query = query.Where("spin_logs.points_gained <= ?", *filter.MaxPoints)
+	}
+	return query
+}
 
-	return &domain.SpinLogCursorResult{
-		Data:       data,
-		NextCursor: nextCursor,
-		HasMore:    hasMore,
-	}, nil
+// escapeLike escapes LIKE wildcards so a prefix is matched literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
 }
 
-func (r *SpinLogRepositoryGorm) toModel(spinLog *domain.SpinLog) *SpinLogModel {
+func toSpinLogModel(spinLog *domain.SpinLog) *SpinLogModel {
 	return &SpinLogModel{
 		ID:           spinLog.ID().String(),
 		PlayerID:     spinLog.PlayerID(),
@@ -170,7 +234,7 @@ func (r *SpinLogRepositoryGorm) toModel(spinLog *domain.SpinLog) *SpinLogModel {
 	}
 }
 
-func (r *SpinLogRepositoryGorm) toDomain(model *SpinLogModel) (*domain.SpinLog, error) {
+func toSpinLogDomain(model *SpinLogModel) (*domain.SpinLog, error) {
 	return domain.ReconstructSpinLog(
 		model.ID,
 		model.PlayerID,
@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"backend/internal/infrastructure/database"
+	"backend/internal/modules/history/domain"
+	"backend/internal/shared/constants"
+	"backend/internal/shared/cursor"
+	shared "backend/internal/shared/domain"
+	"cmp"
+	"context"
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NicknameLookup resolves a player's nickname ("" when unknown); memory
+// repositories use it in place of a join on players
+type NicknameLookup interface {
+	Nickname(playerID string) string
+}
+
+// SpinLogRepositoryMemory implements domain.SpinLogRepository in process memory
+// (STORAGE=memory and tests); its cursors are interchangeable with the GORM ones
+type SpinLogRepositoryMemory struct {
+	mu        sync.RWMutex
+	logs      []SpinLogModel
+	nicknames NicknameLookup
+	cursors   *cursor.Codec
+}
+
+func NewSpinLogRepositoryMemory(nicknames NicknameLookup, cursors *cursor.Codec) *SpinLogRepositoryMemory {
+	return &SpinLogRepositoryMemory{nicknames: nicknames, cursors: cursors}
+}
+
+func (r *SpinLogRepositoryMemory) Store(ctx context.Context, spinLog *domain.SpinLog) error {
+	model := toSpinLogModel(spinLog)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.logs = append(r.logs, *model)
+	return nil
+}
+
+func (r *SpinLogRepositoryMemory) FindByID(ctx context.Context, id *domain.SpinLogID) (*domain.SpinLog, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for i := range r.logs {
+		if r.logs[i].ID == id.String() {
+			return toSpinLogDomain(&r.logs[i])
+		}
+	}
+	return nil, errors.New("spin log not found")
+}
+
+// CountTodayByPlayer counts GAME spins only, like the GORM repository
+func (r *SpinLogRepositoryMemory) CountTodayByPlayer(ctx context.Context, playerID string) (int, error) {
+	today := time.Now().Truncate(24 * time.Hour)
+	tomorrow := today.Add(24 * time.Hour)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	count := 0
+	for _, m := range r.logs {
+		if m.PlayerID == playerID && m.Source == string(constants.SpinSourceGame) &&
+			!m.CreatedAt.Before(today) && m.CreatedAt.Before(tomorrow) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *SpinLogRepositoryMemory) ListAllCursor(ctx context.Context, params shared.CursorParams, filter domain.SpinLogFilter) (*domain.SpinLogCursorResult, error) {
+	req := database.PageRequest{Limit: params.Limit, Cursor: params.Cursor, Filter: filter.Encode()}
+	pos, err := globalHistoryKeyset.Position(r.cursors, req)
+	if err != nil {
+		return nil, err
+	}
+
+	page := database.PaginateSlice(r.matching("", filter), r.cursors, globalHistoryKeyset, req, pos, spinLogKey)
+	return toSpinLogCursorResult(page, true)
+}
+
+func (r *SpinLogRepositoryMemory) ListByPlayerCursor(ctx context.Context, playerID string, params shared.CursorParams, filter domain.SpinLogFilter) (*domain.SpinLogCursorResult, error) {
+	boundFilter := url.Values{"player_id": {playerID}}.Encode()
+	if encoded := filter.Encode(); encoded != "" {
+		boundFilter += "&" + encoded
+	}
+	req := database.PageRequest{Limit: params.Limit, Cursor: params.Cursor, Filter: boundFilter}
+	pos, err := personalHistoryKeyset.Position(r.cursors, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// The personal listing ignores the nickname filter, as in SQL
+	filter.NicknamePrefix = ""
+	page := database.PaginateSlice(r.matching(playerID, filter), r.cursors, personalHistoryKeyset, req, pos, spinLogKey)
+	return toSpinLogCursorResult(page, false)
+}
+
+func (r *SpinLogRepositoryMemory) StreamForExport(ctx context.Context, playerID string, filter domain.SpinLogFilter, batchSize int, fn func([]*domain.SpinLogWithPlayer) error) error {
+	rows := r.matching(playerID, filter)
+	slices.SortFunc(rows, func(a, b SpinLogModel) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return database.StreamSlice(ctx, rows, batchSize, func(batch []SpinLogModel) error {
+		items := make([]*domain.SpinLogWithPlayer, len(batch))
+		for i := range batch {
+			spinLog, err := toSpinLogDomain(&batch[i])
+			if err != nil {
+				return err
+			}
+			items[i] = &domain.SpinLogWithPlayer{SpinLog: spinLog, PlayerNickname: batch[i].Player.Nickname}
+		}
+		return fn(items)
+	})
+}
+
+// StatsByPlayer computes the same aggregates as the SQL version
+func (r *SpinLogRepositoryMemory) StatsByPlayer(ctx context.Context, playerID string, now time.Time, days shared.DayBoundary) (*domain.SpinStats, error) {
+	today := domain.StartOfDay(days, now)
+	week := domain.StartOfWeek(days, now)
+
+	stats := &domain.SpinStats{}
+	counts := make(map[int]int)
+	var spinDays []time.Time
+	for _, m := range r.matching(playerID, domain.SpinLogFilter{}) {
+		stats.TotalSpins++
+		stats.TotalPoints += m.PointsGained
+		if !m.CreatedAt.Before(today) {
+			stats.SpinsToday++
+			stats.PointsToday += m.PointsGained
+		}
+		if !m.CreatedAt.Before(week) {
+			stats.SpinsThisWeek++
+			stats.PointsThisWeek += m.PointsGained
+		}
+		if stats.FirstSpinAt == nil || m.CreatedAt.Before(*stats.FirstSpinAt) {
+			stats.FirstSpinAt = &m.CreatedAt
+		}
+		if stats.LastSpinAt == nil || m.CreatedAt.After(*stats.LastSpinAt) {
+			stats.LastSpinAt = &m.CreatedAt
+		}
+		counts[m.PointsGained]++
+		spinDays = append(spinDays, days.DayOf(m.CreatedAt))
+	}
+
+	for points, count := range counts {
+		stats.Distribution = append(stats.Distribution, domain.OutcomeCount{Points: points, Count: count})
+	}
+	slices.SortFunc(stats.Distribution, func(a, b domain.OutcomeCount) int {
+		return cmp.Compare(a.Points, b.Points)
+	})
+
+	stats.BestStreak, stats.CurrentStreak = domain.Streaks(spinDays, days.DayOf(now))
+	return stats, nil
+}
+
+// matching returns copies of the logs of playerID ("" for all) that pass
+// filter, with the player's nickname attached
+func (r *SpinLogRepositoryMemory) matching(playerID string, filter domain.SpinLogFilter) []SpinLogModel {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var rows []SpinLogModel
+	for _, m := range r.logs {
+		if playerID != "" && m.PlayerID != playerID {
+			continue
+		}
+		if filter.From != nil && m.CreatedAt.Before(*filter.From) {
+			continue
+		}
+		if filter.To != nil && !m.CreatedAt.Before(*filter.To) {
+			continue
+		}
+		if filter.Source != "" && m.Source != string(filter.Source) {
+			continue
+		}
+		if filter.MinPoints != nil && m.PointsGained < *filter.MinPoints {
+			continue
+		}
+		if filter.MaxPoints != nil && m.PointsGained > *filter.MaxPoints {
+			continue
+		}
+
+		nickname := r.nicknames.Nickname(m.PlayerID)
+		if filter.NicknamePrefix != "" && !strings.HasPrefix(nickname, filter.NicknamePrefix) {
+			continue
+		}
+		m.Player = &PlayerModelRef{ID: m.PlayerID, Nickname: nickname}
+		rows = append(rows, m)
+	}
+	return rows
+}
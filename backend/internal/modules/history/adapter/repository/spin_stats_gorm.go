@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"backend/internal/infrastructure/database"
+	"backend/internal/modules/history/domain"
+	shared "backend/internal/shared/domain"
+
+	"gorm.io/gorm"
+)
+
+// spinTotalsQuery aggregates counts and sums in one pass over the player's rows
+// (served by idx_spin_logs_player_stats)
+const spinTotalsQuery = `
+SELECT
+	COUNT(*) AS total_spins,
+	COALESCE(SUM(points_gained), 0) AS total_points,
+	COUNT(*) FILTER (WHERE created_at >= @today) AS spins_today,
+	COALESCE(SUM(points_gained) FILTER (WHERE created_at >= @today), 0) AS points_today,
+	COUNT(*) FILTER (WHERE created_at >= @week) AS spins_this_week,
+	COALESCE(SUM(points_gained) FILTER (WHERE created_at >= @week), 0) AS points_this_week,
+	MIN(created_at) AS first_spin_at,
+	MAX(created_at) AS last_spin_at
+FROM spin_logs
+WHERE player_id = @player`
+
+// spinDistributionQuery counts spins per outcome
+const spinDistributionQuery = `
+SELECT points_gained AS points, COUNT(*) AS count
+FROM spin_logs
+WHERE player_id = @player
+GROUP BY points_gained
+ORDER BY points_gained`
+
+// spinStreaksQuery groups the player's spin days into runs of consecutive days
+// (gaps and islands: day minus its rank is constant within a run). A spin's
+// day is its local date in @zone less @offset seconds, as DayBoundary.DayOf.
+const spinStreaksQuery = `
+WITH days AS (
+	SELECT DISTINCT ((created_at AT TIME ZONE @zone) - make_interval(secs => @offset))::date AS day
+	FROM spin_logs
+	WHERE player_id = @player
+), islands AS (
+	SELECT MAX(day) AS last_day, COUNT(*) AS length
+	FROM (SELECT day, day - CAST(ROW_NUMBER() OVER (ORDER BY day) AS integer) AS grp FROM days) runs
+	GROUP BY grp
+)
+SELECT
+	COALESCE(MAX(length), 0) AS best,
+	COALESCE((SELECT length FROM islands WHERE last_day >= CAST(@yesterday AS date) ORDER BY last_day DESC LIMIT 1), 0) AS current
+FROM islands`
+
+// spinTimesQuerySQLite lists the player's spin times: SQLite has no time
+// zone rules, so the spin days and their runs are worked out in Go
+const spinTimesQuerySQLite = `
+SELECT created_at
+FROM spin_logs
+WHERE player_id = @player`
+
+type spinTotalsRow struct {
+	TotalSpins     int
+	TotalPoints    int
+	SpinsToday     int
+	PointsToday    int
+	SpinsThisWeek  int
+	PointsThisWeek int
+	FirstSpinAt    database.NullTime
+	LastSpinAt     database.NullTime
+}
+
+type spinStreaksRow struct {
+	Best    int
+	Current int
+}
+
+// StatsByPlayer runs the aggregates in one read-only snapshot so they agree with each other
+func (r *SpinLogRepositoryGorm) StatsByPlayer(ctx context.Context, playerID string, now time.Time, days shared.DayBoundary) (*domain.SpinStats, error) {
+	today := days.DayOf(now)
+	args := map[string]any{
+		"player":    playerID,
+		"today":     domain.StartOfDay(days, now),
+		"week":      domain.StartOfWeek(days, now),
+		"yesterday": today.AddDate(0, 0, -1).Format(time.DateOnly),
+		"zone":      days.Zone(),
+		"offset":    days.Offset.Seconds(),
+	}
+
+	var stats *domain.SpinStats
+	err := r.reads.ReadFor(ctx, playerID, func(db *gorm.DB) error {
+		return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			var totals spinTotalsRow
+			if err := tx.Raw(spinTotalsQuery, args).Scan(&totals).Error; err != nil {
+				return err
+			}
+
+			var distribution []domain.OutcomeCount
+			if err := tx.Raw(spinDistributionQuery, args).Scan(&distribution).Error; err != nil {
+				return err
+			}
+
+			var streaks spinStreaksRow
+			if database.IsSQLite(tx) {
+				var times []time.Time
+				if err := tx.Raw(spinTimesQuerySQLite, args).Scan(&times).Error; err != nil {
+					return err
+				}
+				spinDays := make([]time.Time, len(times))
+				for i, t := range times {
+					spinDays[i] = days.DayOf(t)
+				}
+				streaks.Best, streaks.Current = domain.Streaks(spinDays, today)
+			} else if err := tx.Raw(spinStreaksQuery, args).Scan(&streaks).Error; err != nil {
+				return err
+			}
+
+			stats = &domain.SpinStats{
+				TotalSpins:     totals.TotalSpins,
+				TotalPoints:    totals.TotalPoints,
+				SpinsToday:     totals.SpinsToday,
+				PointsToday:    totals.PointsToday,
+				SpinsThisWeek:  totals.SpinsThisWeek,
+				PointsThisWeek: totals.PointsThisWeek,
+				FirstSpinAt:    totals.FirstSpinAt.Ptr(),
+				LastSpinAt:     totals.LastSpinAt.Ptr(),
+				Distribution:   distribution,
+				BestStreak:     streaks.Best,
+				CurrentStreak:  streaks.Current,
+			}
+			return nil
+		}, &sql.TxOptions{ReadOnly: true, Isolation: sql.LevelRepeatableRead})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
@@ -6,10 +6,21 @@ import (
 
 // ========== Requests ==========
 
+// HistoryFilterRequest holds the optional history filters (from/to are RFC3339)
+type HistoryFilterRequest struct {
+	From      string `query:"from"`
+	To        string `query:"to"`
+	Source    string `query:"source"`
+	MinPoints *int   `query:"min_points"`
+	MaxPoints *int   `query:"max_points"`
+}
+
 // Global History Request (cursor-based)
 type GetGlobalRequest struct {
 	Limit  int    `query:"limit"`
 	Cursor string `query:"cursor"`
+	HistoryFilterRequest
+	NicknamePrefix string `query:"nickname_prefix"`
 }
 
 // Personal History Request (cursor-based)
@@ -17,6 +28,17 @@ type GetPersonalRequest struct {
 	PlayerID string `params:"player_id"`
 	Limit    int    `query:"limit"`
 	Cursor   string `query:"cursor"`
+	HistoryFilterRequest
+}
+
+// ExportRequest selects the spins and encoding of GET /admin/history/export
+type ExportRequest struct {
+	HistoryFilterRequest
+	NicknamePrefix string `query:"nickname_prefix"`
+	PlayerID       string `query:"player_id"`
+	Format         string `query:"format"`  // csv (default) or ndjson
+	Columns        string `query:"columns"` // comma-separated, defaults to export.yaml
+	Timezone       string `query:"tz"`      // IANA zone, defaults to export.yaml
 }
 
 // ========== DTOs ==========
@@ -44,12 +66,16 @@ type PersonalSpinLogDTO struct {
 type GlobalHistoryResponse struct {
 	Data       []GlobalSpinLogDTO `json:"data"`
 	NextCursor string             `json:"next_cursor,omitempty"`
+	PrevCursor string             `json:"prev_cursor,omitempty"`
 	HasMore    bool               `json:"has_more"`
+	HasPrev    bool               `json:"has_prev"`
 }
 
 // PersonalHistoryResponse (cursor-based)
 type PersonalHistoryResponse struct {
 	Data       []PersonalSpinLogDTO `json:"data"`
 	NextCursor string               `json:"next_cursor,omitempty"`
+	PrevCursor string               `json:"prev_cursor,omitempty"`
 	HasMore    bool                 `json:"has_more"`
+	HasPrev    bool                 `json:"has_prev"`
 }
@@ -0,0 +1,61 @@
+package export_spins
+
+import (
+	"context"
+
+	"backend/internal/modules/history/application"
+	"backend/internal/modules/history/domain"
+	shared "backend/internal/shared/domain"
+	"backend/internal/shared/export"
+	"backend/internal/shared/tracing"
+)
+
+type row = *domain.SpinLogWithPlayer
+
+// columns are the fields a spin export can contain
+var columns = []export.Column[row]{
+	{Name: "id", Value: func(r row) any { return r.SpinLog.ID().String() }},
+	{Name: "player_id", Value: func(r row) any { return r.SpinLog.PlayerID() }},
+	{Name: "nickname", Value: func(r row) any { return r.PlayerNickname }},
+	{Name: "points_gained", Value: func(r row) any { return r.SpinLog.PointsGained() }},
+	{Name: "source", Value: func(r row) any { return string(r.SpinLog.Source()) }},
+	{Name: "created_at", Value: func(r row) any { return r.SpinLog.CreatedAt() }},
+}
+
+type UseCase struct {
+	spinLogRepo domain.SpinLogRepository
+	defaults    export.Options
+}
+
+func New(repo domain.SpinLogRepository, defaults export.Options) *UseCase {
+	return &UseCase{
+		spinLogRepo: repo,
+		defaults:    defaults,
+	}
+}
+
+// Prepare validates the request and returns a job that streams the matching
+// spins; nothing is read until the job runs
+func (uc *UseCase) Prepare(req application.ExportRequest) (*export.Job, error) {
+	filter, err := req.HistoryFilterRequest.ToDomain()
+	if err != nil {
+		return nil, err
+	}
+	filter.NicknamePrefix = req.NicknamePrefix
+
+	opts, err := export.ResolveOptions(uc.defaults, req.Format, req.Columns, req.Timezone)
+	if err != nil {
+		return nil, shared.Invalid(err)
+	}
+	selected, err := export.SelectColumns(columns, opts.Columns)
+	if err != nil {
+		return nil, shared.Invalid(err)
+	}
+
+	return export.NewJob("spin_history", opts, selected, func(ctx context.Context, batchSize int, fn func([]row) error) (err error) {
+		ctx, span := tracing.Start(ctx, "history.export_spins.Run")
+		defer func() { tracing.End(span, err) }()
+
+		return uc.spinLogRepo.StreamForExport(ctx, req.PlayerID, filter, batchSize, fn)
+	}), nil
+}
@@ -0,0 +1,52 @@
+package application
+
+import (
+	"strings"
+	"time"
+
+	"backend/internal/modules/history/domain"
+	"backend/internal/shared/constants"
+	sharedcursor "backend/internal/shared/cursor"
+	shared "backend/internal/shared/domain"
+)
+
+// ToDomain parses and validates the request filters
+func (r HistoryFilterRequest) ToDomain() (domain.SpinLogFilter, error) {
+	var filter domain.SpinLogFilter
+
+	if r.From != "" {
+		from, err := time.Parse(time.RFC3339, r.From)
+		if err != nil {
+			return filter, shared.NewValidationError("from must be an RFC3339 timestamp")
+		}
+		filter.From = &from
+	}
+	if r.To != "" {
+		to, err := time.Parse(time.RFC3339, r.To)
+		if err != nil {
+			return filter, shared.NewValidationError("to must be an RFC3339 timestamp")
+		}
+		filter.To = &to
+	}
+	filter.Source = constants.SpinSource(strings.ToUpper(r.Source))
+	filter.MinPoints = r.MinPoints
+	filter.MaxPoints = r.MaxPoints
+
+	return filter, shared.Invalid(filter.Validate())
+}
+
+// ResolveFilter returns the filters to use for a page: a cursor without
+// query filters continues with the filters it was issued for
+func ResolveFilter(cursor string, requested domain.SpinLogFilter) (domain.SpinLogFilter, error) {
+	if cursor == "" || !requested.IsZero() {
+		return requested, nil
+	}
+
+	// The repository verifies the cursor signature and that it matches these filters
+	c, err := sharedcursor.Peek(cursor)
+	if err != nil {
+		return requested, err
+	}
+	filter, err := domain.DecodeSpinLogFilter(c.Filter)
+	return filter, shared.Invalid(err)
+}
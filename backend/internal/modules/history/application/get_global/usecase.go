@@ -6,6 +6,7 @@ import (
 	"backend/internal/modules/history/application"
 	"backend/internal/modules/history/domain"
 	shared "backend/internal/shared/domain"
+	"backend/internal/shared/tracing"
 )
 
 type UseCase struct {
@@ -21,9 +22,22 @@ func New(repo domain.SpinLogRepository, cfg shared.PaginationConfig) *UseCase {
 }
 
 // Execute handles cursor-based pagination
-func (uc *UseCase) Execute(ctx context.Context, req application.GetGlobalRequest) (*application.GlobalHistoryResponse, error) {
+func (uc *UseCase) Execute(ctx context.Context, req application.GetGlobalRequest) (_ *application.GlobalHistoryResponse, err error) {
+	ctx, span := tracing.Start(ctx, "history.get_global.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	filter, err := req.HistoryFilterRequest.ToDomain()
+	if err != nil {
+		return nil, err
+	}
+	filter.NicknamePrefix = req.NicknamePrefix
+	filter, err = application.ResolveFilter(req.Cursor, filter)
+	if err != nil {
+		return nil, err
+	}
+
 	params := shared.NewCursorParams(req.Limit, req.Cursor, uc.paginationCfg)
-	result, err := uc.spinLogRepo.ListAllCursor(ctx, params)
+	result, err := uc.spinLogRepo.ListAllCursor(ctx, params, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -42,6 +56,8 @@ func (uc *UseCase) Execute(ctx context.Context, req application.GetGlobalRequest
 	return &application.GlobalHistoryResponse{
 		Data:       dtos,
 		NextCursor: result.NextCursor,
+		PrevCursor: result.PrevCursor,
 		HasMore:    result.HasMore,
+		HasPrev:    result.HasPrev,
 	}, nil
 }
@@ -2,11 +2,11 @@ package get_personal
 
 import (
 	"context"
-	"errors"
 
 	"backend/internal/modules/history/application"
 	"backend/internal/modules/history/domain"
 	shared "backend/internal/shared/domain"
+	"backend/internal/shared/tracing"
 )
 
 type UseCase struct {
@@ -22,13 +22,28 @@ func New(repo domain.SpinLogRepository, cfg shared.PaginationConfig) *UseCase {
 }
 
 // Execute handles cursor-based pagination
-func (uc *UseCase) Execute(ctx context.Context, req application.GetPersonalRequest) (*application.PersonalHistoryResponse, error) {
+func (uc *UseCase) Execute(ctx context.Context, req application.GetPersonalRequest) (_ *application.PersonalHistoryResponse, err error) {
+	ctx, span := tracing.Start(ctx, "history.get_personal.Execute")
+	defer func() { tracing.End(span, err) }()
+
 	if req.PlayerID == "" {
-		return nil, errors.New("player ID is required")
+		return nil, shared.NewValidationError("player ID is required")
+	}
+
+	filter, err := req.HistoryFilterRequest.ToDomain()
+	if err != nil {
+		return nil, err
+	}
+	filter, err = application.ResolveFilter(req.Cursor, filter)
+	if err != nil {
+		return nil, err
+	}
+	if filter.NicknamePrefix != "" {
+		return nil, shared.NewValidationError("nickname_prefix is only supported for global history")
 	}
 
 	params := shared.NewCursorParams(req.Limit, req.Cursor, uc.paginationCfg)
-	result, err := uc.spinLogRepo.ListByPlayerCursor(ctx, req.PlayerID, params)
+	result, err := uc.spinLogRepo.ListByPlayerCursor(ctx, req.PlayerID, params, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -46,6 +61,8 @@ func (uc *UseCase) Execute(ctx context.Context, req application.GetPersonalReque
 	return &application.PersonalHistoryResponse{
 		Data:       dtos,
 		NextCursor: result.NextCursor,
+		PrevCursor: result.PrevCursor,
 		HasMore:    result.HasMore,
+		HasPrev:    result.HasPrev,
 	}, nil
 }
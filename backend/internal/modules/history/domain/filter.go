@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+
+	"backend/internal/shared/constants"
+)
+
+// SpinLogFilter narrows history listings; zero values mean "no filter"
+type SpinLogFilter struct {
+	From           *time.Time // inclusive
+	To             *time.Time // exclusive
+	Source         constants.SpinSource
+	MinPoints      *int
+	MaxPoints      *int
+	NicknamePrefix string // global history only
+}
+
+// Validate checks the filter is consistent
+func (f SpinLogFilter) Validate() error {
+	if f.From != nil && f.To != nil && !f.From.Before(*f.To) {
+		return errors.New("from must be before to")
+	}
+	if f.Source != "" && !f.Source.IsValid() {
+		return errors.New("source must be GAME, BONUS or ADMIN")
+	}
+	if f.MinPoints != nil && *f.MinPoints < 0 {
+		return errors.New("min_points cannot be negative")
+	}
+	if f.MinPoints != nil && f.MaxPoints != nil && *f.MinPoints > *f.MaxPoints {
+		return errors.New("min_points cannot exceed max_points")
+	}
+	return nil
+}
+
+// IsZero returns true when no filter is set
+func (f SpinLogFilter) IsZero() bool {
+	return f.Encode() == ""
+}
+
+// Encode returns a canonical form stored in cursors so a page is only
+// continued with the filters it was started with
+func (f SpinLogFilter) Encode() string {
+	values := url.Values{}
+	if f.From != nil {
+		values.Set("from", f.From.UTC().Format(time.RFC3339Nano))
+	}
+	if f.To != nil {
+		values.Set("to", f.To.UTC().Format(time.RFC3339Nano))
+	}
+	if f.Source != "" {
+		values.Set("source", string(f.Source))
+	}
+	if f.MinPoints != nil {
+		values.Set("min_points", strconv.Itoa(*f.MinPoints))
+	}
+	if f.MaxPoints != nil {
+		values.Set("max_points", strconv.Itoa(*f.MaxPoints))
+	}
+	if f.NicknamePrefix != "" {
+		values.Set("nickname_prefix", f.NicknamePrefix)
+	}
+	return values.Encode()
+}
+
+// DecodeSpinLogFilter parses the canonical form produced by Encode
+func DecodeSpinLogFilter(encoded string) (SpinLogFilter, error) {
+	var f SpinLogFilter
+	values, err := url.ParseQuery(encoded)
+	if err != nil {
+		return f, errors.New("invalid cursor filters")
+	}
+
+	if v := values.Get("from"); v != "" {
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return f, errors.New("invalid cursor filters")
+		}
+		f.From = &t
+	}
+	if v := values.Get("to"); v != "" {
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return f, errors.New("invalid cursor filters")
+		}
+		f.To = &t
+	}
+	f.Source = constants.SpinSource(values.Get("source"))
+	if v := values.Get("min_points"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return f, errors.New("invalid cursor filters")
+		}
+		f.MinPoints = &n
+	}
+	if v := values.Get("max_points"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return f, errors.New("invalid cursor filters")
+		}
+		f.MaxPoints = &n
+	}
+	f.NicknamePrefix = values.Get("nickname_prefix")
+
+	return f, f.Validate()
+}
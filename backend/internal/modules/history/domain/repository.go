@@ -3,6 +3,7 @@ package domain
 import (
 	shared "backend/internal/shared/domain"
 	"context"
+	"time"
 )
 
 // SpinLogRepository defines persistence contract
@@ -14,12 +15,19 @@ type SpinLogRepository interface {
 	FindByID(ctx context.Context, id *SpinLogID) (*SpinLog, error)
 
 	// ListAllCursor returns cursor-paginated global history with player info
-	ListAllCursor(ctx context.Context, params shared.CursorParams) (*SpinLogCursorResult, error)
+	ListAllCursor(ctx context.Context, params shared.CursorParams, filter SpinLogFilter) (*SpinLogCursorResult, error)
 
 	// ListByPlayerCursor returns cursor-paginated history for specific player
-	ListByPlayerCursor(ctx context.Context, playerID string, params shared.CursorParams) (*SpinLogCursorResult, error)
+	ListByPlayerCursor(ctx context.Context, playerID string, params shared.CursorParams, filter SpinLogFilter) (*SpinLogCursorResult, error)
 
-	// CountTodayByPlayer counts today's spins for a player (for daily limit)
+	// StreamForExport passes spin logs matching filter (and playerID when set) to fn
+	// in batches, oldest first, without loading the whole result
+	StreamForExport(ctx context.Context, playerID string, filter SpinLogFilter, batchSize int, fn func([]*SpinLogWithPlayer) error) error
+
+	// StatsByPlayer aggregates the player's spins as of now, splitting days at days
+	StatsByPlayer(ctx context.Context, playerID string, now time.Time, days shared.DayBoundary) (*SpinStats, error)
+
+	// CountTodayByPlayer counts today's GAME spins for a player (for daily limit)
 	CountTodayByPlayer(ctx context.Context, playerID string) (int, error)
 }
 
@@ -27,7 +35,9 @@ type SpinLogRepository interface {
 type SpinLogCursorResult struct {
 	Data       []*SpinLogWithPlayer
 	NextCursor string
+	PrevCursor string
 	HasMore    bool
+	HasPrev    bool
 }
 
 // SpinLogWithPlayer includes player nickname for display
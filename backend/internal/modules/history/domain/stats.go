@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"slices"
+	"time"
+
+	shared "backend/internal/shared/domain"
+)
+
+// SpinStats aggregates a player's spins. Days follow the login streak's day
+// boundary; weeks start on Monday.
+type SpinStats struct {
+	TotalSpins     int
+	TotalPoints    int
+	SpinsToday     int
+	PointsToday    int
+	SpinsThisWeek  int
+	PointsThisWeek int
+	FirstSpinAt    *time.Time
+	LastSpinAt     *time.Time
+
+	// Distribution counts how often each outcome was hit, lowest points first
+	Distribution []OutcomeCount
+
+	// Streaks count consecutive days with at least one spin; the current
+	// streak is still alive if the player spun today or yesterday
+	BestStreak    int
+	CurrentStreak int
+}
+
+// OutcomeCount is how many spins gained Points
+type OutcomeCount struct {
+	Points int
+	Count  int
+}
+
+// AveragePoints returns the mean points per spin, 0 without spins
+func (s *SpinStats) AveragePoints() float64 {
+	if s.TotalSpins == 0 {
+		return 0
+	}
+	return float64(s.TotalPoints) / float64(s.TotalSpins)
+}
+
+// StartOfDay returns when t's day began
+func StartOfDay(days shared.DayBoundary, t time.Time) time.Time {
+	return days.StartOf(days.DayOf(t))
+}
+
+// StartOfWeek returns when the Monday of t's week began
+func StartOfWeek(days shared.DayBoundary, t time.Time) time.Time {
+	day := days.DayOf(t)
+	offset := (int(day.Weekday()) + 6) % 7 // Monday = 0
+	return days.StartOf(day.AddDate(0, 0, -offset))
+}
+
+// Streaks returns the longest run of consecutive spin days and the run that
+// is still alive on today (it reaches today or yesterday). days and today
+// come from DayBoundary.DayOf; days may repeat and be in any order.
+func Streaks(days []time.Time, today time.Time) (best, current int) {
+	sorted := slices.Clone(days)
+	slices.SortFunc(sorted, time.Time.Compare)
+	sorted = slices.Compact(sorted)
+
+	yesterday := today.AddDate(0, 0, -1)
+	run := 0
+	for i, day := range sorted {
+		if i > 0 && sorted[i-1].AddDate(0, 0, 1).Equal(day) {
+			run++
+		} else {
+			run = 1
+		}
+		best = max(best, run)
+		if !day.Before(yesterday) {
+			current = run
+		}
+	}
+	return best, current
+}
@@ -3,23 +3,22 @@ package history
 import (
 	"backend/internal/infrastructure/config"
 	"backend/internal/modules/history/adapter/handler"
-	"backend/internal/modules/history/adapter/repository"
+	"backend/internal/modules/history/application/export_spins"
 	"backend/internal/modules/history/application/get_global"
 	"backend/internal/modules/history/application/get_personal"
+	"backend/internal/modules/history/domain"
 	shared "backend/internal/shared/domain"
+	"backend/internal/shared/export"
 
 	"github.com/gofiber/fiber/v2"
-	"gorm.io/gorm"
 )
 
 type Module struct {
 	Handler     *handler.HistoryHandler
-	SpinLogRepo *repository.SpinLogRepositoryGorm // Exported for Game module
+	SpinLogRepo domain.SpinLogRepository // Exported for Game module
 }
 
-func NewModule(db *gorm.DB, cfg *config.Config) *Module {
-	repo := repository.NewSpinLogRepositoryGorm(db)
-
+func NewModule(cfg *config.Config, repo domain.SpinLogRepository) *Module {
 	// Convert infra config to shared domain config (keeps application layer clean)
 	paginationCfg := shared.PaginationConfig{
 		DefaultLimit:  cfg.Pagination.DefaultLimit,
@@ -30,7 +29,9 @@ func NewModule(db *gorm.DB, cfg *config.Config) *Module {
 	getGlobalUC := get_global.New(repo, paginationCfg)
 	getPersonalUC := get_personal.New(repo, paginationCfg)
 
-	h := handler.NewHistoryHandler(getGlobalUC, getPersonalUC)
+	exportUC := export_spins.New(repo, export.DefaultOptions(cfg.Export.Timezone, cfg.Export.BatchSize, cfg.Export.SpinColumns))
+
+	h := handler.NewHistoryHandler(getGlobalUC, getPersonalUC, exportUC)
 
 	return &Module{
 		Handler:     h,
@@ -38,6 +39,12 @@ func NewModule(db *gorm.DB, cfg *config.Config) *Module {
 	}
 }
 
-func (m *Module) RegisterRoutes(app *fiber.App) {
-	m.Handler.RegisterRoutes(app)
+// RegisterRoutes registers history routes on a versioned API router
+func (m *Module) RegisterRoutes(router fiber.Router) {
+	m.Handler.RegisterRoutes(router)
+}
+
+// RegisterAdminRoutes registers admin routes on a router guarded by admin auth
+func (m *Module) RegisterAdminRoutes(admin fiber.Router) {
+	m.Handler.RegisterAdminRoutes(admin)
 }
@@ -0,0 +1,203 @@
+package history_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"backend/internal/adapter/http/middleware"
+	"backend/internal/infrastructure/config"
+	"backend/internal/modules/history"
+	historyrepo "backend/internal/modules/history/adapter/repository"
+	historydomain "backend/internal/modules/history/domain"
+	playerrepo "backend/internal/modules/player/adapter/repository"
+	playerdomain "backend/internal/modules/player/domain"
+	"backend/internal/shared/constants"
+	"backend/internal/shared/cursor"
+	httputil "backend/internal/shared/http"
+)
+
+const adminKey = "test-admin-key-test-admin-key-0000"
+
+// newApp serves the module on /v1 with the admin routes under /v1/admin
+func newApp() *fiber.App {
+	app, _, _ := newAppWithRepos()
+	return app
+}
+
+// newAppWithRepos is newApp plus the repositories it reads, for seeding
+func newAppWithRepos() (*fiber.App, *playerrepo.PlayerRepositoryMemory, *historyrepo.SpinLogRepositoryMemory) {
+	cfg := &config.Config{
+		Pagination: config.PaginationConfig{DefaultLimit: 20, MaxLimit: 100},
+		Export:     config.ExportConfig{Timezone: "UTC", BatchSize: 100, SpinColumns: []string{"id", "player_id", "nickname"}},
+	}
+	players := playerrepo.NewPlayerRepositoryMemory(playerdomain.NewPlayerFactory(3, 20))
+	spinLogs := historyrepo.NewSpinLogRepositoryMemory(players, cursor.NewCodec("secret"))
+	m := history.NewModule(cfg, spinLogs)
+
+	app := fiber.New(fiber.Config{ErrorHandler: httputil.ErrorHandler(httputil.NewErrorRegistry())})
+	v1 := app.Group("/v1")
+	m.RegisterRoutes(v1)
+	m.RegisterAdminRoutes(v1.Group("/admin", middleware.AdminAuth(adminKey)))
+
+	return app, players, spinLogs
+}
+
+func TestExportRequiresAdminKey(t *testing.T) {
+	app := newApp()
+
+	tests := []struct {
+		name   string
+		path   string
+		key    string
+		status int
+		csv    bool
+	}{
+		{"no key", "/v1/admin/history/export", "", fiber.StatusUnauthorized, false},
+		{"wrong key", "/v1/admin/history/export", "nope", fiber.StatusUnauthorized, false},
+		{"admin key", "/v1/admin/history/export", adminKey, fiber.StatusOK, true},
+		// Not an export any more: personal history of a player named "export"
+		{"public path", "/v1/history/export", "", fiber.StatusOK, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodGet, tt.path, nil)
+			if tt.key != "" {
+				req.Header.Set(middleware.HeaderAdminKey, tt.key)
+			}
+			res, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, _ := io.ReadAll(res.Body)
+			if res.StatusCode != tt.status {
+				t.Fatalf("status = %d, want %d (%s)", res.StatusCode, tt.status, body)
+			}
+			if csv := strings.HasPrefix(res.Header.Get(fiber.HeaderContentType), "text/csv"); csv != tt.csv {
+				t.Errorf("served CSV = %v, want %v (%s)", csv, tt.csv, body)
+			}
+		})
+	}
+}
+
+func TestResponsesUseEnvelope(t *testing.T) {
+	res, err := newApp().Test(httptest.NewRequest(fiber.MethodGet, "/v1/history/global", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var env struct {
+		Success bool            `json:"success"`
+		Data    json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&env); err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != fiber.StatusOK || !env.Success || len(env.Data) == 0 {
+		t.Fatalf("status %d, body %+v; want 200 with data", res.StatusCode, env)
+	}
+}
+
+func TestHistoryFilters(t *testing.T) {
+	app, players, spinLogs := newAppWithRepos()
+	ctx := context.Background()
+	base := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	var playerIDs []string
+	for _, nickname := range []string{"alice", "bob"} {
+		player, err := playerdomain.NewPlayerFactory(3, 20).CreateNewPlayer(nickname)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := players.Store(ctx, player); err != nil {
+			t.Fatal(err)
+		}
+		playerIDs = append(playerIDs, player.ID().String())
+	}
+	alice, bob := playerIDs[0], playerIDs[1]
+	for _, l := range []struct {
+		playerID string
+		points   int
+		source   constants.SpinSource
+		hoursAgo int
+	}{
+		{alice, 100, constants.SpinSourceGame, 3},
+		{alice, 500, constants.SpinSourceBonus, 2},
+		{bob, 300, constants.SpinSourceGame, 1},
+	} {
+		spinLog, err := historydomain.ReconstructSpinLog(historydomain.GenerateSpinLogID().String(), l.playerID, l.points, string(l.source), base.Add(-time.Duration(l.hoursAgo)*time.Hour))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := spinLogs.Store(ctx, spinLog); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		path   string
+		status int
+		points []int // points of the listed spins, newest first
+	}{
+		{"no filter", "/v1/history/global", fiber.StatusOK, []int{300, 500, 100}},
+		{"date range", "/v1/history/global?from=2026-10-19T09:00:00Z&to=2026-10-19T11:00:00Z", fiber.StatusOK, []int{500, 100}},
+		{"source in any case", "/v1/history/global?source=bonus", fiber.StatusOK, []int{500}},
+		{"points range", "/v1/history/global?min_points=200&max_points=400", fiber.StatusOK, []int{300}},
+		{"nickname prefix", "/v1/history/global?nickname_prefix=al", fiber.StatusOK, []int{500, 100}},
+		{"personal filters", "/v1/history/" + alice + "?source=GAME", fiber.StatusOK, []int{100}},
+		{"personal other player", "/v1/history/" + bob + "?max_points=100", fiber.StatusOK, nil},
+
+		{"from not RFC3339", "/v1/history/global?from=yesterday", fiber.StatusBadRequest, nil},
+		{"to not RFC3339", "/v1/history/global?to=2026-10-19", fiber.StatusBadRequest, nil},
+		{"from after to", "/v1/history/global?from=2026-10-19T11:00:00Z&to=2026-10-19T09:00:00Z", fiber.StatusBadRequest, nil},
+		{"empty date range", "/v1/history/global?from=2026-10-19T11:00:00Z&to=2026-10-19T11:00:00Z", fiber.StatusBadRequest, nil},
+		{"unknown source", "/v1/history/global?source=LOTTERY", fiber.StatusBadRequest, nil},
+		{"negative min points", "/v1/history/global?min_points=-1", fiber.StatusBadRequest, nil},
+		{"min above max", "/v1/history/global?min_points=500&max_points=100", fiber.StatusBadRequest, nil},
+		{"points not a number", "/v1/history/global?min_points=lots", fiber.StatusBadRequest, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := app.Test(httptest.NewRequest(fiber.MethodGet, tt.path, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			raw, _ := io.ReadAll(res.Body)
+			if res.StatusCode != tt.status {
+				t.Fatalf("status = %d, want %d (%s)", res.StatusCode, tt.status, raw)
+			}
+
+			var env struct {
+				Data struct {
+					Data []struct {
+						PointsGained int `json:"points_gained"`
+					} `json:"data"`
+				} `json:"data"`
+				Error *httputil.ErrorBody `json:"error"`
+			}
+			if err := json.Unmarshal(raw, &env); err != nil {
+				t.Fatal(err)
+			}
+			if tt.status != fiber.StatusOK {
+				if env.Error == nil || env.Error.Code != constants.ErrCodeValidationFailed {
+					t.Fatalf("error = %+v, want %s", env.Error, constants.ErrCodeValidationFailed)
+				}
+				return
+			}
+			var points []int
+			for _, item := range env.Data.Data {
+				points = append(points, item.PointsGained)
+			}
+			if !slices.Equal(points, tt.points) {
+				t.Fatalf("points = %v, want %v", points, tt.points)
+			}
+		})
+	}
+}
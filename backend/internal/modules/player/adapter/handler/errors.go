@@ -0,0 +1,20 @@
+package handler
+
+import (
+	"backend/internal/modules/player/domain"
+	"backend/internal/shared/constants"
+	httputil "backend/internal/shared/http"
+)
+
+// RegisterErrors maps the player module's errors to HTTP responses
+func RegisterErrors(r *httputil.ErrorRegistry) {
+	r.Register(domain.ErrInvalidReferralCode, constants.StatusBadRequest, constants.ErrCodeInvalidReferralCode, "Referral code not found")
+	r.Register(domain.ErrSelfReferral, constants.StatusBadRequest, constants.ErrCodeSelfReferral, "Players cannot use their own referral code")
+	r.Register(domain.ErrReferralLimitReached, constants.StatusTooManyRequests, constants.ErrCodeReferralLimitReached, "Too many referrals today, try again later")
+	r.Register(domain.ErrNicknameTaken, constants.StatusConflict, constants.ErrCodeNicknameTaken, "Nickname already taken")
+	r.Register(domain.ErrRenameCooldown, constants.StatusTooManyRequests, constants.ErrCodeRenameCooldown, "Nickname was changed too recently")
+	r.Register(domain.ErrInvalidStatus, constants.StatusBadRequest, constants.ErrCodeValidationFailed, "")
+	r.Register(domain.ErrInvalidStatusChange, constants.StatusBadRequest, constants.ErrCodeValidationFailed, "")
+	r.Register(domain.ErrStatusFinal, constants.StatusConflict, constants.ErrCodePlayerDeleted, "")
+	r.Register(domain.ErrAlreadyErased, constants.StatusConflict, constants.ErrCodeAlreadyErased, "Player data already erased")
+}
@@ -2,9 +2,13 @@ package handler
 
 import (
 	"backend/internal/modules/player/application"
+	"backend/internal/modules/player/application/change_locale"
+	"backend/internal/modules/player/application/change_status"
 	"backend/internal/modules/player/application/enter"
 	"backend/internal/modules/player/application/get_profile"
-	"backend/internal/shared/constants"
+	"backend/internal/modules/player/application/get_referrals"
+	"backend/internal/modules/player/application/get_stats"
+	"backend/internal/modules/player/application/rename"
 	shared "backend/internal/shared/domain"
 	httputil "backend/internal/shared/http"
 
@@ -12,49 +16,70 @@ import (
 )
 
 type PlayerHandler struct {
-	enterUC      *enter.UseCase
-	getProfileUC *get_profile.UseCase
+	enterUC        *enter.UseCase
+	getProfileUC   *get_profile.UseCase
+	getStatsUC     *get_stats.UseCase
+	getReferralsUC *get_referrals.UseCase
+	renameUC       *rename.UseCase
+	changeStatusUC *change_status.UseCase
+	changeLocaleUC *change_locale.UseCase
 }
 
-func NewPlayerHandler(enterUC *enter.UseCase, getProfileUC *get_profile.UseCase) *PlayerHandler {
+func NewPlayerHandler(
+	enterUC *enter.UseCase,
+	getProfileUC *get_profile.UseCase,
+	getStatsUC *get_stats.UseCase,
+	getReferralsUC *get_referrals.UseCase,
+	renameUC *rename.UseCase,
+	changeStatusUC *change_status.UseCase,
+	changeLocaleUC *change_locale.UseCase,
+) *PlayerHandler {
 	return &PlayerHandler{
-		enterUC:      enterUC,
-		getProfileUC: getProfileUC,
+		enterUC:        enterUC,
+		getProfileUC:   getProfileUC,
+		getStatsUC:     getStatsUC,
+		getReferralsUC: getReferralsUC,
+		renameUC:       renameUC,
+		changeStatusUC: changeStatusUC,
+		changeLocaleUC: changeLocaleUC,
 	}
 }
 
 // Enter handles POST /players/enter
 // @Summary Enter or resume a player
-// @Description Enter an existing player or create a new one with the given nickname
+// @Description Enter an existing player or create a new one with the given nickname. A new player may pass another player's invite code as referral_code; it is ignored for existing players.
 // @Tags Players
 // @Accept json
 // @Produce json
 // @Param request body application.EnterRequest true "Player enter request"
-// @Success 200 {object} application.EnterResponse "Existing player found"
-// @Success 201 {object} application.EnterResponse "New player created"
-// @Failure 400 {object} object "Bad request"
+// @Success 200 {object} httputil.Response{data=application.EnterResponse} "Existing player found"
+// @Success 201 {object} httputil.Response{data=application.EnterResponse} "New player created"
+// @Failure 400 {object} object "Bad request or invalid referral code"
+// @Failure 403 {object} object "Player suspended, banned or deleted"
+// @Failure 409 {object} object "Player updated concurrently; retry"
+// @Failure 429 {object} object "Referral limit reached"
 // @Failure 500 {object} object "Internal server error"
 // @Router /players/enter [post]
 func (h *PlayerHandler) Enter(c *fiber.Ctx) error {
 	// Parse request body
 	var req application.EnterRequest
 	if err := c.BodyParser(&req); err != nil {
-		return httputil.BadRequest(c, constants.ErrCodeValidationFailed, "Invalid request body")
+		return shared.NewValidationError("Invalid request body")
 	}
 
+	req.IP = c.IP()
+
 	// Execute usecase
-	resp, err := h.enterUC.Execute(c.Context(), req)
+	resp, err := h.enterUC.Execute(c.UserContext(), req)
 	if err != nil {
-		return httputil.Error(c, constants.StatusInternalServerError, "INTERNAL_ERROR", "Failed to enter player")
+		return err
 	}
 
 	// Return response with appropriate status (200 or 201)
-	status := fiber.StatusOK
 	if resp.IsNew {
-		status = fiber.StatusCreated
+		return httputil.Created(c, resp)
 	}
-
-	return c.Status(status).JSON(resp)
+	return httputil.Success(c, fiber.StatusOK, resp)
 }
 
 // GetProfile handles GET /players/:id
@@ -64,7 +89,7 @@ func (h *PlayerHandler) Enter(c *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Param id path string true "Player ID"
-// @Success 200 {object} application.ProfileResponse "Player profile"
+// @Success 200 {object} httputil.Response{data=application.ProfileResponse} "Player profile"
 // @Failure 400 {object} object "Bad request"
 // @Failure 404 {object} object "Player not found"
 // @Failure 500 {object} object "Internal server error"
@@ -73,7 +98,7 @@ func (h *PlayerHandler) GetProfile(c *fiber.Ctx) error {
 	// Parse player ID from URL
 	playerID := c.Params("id")
 	if playerID == "" {
-		return httputil.BadRequest(c, constants.ErrCodeValidationFailed, "Player ID is required")
+		return shared.NewValidationError("Player ID is required")
 	}
 
 	req := application.GetProfileRequest{
@@ -81,14 +106,159 @@ func (h *PlayerHandler) GetProfile(c *fiber.Ctx) error {
 	}
 
 	// Execute usecase
-	resp, err := h.getProfileUC.Execute(c.Context(), req)
+	resp, err := h.getProfileUC.Execute(c.UserContext(), req)
 	if err != nil {
-		if err == shared.ErrPlayerNotFound {
-			return httputil.NotFound(c, constants.ErrCodePlayerNotFound, "Player not found")
-		}
-		return httputil.Error(c, constants.StatusInternalServerError, "INTERNAL_ERROR", "Failed to get player profile")
+		return err
 	}
 
 	// Return response
-	return c.JSON(resp)
+	return httputil.Success(c, fiber.StatusOK, resp)
+}
+
+// GetStats handles GET /players/:id/stats
+// @Summary Get player statistics
+// @Description Spin counts and points today, this week (from Monday UTC) and all time, outcome distribution, daily streaks, and the next unclaimed checkpoint
+// @Tags Players
+// @Accept json
+// @Produce json
+// @Param id path string true "Player ID"
+// @Success 200 {object} httputil.Response{data=application.StatsResponse} "Player statistics"
+// @Failure 400 {object} object "Bad request"
+// @Failure 404 {object} object "Player not found"
+// @Failure 500 {object} object "Internal server error"
+// @Router /players/{id}/stats [get]
+func (h *PlayerHandler) GetStats(c *fiber.Ctx) error {
+	// Parse player ID from URL
+	playerID := c.Params("id")
+	if playerID == "" {
+		return shared.NewValidationError("Player ID is required")
+	}
+
+	// Execute usecase
+	resp, err := h.getStatsUC.Execute(c.UserContext(), application.GetStatsRequest{PlayerID: playerID})
+	if err != nil {
+		return err
+	}
+
+	return httputil.Success(c, fiber.StatusOK, resp)
+}
+
+// GetReferrals handles GET /players/:id/referrals
+// @Summary Get player referrals
+// @Description The player's invite code, referral counts and points earned, and the most recent referred players with their progress towards the milestone
+// @Tags Players
+// @Accept json
+// @Produce json
+// @Param id path string true "Player ID"
+// @Success 200 {object} httputil.Response{data=application.ReferralsResponse} "Referral summary"
+// @Failure 400 {object} object "Bad request"
+// @Failure 404 {object} object "Player not found"
+// @Failure 500 {object} object "Internal server error"
+// @Router /players/{id}/referrals [get]
+func (h *PlayerHandler) GetReferrals(c *fiber.Ctx) error {
+	// Parse player ID from URL
+	playerID := c.Params("id")
+	if playerID == "" {
+		return shared.NewValidationError("Player ID is required")
+	}
+
+	// Execute usecase
+	resp, err := h.getReferralsUC.Execute(c.UserContext(), application.GetReferralsRequest{PlayerID: playerID})
+	if err != nil {
+		return err
+	}
+
+	return httputil.Success(c, fiber.StatusOK, resp)
+}
+
+// Rename handles POST /players/:id/rename
+// @Summary Change nickname
+// @Description Change the player's nickname; allowed once per cooldown (players.yaml)
+// @Tags Players
+// @Accept json
+// @Produce json
+// @Param id path string true "Player ID"
+// @Param request body application.RenameRequest true "New nickname"
+// @Success 200 {object} httputil.Response{data=application.RenameResponse} "Player renamed"
+// @Failure 400 {object} object "Invalid nickname"
+// @Failure 403 {object} object "Player suspended, banned or deleted"
+// @Failure 404 {object} object "Player not found"
+// @Failure 409 {object} object "Nickname already taken, or player updated concurrently"
+// @Failure 429 {object} object "Renamed too recently"
+// @Failure 500 {object} object "Internal server error"
+// @Router /players/{id}/rename [post]
+func (h *PlayerHandler) Rename(c *fiber.Ctx) error {
+	var req application.RenameRequest
+	if err := c.BodyParser(&req); err != nil {
+		return shared.NewValidationError("Invalid request body")
+	}
+	req.PlayerID = c.Params("id")
+
+	resp, err := h.renameUC.Execute(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return httputil.Success(c, fiber.StatusOK, resp)
+}
+
+// ChangeLocale handles POST /players/:id/locale
+// @Summary Set preferred locale
+// @Description Render the player's reward names and error messages in this locale (en or th), whatever Accept-Language says. An empty locale clears the preference.
+// @Tags Players
+// @Accept json
+// @Produce json
+// @Param id path string true "Player ID"
+// @Param request body application.ChangeLocaleRequest true "Preferred locale"
+// @Success 200 {object} httputil.Response{data=application.LocaleResponse} "Locale changed"
+// @Failure 400 {object} object "Unsupported locale"
+// @Failure 403 {object} object "Player suspended, banned or deleted"
+// @Failure 404 {object} object "Player not found"
+// @Failure 409 {object} object "Player updated concurrently; retry"
+// @Failure 500 {object} object "Internal server error"
+// @Router /players/{id}/locale [post]
+func (h *PlayerHandler) ChangeLocale(c *fiber.Ctx) error {
+	var req application.ChangeLocaleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return shared.NewValidationError("Invalid request body")
+	}
+	req.PlayerID = c.Params("id")
+
+	resp, err := h.changeLocaleUC.Execute(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return httputil.Success(c, fiber.StatusOK, resp)
+}
+
+// ChangeStatus handles POST /admin/players/:id/status
+// @Summary Change player status (admin)
+// @Description Suspend (until suspended_until), ban, reactivate or soft delete a player. A reason is required. Deleted players cannot change status again; their nickname is released after the grace period.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Player ID"
+// @Param X-Admin-Key header string true "Admin API key"
+// @Param request body application.ChangeStatusRequest true "New status"
+// @Success 200 {object} httputil.Response{data=application.PlayerStatusResponse} "Status changed"
+// @Failure 400 {object} object "Invalid status change"
+// @Failure 401 {object} object "Missing or invalid admin key"
+// @Failure 404 {object} object "Player not found"
+// @Failure 409 {object} object "Player is deleted, or updated concurrently"
+// @Failure 500 {object} object "Internal server error"
+// @Router /admin/players/{id}/status [post]
+func (h *PlayerHandler) ChangeStatus(c *fiber.Ctx) error {
+	var req application.ChangeStatusRequest
+	if err := c.BodyParser(&req); err != nil {
+		return shared.NewValidationError("Invalid request body")
+	}
+	req.PlayerID = c.Params("id")
+
+	resp, err := h.changeStatusUC.Execute(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return httputil.Success(c, fiber.StatusOK, resp)
 }
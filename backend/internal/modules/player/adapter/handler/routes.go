@@ -3,9 +3,20 @@ package handler
 import "github.com/gofiber/fiber/v2"
 
 // RegisterRoutes registers player routes
-func (h *PlayerHandler) RegisterRoutes(app *fiber.App) {
-	players := app.Group("/players")
+func (h *PlayerHandler) RegisterRoutes(router fiber.Router) {
+	players := router.Group("/players")
 
 	players.Post("/enter", h.Enter)
 	players.Get("/:id", h.GetProfile)
+	players.Get("/:id/stats", h.GetStats)
+	players.Get("/:id/referrals", h.GetReferrals)
+	players.Post("/:id/rename", h.Rename)
+	players.Post("/:id/locale", h.ChangeLocale)
+}
+
+// RegisterAdminRoutes registers player admin routes on an authenticated router
+func (h *PlayerHandler) RegisterAdminRoutes(admin fiber.Router) {
+	players := admin.Group("/players")
+
+	players.Post("/:id/status", h.ChangeStatus)
 }
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"backend/internal/modules/player/domain"
+	"backend/internal/shared/contracttest"
+
+	"gorm.io/gorm"
+)
+
+func TestPlayerRepositoryMemoryContract(t *testing.T) {
+	contracttest.PlayerRepository(t, func(t *testing.T) domain.PlayerRepository {
+		return NewPlayerRepositoryMemory(domain.NewPlayerFactory(3, 50))
+	})
+}
+
+func TestPlayerRepositoryGormContract(t *testing.T) {
+	testPlayerRepositoryGorm(t, openTestDB(t))
+}
+
+func TestPlayerRepositorySQLiteContract(t *testing.T) {
+	testPlayerRepositoryGorm(t, contracttest.OpenSQLite(t))
+}
+
+func testPlayerRepositoryGorm(t *testing.T, db *gorm.DB) {
+	contracttest.PlayerRepository(t, func(t *testing.T) domain.PlayerRepository {
+		return &cleanupPlayerRepo{PlayerRepositoryGorm: NewPlayerRepositoryGorm(db, domain.NewPlayerFactory(3, 50)), t: t, db: db}
+	})
+}
+
+// cleanupPlayerRepo deletes the players a test stores once the test ends
+type cleanupPlayerRepo struct {
+	*PlayerRepositoryGorm
+	t  *testing.T
+	db *gorm.DB
+}
+
+func (r *cleanupPlayerRepo) Store(ctx context.Context, player *domain.Player) error {
+	if err := r.PlayerRepositoryGorm.Store(ctx, player); err != nil {
+		return err
+	}
+	r.t.Cleanup(func() {
+		r.db.Where("id = ?", player.ID().String()).Delete(&PlayerModel{})
+	})
+	return nil
+}
@@ -8,10 +8,36 @@ import (
 // PlayerModel is the GORM database model
 type PlayerModel struct {
 	ID          string    `gorm:"type:uuid;primaryKey"`
-	Nickname    string    `gorm:"type:varchar(50);uniqueIndex;not null"`
+	Nickname    string    `gorm:"type:varchar(50);not null"` // unique while nickname_released_at is null
+	InviteCode  string    `gorm:"type:varchar(8);uniqueIndex;not null"`
 	TotalPoints int       `gorm:"type:integer;not null;default:0"`
 	CreatedAt   time.Time `gorm:"not null"`
 	UpdatedAt   time.Time `gorm:"not null"`
+	Version     int       `gorm:"type:integer;not null;default:1"` // optimistic concurrency
+
+	// Login streak and streak reward boosts
+	CurrentStreak       int        `gorm:"type:integer;not null;default:0"`
+	LongestStreak       int        `gorm:"type:integer;not null;default:0"`
+	StreakLastDay       *time.Time `gorm:"type:date"`
+	StreakFreezes       int        `gorm:"type:integer;not null;default:0"`
+	BonusSpins          int        `gorm:"type:integer;not null;default:0"`
+	PointsMultiplier    float64    `gorm:"type:numeric(6,2);not null;default:1"`
+	MultiplierExpiresAt *time.Time
+
+	// Account lifecycle
+	Status             string `gorm:"type:varchar(10);not null;default:'active'"`
+	SuspendedUntil     *time.Time
+	StatusReason       string `gorm:"type:text;not null;default:''"`
+	StatusChangedAt    *time.Time
+	NicknameChangedAt  *time.Time
+	NicknameReleasedAt *time.Time
+	ErasedAt           *time.Time
+
+	// Preferred locale; empty follows Accept-Language
+	Locale string `gorm:"type:varchar(10);not null;default:''"`
+
+	// Where the player signed up; cleared on erasure
+	SignupIP string `gorm:"type:varchar(45);not null;default:''"`
 }
 
 func (PlayerModel) TableName() string {
@@ -1,10 +1,12 @@
 package repository
 
 import (
+	"backend/internal/infrastructure/database"
 	"backend/internal/modules/player/domain"
 	shared "backend/internal/shared/domain"
 	"context"
 	"errors"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -24,8 +26,8 @@ func NewPlayerRepositoryGorm(db *gorm.DB, factory *domain.PlayerFactory) *Player
 
 // Store stores a new player
 func (r *PlayerRepositoryGorm) Store(ctx context.Context, player *domain.Player) error {
-	model := r.toModel(player)
-	result := r.db.WithContext(ctx).Create(model)
+	model := toPlayerModel(player)
+	result := database.Conn(ctx, r.db).Create(model)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -35,7 +37,7 @@ func (r *PlayerRepositoryGorm) Store(ctx context.Context, player *domain.Player)
 // FindByID loads player by ID
 func (r *PlayerRepositoryGorm) FindByID(ctx context.Context, id *domain.PlayerID) (*domain.Player, error) {
 	var model PlayerModel
-	result := r.db.WithContext(ctx).Where("id = ?", id.String()).First(&model)
+	result := database.Conn(ctx, r.db).Where("id = ?", id.String()).First(&model)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, shared.ErrPlayerNotFound
@@ -43,13 +45,27 @@ func (r *PlayerRepositoryGorm) FindByID(ctx context.Context, id *domain.PlayerID
 		return nil, result.Error
 	}
 
-	return r.toDomain(&model)
+	return toPlayerDomain(r.factory, &model)
+}
+
+// FindByInviteCode loads the player who owns an invite code
+func (r *PlayerRepositoryGorm) FindByInviteCode(ctx context.Context, code *domain.InviteCode) (*domain.Player, error) {
+	var model PlayerModel
+	result := database.Conn(ctx, r.db).Where("invite_code = ?", code.String()).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, shared.ErrPlayerNotFound
+		}
+		return nil, result.Error
+	}
+
+	return toPlayerDomain(r.factory, &model)
 }
 
 // FindByNickname loads player by nickname
 func (r *PlayerRepositoryGorm) FindByNickname(ctx context.Context, nickname *domain.Nickname) (*domain.Player, error) {
 	var model PlayerModel
-	result := r.db.WithContext(ctx).Where("nickname = ?", nickname.String()).First(&model)
+	result := database.Conn(ctx, r.db).Where("nickname = ? AND nickname_released_at IS NULL", nickname.String()).First(&model)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, shared.ErrPlayerNotFound
@@ -57,47 +73,114 @@ func (r *PlayerRepositoryGorm) FindByNickname(ctx context.Context, nickname *dom
 		return nil, result.Error
 	}
 
-	return r.toDomain(&model)
+	return toPlayerDomain(r.factory, &model)
 }
 
-// Update persists changes to existing player
+// Update persists changes to existing player, conditional on its version
 func (r *PlayerRepositoryGorm) Update(ctx context.Context, player *domain.Player) error {
-	model := r.toModel(player)
-	result := r.db.WithContext(ctx).Save(model)
+	model := toPlayerModel(player)
+	model.Version = player.Version() + 1
+	result := database.Conn(ctx, r.db).
+		Model(model).
+		Where("version = ?", player.Version()).
+		Select("*").
+		Omit("id", "created_at").
+		Updates(model)
 	if result.Error != nil {
 		return result.Error
 	}
+	if result.RowsAffected == 0 {
+		// Either another update got there first or the player is gone
+		var count int64
+		if err := database.Conn(ctx, r.db).Model(&PlayerModel{}).Where("id = ?", model.ID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return shared.ErrPlayerNotFound
+		}
+		return shared.ErrConcurrentModification
+	}
+
+	player.SetVersion(model.Version)
 	return nil
 }
 
 // ExistsByNickname checks if nickname is taken
 func (r *PlayerRepositoryGorm) ExistsByNickname(ctx context.Context, nickname *domain.Nickname) (bool, error) {
 	var count int64
-	result := r.db.WithContext(ctx).Model(&PlayerModel{}).Where("nickname = ?", nickname.String()).Count(&count)
+	result := database.Conn(ctx, r.db).Model(&PlayerModel{}).Where("nickname = ? AND nickname_released_at IS NULL", nickname.String()).Count(&count)
 	if result.Error != nil {
 		return false, result.Error
 	}
 	return count > 0, nil
 }
 
-// toModel converts domain to model
-func (r *PlayerRepositoryGorm) toModel(player *domain.Player) *PlayerModel {
+// ReleaseNicknames frees the nicknames of players deleted before a time
+func (r *PlayerRepositoryGorm) ReleaseNicknames(ctx context.Context, deletedBefore time.Time) (int, error) {
+	result := database.Conn(ctx, r.db).
+		Model(&PlayerModel{}).
+		Where("status = ? AND status_changed_at < ? AND nickname_released_at IS NULL", string(domain.PlayerDeleted), deletedBefore).
+		Updates(map[string]interface{}{
+			"nickname_released_at": time.Now(),
+			"version":              gorm.Expr("version + 1"),
+		})
+	return int(result.RowsAffected), result.Error
+}
+
+// toPlayerModel converts domain to model
+func toPlayerModel(player *domain.Player) *PlayerModel {
+	streak := player.Streak()
+	multiplier, multiplierUntil := player.Boosts().Multiplier()
+	lifecycle := player.Lifecycle()
 	return &PlayerModel{
-		ID:          player.ID().String(),
-		Nickname:    player.Nickname().String(),
-		TotalPoints: player.TotalPoints().Value(),
-		CreatedAt:   player.CreatedAt(),
-		UpdatedAt:   player.UpdatedAt(),
+		ID:                  player.ID().String(),
+		Nickname:            player.Nickname().String(),
+		InviteCode:          player.InviteCode().String(),
+		TotalPoints:         player.TotalPoints().Value(),
+		CreatedAt:           player.CreatedAt(),
+		UpdatedAt:           player.UpdatedAt(),
+		CurrentStreak:       streak.Current(),
+		LongestStreak:       streak.Longest(),
+		StreakLastDay:       streak.LastDay(),
+		StreakFreezes:       streak.Freezes(),
+		BonusSpins:          player.Boosts().BonusSpins(),
+		PointsMultiplier:    multiplier,
+		MultiplierExpiresAt: multiplierUntil,
+		Status:              string(lifecycle.Status()),
+		SuspendedUntil:      lifecycle.SuspendedUntil(),
+		StatusReason:        lifecycle.Reason(),
+		StatusChangedAt:     lifecycle.ChangedAt(),
+		NicknameChangedAt:   lifecycle.NicknameChangedAt(),
+		NicknameReleasedAt:  lifecycle.NicknameReleasedAt(),
+		ErasedAt:            lifecycle.ErasedAt(),
+		Locale:              player.Locale(),
+		SignupIP:            player.SignupIP(),
+		Version:             player.Version(),
 	}
 }
 
-// toDomain converts model to domain
-func (r *PlayerRepositoryGorm) toDomain(model *PlayerModel) (*domain.Player, error) {
-	return r.factory.ReconstructPlayer(
+// toPlayerDomain converts model to domain
+func toPlayerDomain(factory *domain.PlayerFactory, model *PlayerModel) (*domain.Player, error) {
+	return factory.ReconstructPlayer(
 		model.ID,
 		model.Nickname,
+		model.InviteCode,
 		model.TotalPoints,
+		domain.NewLoginStreak(model.CurrentStreak, model.LongestStreak, model.StreakLastDay, model.StreakFreezes),
+		domain.NewBoosts(model.BonusSpins, model.PointsMultiplier, model.MultiplierExpiresAt),
+		domain.NewLifecycle(
+			domain.PlayerStatus(model.Status),
+			model.SuspendedUntil,
+			model.StatusReason,
+			model.StatusChangedAt,
+			model.NicknameChangedAt,
+			model.NicknameReleasedAt,
+			model.ErasedAt,
+		),
+		model.Locale,
+		model.SignupIP,
 		model.CreatedAt,
 		model.UpdatedAt,
+		model.Version,
 	)
 }
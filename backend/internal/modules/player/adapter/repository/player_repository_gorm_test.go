@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"backend/internal/modules/player/domain"
+	"backend/internal/shared/contracttest"
+	shared "backend/internal/shared/domain"
+
+	"gorm.io/gorm"
+)
+
+// openTestDB connects to a migrated Postgres named by TEST_DATABASE_URL
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	return contracttest.OpenPostgres(t)
+}
+
+// storeTestPlayer stores a fresh player and removes it when the test ends
+func storeTestPlayer(t *testing.T, db *gorm.DB, repo *PlayerRepositoryGorm, factory *domain.PlayerFactory) *domain.Player {
+	t.Helper()
+	player, err := factory.CreateNewPlayer("occ-" + domain.GeneratePlayerID().String()[:8])
+	if err != nil {
+		t.Fatalf("create player: %v", err)
+	}
+	if err := repo.Store(context.Background(), player); err != nil {
+		t.Fatalf("store player: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Where("id = ?", player.ID().String()).Delete(&PlayerModel{})
+	})
+	return player
+}
+
+func addPoints(t *testing.T, player *domain.Player, amount int) {
+	t.Helper()
+	points, err := shared.NewPoints(amount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := player.AddPoints(points); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUpdateRejectsStaleCopy(t *testing.T) {
+	db := openTestDB(t)
+	factory := domain.NewPlayerFactory(3, 50)
+	repo := NewPlayerRepositoryGorm(db, factory)
+	ctx := context.Background()
+	player := storeTestPlayer(t, db, repo, factory)
+
+	// Two requests load the same player
+	first, err := repo.FindByID(ctx, player.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := repo.FindByID(ctx, player.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first one to write wins
+	addPoints(t, first, 100)
+	if err := repo.Update(ctx, first); err != nil {
+		t.Fatalf("first update: %v", err)
+	}
+	if first.Version() != 2 {
+		t.Fatalf("version after first update = %d, want 2", first.Version())
+	}
+
+	// The stale copy must not overwrite the first one's points
+	addPoints(t, second, 50)
+	if err := repo.Update(ctx, second); !errors.Is(err, shared.ErrConcurrentModification) {
+		t.Fatalf("stale update error = %v, want ErrConcurrentModification", err)
+	}
+
+	// Reloading and reapplying succeeds and keeps both changes
+	second, err = repo.FindByID(ctx, player.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	addPoints(t, second, 50)
+	if err := repo.Update(ctx, second); err != nil {
+		t.Fatalf("update after reload: %v", err)
+	}
+
+	stored, err := repo.FindByID(ctx, player.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := stored.TotalPoints().Value(); got != 150 {
+		t.Fatalf("total points = %d, want 150", got)
+	}
+	if stored.Version() != 3 {
+		t.Fatalf("version = %d, want 3", stored.Version())
+	}
+}
+
+func TestUpdateAfterNicknameReleaseIsStale(t *testing.T) {
+	db := openTestDB(t)
+	factory := domain.NewPlayerFactory(3, 50)
+	repo := NewPlayerRepositoryGorm(db, factory)
+	ctx := context.Background()
+	player := storeTestPlayer(t, db, repo, factory)
+
+	// Deleted long enough ago for the nickname to be released
+	if err := player.Delete("closed account", player.CreatedAt().AddDate(0, -2, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Update(ctx, player); err != nil {
+		t.Fatal(err)
+	}
+	stale, err := repo.FindByID(ctx, player.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.ReleaseNicknames(ctx, player.CreatedAt().AddDate(0, -1, 0)); err != nil {
+		t.Fatal(err)
+	}
+
+	// A copy loaded before the release would clear nickname_released_at
+	if err := repo.Update(ctx, stale); !errors.Is(err, shared.ErrConcurrentModification) {
+		t.Fatalf("stale update error = %v, want ErrConcurrentModification", err)
+	}
+}
+
+func TestUpdateMissingPlayer(t *testing.T) {
+	db := openTestDB(t)
+	factory := domain.NewPlayerFactory(3, 50)
+	repo := NewPlayerRepositoryGorm(db, factory)
+
+	player, err := factory.CreateNewPlayer("never-stored")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Update(context.Background(), player); !errors.Is(err, shared.ErrPlayerNotFound) {
+		t.Fatalf("update error = %v, want ErrPlayerNotFound", err)
+	}
+}
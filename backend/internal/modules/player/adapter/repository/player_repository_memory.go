@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"backend/internal/modules/player/domain"
+	shared "backend/internal/shared/domain"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	errNicknameTaken   = errors.New("nickname already taken")
+	errInviteCodeTaken = errors.New("invite code already taken")
+	errPlayerExists    = errors.New("player already exists")
+)
+
+// PlayerRepositoryMemory implements domain.PlayerRepository in process memory
+// (STORAGE=memory and tests); it keeps the same constraints as the players table
+type PlayerRepositoryMemory struct {
+	mu      sync.RWMutex
+	players map[string]PlayerModel
+	factory *domain.PlayerFactory
+}
+
+func NewPlayerRepositoryMemory(factory *domain.PlayerFactory) *PlayerRepositoryMemory {
+	return &PlayerRepositoryMemory{
+		players: make(map[string]PlayerModel),
+		factory: factory,
+	}
+}
+
+// Store stores a new player
+func (r *PlayerRepositoryMemory) Store(ctx context.Context, player *domain.Player) error {
+	model := toPlayerModel(player)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.players[model.ID]; ok {
+		return errPlayerExists
+	}
+	for _, other := range r.players {
+		if other.InviteCode == model.InviteCode {
+			return errInviteCodeTaken
+		}
+		if other.NicknameReleasedAt == nil && other.Nickname == model.Nickname {
+			return errNicknameTaken
+		}
+	}
+	r.players[model.ID] = *model
+	return nil
+}
+
+// FindByID loads player by ID
+func (r *PlayerRepositoryMemory) FindByID(ctx context.Context, id *domain.PlayerID) (*domain.Player, error) {
+	return r.findFirst(func(m *PlayerModel) bool { return m.ID == id.String() })
+}
+
+// FindByInviteCode loads the player who owns an invite code
+func (r *PlayerRepositoryMemory) FindByInviteCode(ctx context.Context, code *domain.InviteCode) (*domain.Player, error) {
+	return r.findFirst(func(m *PlayerModel) bool { return m.InviteCode == code.String() })
+}
+
+// FindByNickname loads the player holding a nickname
+func (r *PlayerRepositoryMemory) FindByNickname(ctx context.Context, nickname *domain.Nickname) (*domain.Player, error) {
+	return r.findFirst(func(m *PlayerModel) bool {
+		return m.NicknameReleasedAt == nil && m.Nickname == nickname.String()
+	})
+}
+
+// Update persists changes to existing player, conditional on its version
+func (r *PlayerRepositoryMemory) Update(ctx context.Context, player *domain.Player) error {
+	model := toPlayerModel(player)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	current, ok := r.players[model.ID]
+	if !ok {
+		return shared.ErrPlayerNotFound
+	}
+	if current.Version != player.Version() {
+		return shared.ErrConcurrentModification
+	}
+	for id, other := range r.players {
+		if id != model.ID && other.NicknameReleasedAt == nil && model.NicknameReleasedAt == nil && other.Nickname == model.Nickname {
+			return errNicknameTaken
+		}
+	}
+
+	model.CreatedAt = current.CreatedAt
+	model.Version = current.Version + 1
+	r.players[model.ID] = *model
+	player.SetVersion(model.Version)
+	return nil
+}
+
+// ExistsByNickname checks if nickname is taken
+func (r *PlayerRepositoryMemory) ExistsByNickname(ctx context.Context, nickname *domain.Nickname) (bool, error) {
+	_, err := r.FindByNickname(ctx, nickname)
+	if errors.Is(err, shared.ErrPlayerNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// ReleaseNicknames frees the nicknames of players deleted before a time
+func (r *PlayerRepositoryMemory) ReleaseNicknames(ctx context.Context, deletedBefore time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	released := 0
+	for id, model := range r.players {
+		if model.Status != string(domain.PlayerDeleted) || model.NicknameReleasedAt != nil ||
+			model.StatusChangedAt == nil || !model.StatusChangedAt.Before(deletedBefore) {
+			continue
+		}
+		model.NicknameReleasedAt = &now
+		model.Version++
+		r.players[id] = model
+		released++
+	}
+	return released, nil
+}
+
+// Nickname returns a player's current nickname ("" when unknown); memory
+// repositories of other modules use it in place of a join on players
+func (r *PlayerRepositoryMemory) Nickname(playerID string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.players[playerID].Nickname
+}
+
+// findFirst returns the first player matching match
+func (r *PlayerRepositoryMemory) findFirst(match func(*PlayerModel) bool) (*domain.Player, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, model := range r.players {
+		if match(&model) {
+			return toPlayerDomain(r.factory, &model)
+		}
+	}
+	return nil, shared.ErrPlayerNotFound
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"backend/internal/shared/constants"
+	"time"
+)
+
+// ReferralModel is the GORM database model
+type ReferralModel struct {
+	ID            string    `gorm:"type:uuid;primaryKey"`
+	ReferrerID    string    `gorm:"type:uuid;not null;index"`
+	RefereeID     string    `gorm:"type:uuid;not null;uniqueIndex"`
+	InviteCode    string    `gorm:"type:varchar(8);not null"`
+	IPAddress     string    `gorm:"type:varchar(45);not null;default:''"`
+	Status        string    `gorm:"type:varchar(10);not null;default:'pending'"`
+	RefereeSpins  int       `gorm:"type:integer;not null;default:0"`
+	RefereePoints int       `gorm:"type:integer;not null;default:0"`
+	RewardPoints  int       `gorm:"type:integer;not null;default:0"`
+	CreatedAt     time.Time `gorm:"not null"`
+	RewardedAt    *time.Time
+}
+
+func (ReferralModel) TableName() string {
+	return constants.TableReferrals
+}
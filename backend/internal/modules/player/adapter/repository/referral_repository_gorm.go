@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"backend/internal/infrastructure/database"
+	"backend/internal/modules/player/domain"
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReferralRepositoryGorm implements domain.ReferralRepository
+type ReferralRepositoryGorm struct {
+	db *gorm.DB
+}
+
+func NewReferralRepositoryGorm(db *gorm.DB) *ReferralRepositoryGorm {
+	return &ReferralRepositoryGorm{db: db}
+}
+
+// Store stores a new referral
+func (r *ReferralRepositoryGorm) Store(ctx context.Context, referral *domain.Referral) error {
+	return database.Conn(ctx, r.db).Create(toReferralModel(referral)).Error
+}
+
+// FindByReferee loads the referral of a referred player
+func (r *ReferralRepositoryGorm) FindByReferee(ctx context.Context, refereeID string) (*domain.Referral, error) {
+	var model ReferralModel
+	result := database.Conn(ctx, r.db).Where("referee_id = ?", refereeID).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrReferralNotFound
+		}
+		return nil, result.Error
+	}
+	return toReferralDomain(&model), nil
+}
+
+// RecordSpin increments referee_spins in SQL, so concurrent spins by the same
+// referee never lose a count
+func (r *ReferralRepositoryGorm) RecordSpin(ctx context.Context, refereeID string, totalPoints int) (*domain.Referral, error) {
+	var models []ReferralModel
+	result := database.Conn(ctx, r.db).
+		Model(&models).
+		Clauses(clause.Returning{}).
+		Where("referee_id = ? AND status = ?", refereeID, string(domain.ReferralPending)).
+		Updates(map[string]interface{}{
+			"referee_spins":  gorm.Expr("referee_spins + 1"),
+			"referee_points": gorm.Expr("CASE WHEN referee_points < ? THEN ? ELSE referee_points END", totalPoints, totalPoints),
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if len(models) == 0 {
+		return nil, domain.ErrReferralNotFound
+	}
+	return toReferralDomain(&models[0]), nil
+}
+
+// MarkRewarded flips a pending referral to rewarded; the status condition
+// makes concurrent milestone spins pay the referrer once
+func (r *ReferralRepositoryGorm) MarkRewarded(ctx context.Context, referral *domain.Referral) (bool, error) {
+	result := database.Conn(ctx, r.db).
+		Model(&ReferralModel{}).
+		Where("id = ? AND status = ?", referral.ID(), string(domain.ReferralPending)).
+		Updates(map[string]interface{}{
+			"status":        string(referral.Status()),
+			"reward_points": referral.RewardPoints(),
+			"rewarded_at":   referral.RewardedAt(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// CountByReferrerSince counts referrals a referrer made since a time
+func (r *ReferralRepositoryGorm) CountByReferrerSince(ctx context.Context, referrerID string, since time.Time) (int, error) {
+	var count int64
+	err := database.Conn(ctx, r.db).
+		Model(&ReferralModel{}).
+		Where("referrer_id = ? AND created_at >= ?", referrerID, since).
+		Count(&count).Error
+	return int(count), err
+}
+
+// CountByIPSince counts referrals signed up from an IP since a time
+func (r *ReferralRepositoryGorm) CountByIPSince(ctx context.Context, ip string, since time.Time) (int, error) {
+	var count int64
+	err := database.Conn(ctx, r.db).
+		Model(&ReferralModel{}).
+		Where("ip_address = ? AND created_at >= ?", ip, since).
+		Count(&count).Error
+	return int(count), err
+}
+
+// SummaryByReferrer counts a referrer's referrals by status
+func (r *ReferralRepositoryGorm) SummaryByReferrer(ctx context.Context, referrerID string) (*domain.ReferralSummary, error) {
+	var row struct {
+		Total        int
+		Pending      int
+		Rewarded     int
+		PointsEarned int
+	}
+	err := database.Conn(ctx, r.db).
+		Model(&ReferralModel{}).
+		Select(`COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE status = ?) AS pending,
+			COUNT(*) FILTER (WHERE status = ?) AS rewarded,
+			COALESCE(SUM(reward_points), 0) AS points_earned`,
+			string(domain.ReferralPending), string(domain.ReferralRewarded)).
+		Where("referrer_id = ?", referrerID).
+		Scan(&row).Error
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ReferralSummary{
+		Total:        row.Total,
+		Pending:      row.Pending,
+		Rewarded:     row.Rewarded,
+		PointsEarned: row.PointsEarned,
+	}, nil
+}
+
+// referralEntryRow is a referral joined with the referee's nickname
+type referralEntryRow struct {
+	ReferralModel
+	RefereeNickname string
+}
+
+// ListByReferrer returns a referrer's most recent referrals (all when limit <= 0)
+func (r *ReferralRepositoryGorm) ListByReferrer(ctx context.Context, referrerID string, limit int) ([]*domain.ReferralEntry, error) {
+	var rows []referralEntryRow
+	query := database.Conn(ctx, r.db).
+		Model(&ReferralModel{}).
+		Select("referrals.*, players.nickname AS referee_nickname").
+		Joins("JOIN players ON players.id = referrals.referee_id").
+		Where("referrals.referrer_id = ?", referrerID).
+		Order("referrals.created_at DESC, referrals.id DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	entries := make([]*domain.ReferralEntry, len(rows))
+	for i := range rows {
+		entries[i] = &domain.ReferralEntry{
+			Referral:        toReferralDomain(&rows[i].ReferralModel),
+			RefereeNickname: rows[i].RefereeNickname,
+		}
+	}
+	return entries, nil
+}
+
+// DetachPersonalData clears the signup IP of the player's referral
+func (r *ReferralRepositoryGorm) DetachPersonalData(ctx context.Context, refereeID string) error {
+	return database.Conn(ctx, r.db).
+		Model(&ReferralModel{}).
+		Where("referee_id = ?", refereeID).
+		Update("ip_address", "").Error
+}
+
+// toReferralModel converts domain to model
+func toReferralModel(referral *domain.Referral) *ReferralModel {
+	return &ReferralModel{
+		ID:            referral.ID(),
+		ReferrerID:    referral.ReferrerID(),
+		RefereeID:     referral.RefereeID(),
+		InviteCode:    referral.InviteCode(),
+		IPAddress:     referral.IPAddress(),
+		Status:        string(referral.Status()),
+		RefereeSpins:  referral.RefereeSpins(),
+		RefereePoints: referral.RefereePoints(),
+		RewardPoints:  referral.RewardPoints(),
+		CreatedAt:     referral.CreatedAt(),
+		RewardedAt:    referral.RewardedAt(),
+	}
+}
+
+// toReferralDomain converts model to domain
+func toReferralDomain(model *ReferralModel) *domain.Referral {
+	return domain.ReconstructReferral(
+		model.ID,
+		model.ReferrerID,
+		model.RefereeID,
+		model.InviteCode,
+		model.IPAddress,
+		domain.ReferralStatus(model.Status),
+		model.RefereeSpins,
+		model.RefereePoints,
+		model.RewardPoints,
+		model.CreatedAt,
+		model.RewardedAt,
+	)
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"backend/internal/modules/player/domain"
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+)
+
+var errReferralExists = errors.New("player already has a referral")
+
+// ReferralRepositoryMemory implements domain.ReferralRepository in process memory
+type ReferralRepositoryMemory struct {
+	mu        sync.RWMutex
+	referrals map[string]ReferralModel // by referee
+	players   *PlayerRepositoryMemory  // referee nicknames
+}
+
+func NewReferralRepositoryMemory(players *PlayerRepositoryMemory) *ReferralRepositoryMemory {
+	return &ReferralRepositoryMemory{
+		referrals: make(map[string]ReferralModel),
+		players:   players,
+	}
+}
+
+// Store stores a new referral
+func (r *ReferralRepositoryMemory) Store(ctx context.Context, referral *domain.Referral) error {
+	model := toReferralModel(referral)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.referrals[model.RefereeID]; ok {
+		return errReferralExists
+	}
+	r.referrals[model.RefereeID] = *model
+	return nil
+}
+
+// FindByReferee loads the referral of a referred player
+func (r *ReferralRepositoryMemory) FindByReferee(ctx context.Context, refereeID string) (*domain.Referral, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	model, ok := r.referrals[refereeID]
+	if !ok {
+		return nil, domain.ErrReferralNotFound
+	}
+	return toReferralDomain(&model), nil
+}
+
+// RecordSpin counts a referee spin and keeps their highest total
+func (r *ReferralRepositoryMemory) RecordSpin(ctx context.Context, refereeID string, totalPoints int) (*domain.Referral, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	model, ok := r.referrals[refereeID]
+	if !ok || model.Status != string(domain.ReferralPending) {
+		return nil, domain.ErrReferralNotFound
+	}
+	model.RefereeSpins++
+	model.RefereePoints = max(model.RefereePoints, totalPoints)
+	r.referrals[model.RefereeID] = model
+	return toReferralDomain(&model), nil
+}
+
+// MarkRewarded flips a pending referral to rewarded, once
+func (r *ReferralRepositoryMemory) MarkRewarded(ctx context.Context, referral *domain.Referral) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	model, ok := r.referrals[referral.RefereeID()]
+	if !ok || model.Status != string(domain.ReferralPending) {
+		return false, nil
+	}
+	model.Status = string(referral.Status())
+	model.RewardPoints = referral.RewardPoints()
+	model.RewardedAt = referral.RewardedAt()
+	r.referrals[model.RefereeID] = model
+	return true, nil
+}
+
+// CountByReferrerSince counts referrals a referrer made since a time
+func (r *ReferralRepositoryMemory) CountByReferrerSince(ctx context.Context, referrerID string, since time.Time) (int, error) {
+	return r.count(func(m *ReferralModel) bool {
+		return m.ReferrerID == referrerID && !m.CreatedAt.Before(since)
+	}), nil
+}
+
+// CountByIPSince counts referrals signed up from an IP since a time
+func (r *ReferralRepositoryMemory) CountByIPSince(ctx context.Context, ip string, since time.Time) (int, error) {
+	return r.count(func(m *ReferralModel) bool {
+		return m.IPAddress == ip && !m.CreatedAt.Before(since)
+	}), nil
+}
+
+// SummaryByReferrer counts a referrer's referrals by status
+func (r *ReferralRepositoryMemory) SummaryByReferrer(ctx context.Context, referrerID string) (*domain.ReferralSummary, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	summary := &domain.ReferralSummary{}
+	for _, model := range r.referrals {
+		if model.ReferrerID != referrerID {
+			continue
+		}
+		summary.Total++
+		switch domain.ReferralStatus(model.Status) {
+		case domain.ReferralPending:
+			summary.Pending++
+		case domain.ReferralRewarded:
+			summary.Rewarded++
+		}
+		summary.PointsEarned += model.RewardPoints
+	}
+	return summary, nil
+}
+
+// ListByReferrer returns a referrer's most recent referrals (all when limit <= 0)
+func (r *ReferralRepositoryMemory) ListByReferrer(ctx context.Context, referrerID string, limit int) ([]*domain.ReferralEntry, error) {
+	r.mu.RLock()
+	var models []ReferralModel
+	for _, model := range r.referrals {
+		if model.ReferrerID == referrerID {
+			models = append(models, model)
+		}
+	}
+	r.mu.RUnlock()
+
+	slices.SortFunc(models, func(a, b ReferralModel) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.ID, a.ID)
+	})
+	if limit > 0 && len(models) > limit {
+		models = models[:limit]
+	}
+
+	entries := make([]*domain.ReferralEntry, len(models))
+	for i := range models {
+		entries[i] = &domain.ReferralEntry{
+			Referral:        toReferralDomain(&models[i]),
+			RefereeNickname: r.players.Nickname(models[i].RefereeID),
+		}
+	}
+	return entries, nil
+}
+
+// DetachPersonalData clears the signup IP of the player's referral
+func (r *ReferralRepositoryMemory) DetachPersonalData(ctx context.Context, refereeID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if model, ok := r.referrals[refereeID]; ok {
+		model.IPAddress = ""
+		r.referrals[refereeID] = model
+	}
+	return nil
+}
+
+// count counts referrals matching match
+func (r *ReferralRepositoryMemory) count(match func(*ReferralModel) bool) int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	n := 0
+	for _, model := range r.referrals {
+		if match(&model) {
+			n++
+		}
+	}
+	return n
+}
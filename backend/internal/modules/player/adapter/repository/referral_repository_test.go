@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"backend/internal/modules/player/domain"
+	"backend/internal/shared/contracttest"
+)
+
+func TestReferralRecordSpinMemory(t *testing.T) {
+	players := NewPlayerRepositoryMemory(domain.NewPlayerFactory(3, 50))
+	testReferralRecordSpin(t, players, NewReferralRepositoryMemory(players))
+}
+
+func TestReferralRecordSpinSQLite(t *testing.T) {
+	db := contracttest.OpenSQLite(t)
+	testReferralRecordSpin(t, NewPlayerRepositoryGorm(db, domain.NewPlayerFactory(3, 50)), NewReferralRepositoryGorm(db))
+}
+
+func TestReferralRecordSpinPostgres(t *testing.T) {
+	db := openTestDB(t)
+	players := &cleanupPlayerRepo{PlayerRepositoryGorm: NewPlayerRepositoryGorm(db, domain.NewPlayerFactory(3, 50)), t: t, db: db}
+	testReferralRecordSpin(t, players, &cleanupReferralRepo{ReferralRepositoryGorm: NewReferralRepositoryGorm(db), t: t})
+}
+
+// cleanupReferralRepo deletes the referrals a test stores before their players
+type cleanupReferralRepo struct {
+	*ReferralRepositoryGorm
+	t *testing.T
+}
+
+func (r *cleanupReferralRepo) Store(ctx context.Context, referral *domain.Referral) error {
+	if err := r.ReferralRepositoryGorm.Store(ctx, referral); err != nil {
+		return err
+	}
+	r.t.Cleanup(func() {
+		r.db.Where("id = ?", referral.ID()).Delete(&ReferralModel{})
+	})
+	return nil
+}
+
+// testReferralRecordSpin checks that concurrent referee spins are all counted
+// and that a rewarded referral stops counting
+func testReferralRecordSpin(t *testing.T, players domain.PlayerRepository, referrals domain.ReferralRepository) {
+	ctx := context.Background()
+	factory := domain.NewPlayerFactory(3, 50)
+	referrer, _ := factory.CreateNewPlayer(contracttest.UniqueName("ref-"))
+	referee, _ := factory.CreateNewPlayer(contracttest.UniqueName("ree-"))
+	for _, p := range []*domain.Player{referrer, referee} {
+		if err := players.Store(ctx, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	referral, err := domain.NewReferral(referrer, referee)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := referrals.Store(ctx, referral); err != nil {
+		t.Fatal(err)
+	}
+	refereeID := referee.ID().String()
+
+	const spins = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, spins)
+	for i := 1; i <= spins; i++ {
+		wg.Add(1)
+		go func(total int) {
+			defer wg.Done()
+			if _, err := referrals.RecordSpin(ctx, refereeID, total); err != nil {
+				errs <- err
+			}
+		}(i * 10)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("record spin: %v", err)
+	}
+
+	stored, err := referrals.FindByReferee(ctx, refereeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.RefereeSpins() != spins || stored.RefereePoints() != spins*10 {
+		t.Fatalf("recorded %d spins, %d points; want %d, %d", stored.RefereeSpins(), stored.RefereePoints(), spins, spins*10)
+	}
+
+	if err := stored.MarkRewarded(100, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if marked, err := referrals.MarkRewarded(ctx, stored); err != nil || !marked {
+		t.Fatalf("mark rewarded = %v, %v; want true", marked, err)
+	}
+	if _, err := referrals.RecordSpin(ctx, refereeID, 500); !errors.Is(err, domain.ErrReferralNotFound) {
+		t.Fatalf("record spin after reward: err = %v, want %v", err, domain.ErrReferralNotFound)
+	}
+}
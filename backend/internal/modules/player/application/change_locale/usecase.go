@@ -0,0 +1,72 @@
+package change_locale
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"backend/internal/modules/player/application"
+	"backend/internal/modules/player/domain"
+	shared "backend/internal/shared/domain"
+	"backend/internal/shared/i18n"
+	"backend/internal/shared/logging"
+	"backend/internal/shared/tracing"
+)
+
+// UseCase sets the locale a player's responses are rendered in
+type UseCase struct {
+	playerRepo domain.PlayerRepository
+	bundle     *i18n.Bundle
+	now        func() time.Time
+}
+
+func New(repo domain.PlayerRepository, bundle *i18n.Bundle) *UseCase {
+	return &UseCase{
+		playerRepo: repo,
+		bundle:     bundle,
+		now:        time.Now,
+	}
+}
+
+// Execute stores the player's preferred locale; an empty locale clears it so
+// Accept-Language is used again
+func (uc *UseCase) Execute(ctx context.Context, req application.ChangeLocaleRequest) (_ *application.LocaleResponse, err error) {
+	ctx, span := tracing.Start(ctx, "player.change_locale.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	// Validate request ("th-TH" is stored as "th")
+	playerID, err := domain.NewPlayerID(req.PlayerID)
+	if err != nil {
+		return nil, shared.ErrPlayerNotFound
+	}
+	locale := ""
+	if req.Locale != "" {
+		matched, ok := uc.bundle.Match(req.Locale)
+		if !ok {
+			return nil, shared.NewValidationError(fmt.Sprintf("unsupported locale %q, use one of: %s", req.Locale, strings.Join(uc.bundle.Locales(), ", ")))
+		}
+		locale = matched
+	}
+
+	// Find player (shared.ErrPlayerNotFound when missing)
+	player, err := uc.playerRepo.FindByID(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := player.ChangeLocale(locale, uc.now()); err != nil {
+		return nil, err
+	}
+	if err := uc.playerRepo.Update(ctx, player); err != nil {
+		return nil, err
+	}
+	i18n.Prefer(ctx, locale)
+
+	logging.FromContext(ctx).InfoContext(ctx, "player locale changed", "player_id", req.PlayerID, "locale", locale)
+
+	return &application.LocaleResponse{
+		ID:     player.ID().String(),
+		Locale: player.Locale(),
+	}, nil
+}
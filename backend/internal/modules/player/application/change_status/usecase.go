@@ -0,0 +1,99 @@
+package change_status
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"backend/internal/modules/player/application"
+	"backend/internal/modules/player/domain"
+	shared "backend/internal/shared/domain"
+	"backend/internal/shared/events"
+	"backend/internal/shared/logging"
+	"backend/internal/shared/tracing"
+)
+
+// maxReasonLength bounds the stored reason
+const maxReasonLength = 500
+
+// UseCase handles admin status changes (suspend, ban, reactivate, delete)
+type UseCase struct {
+	playerRepo domain.PlayerRepository
+	publisher  events.Publisher
+	now        func() time.Time
+}
+
+func New(repo domain.PlayerRepository, publisher events.Publisher) *UseCase {
+	return &UseCase{
+		playerRepo: repo,
+		publisher:  publisher,
+		now:        time.Now,
+	}
+}
+
+// Execute moves the player to the requested status
+func (uc *UseCase) Execute(ctx context.Context, req application.ChangeStatusRequest) (_ *application.PlayerStatusResponse, err error) {
+	ctx, span := tracing.Start(ctx, "player.change_status.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	// Validate request
+	playerID, err := domain.NewPlayerID(req.PlayerID)
+	if err != nil {
+		return nil, shared.ErrPlayerNotFound
+	}
+	status, err := domain.ParsePlayerStatus(req.Status)
+	if err != nil {
+		return nil, err
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if len(reason) > maxReasonLength {
+		return nil, fmt.Errorf("%w: reason must be at most %d characters", domain.ErrInvalidStatusChange, maxReasonLength)
+	}
+
+	// Find player (shared.ErrPlayerNotFound when missing)
+	player, err := uc.playerRepo.FindByID(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := uc.now()
+	switch status {
+	case domain.PlayerSuspended:
+		if req.SuspendedUntil == nil {
+			return nil, fmt.Errorf("%w: suspended_until is required", domain.ErrInvalidStatusChange)
+		}
+		err = player.Suspend(*req.SuspendedUntil, reason, now)
+	case domain.PlayerBanned:
+		err = player.Ban(reason, now)
+	case domain.PlayerDeleted:
+		err = player.Delete(reason, now)
+	default:
+		err = player.Reactivate(reason, now)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.playerRepo.Update(ctx, player); err != nil {
+		return nil, err
+	}
+	uc.publisher.Publish(ctx, player.DomainEvents()...)
+	player.ClearEvents()
+
+	logging.FromContext(ctx).InfoContext(ctx, "player status changed",
+		"player_id", req.PlayerID,
+		"status", status,
+		"reason", reason,
+	)
+
+	lifecycle := player.Lifecycle()
+	return &application.PlayerStatusResponse{
+		ID:              player.ID().String(),
+		Nickname:        player.Nickname().String(),
+		Status:          string(lifecycle.Status()),
+		SuspendedUntil:  lifecycle.SuspendedUntil(),
+		Reason:          lifecycle.Reason(),
+		StatusChangedAt: lifecycle.ChangedAt(),
+	}, nil
+}
@@ -4,7 +4,9 @@ import "time"
 
 // EnterRequest is input for enter usecase
 type EnterRequest struct {
-	Nickname string `json:"nickname" validate:"required,min=3,max=50"`
+	Nickname     string `json:"nickname" validate:"required,min=3,max=50"`
+	ReferralCode string `json:"referral_code,omitempty"` // Only used when a new player is created
+	IP           string `json:"-"`                       // Client IP, for referral caps
 }
 
 // EnterResponse is output for enter usecase
@@ -14,6 +16,9 @@ type EnterResponse struct {
 	TotalPoints int       `json:"total_points"`
 	CreatedAt   time.Time `json:"created_at"`
 	IsNew       bool      `json:"is_new,omitempty"` // Only for enter
+	InviteCode  string    `json:"invite_code"`
+	ReferredBy  string    `json:"referred_by,omitempty"` // Referrer ID, only when joining with a referral code
+	Streak      StreakDTO `json:"streak"`
 }
 
 // GetProfileRequest is input for get profile usecase
@@ -23,9 +28,160 @@ type GetProfileRequest struct {
 
 // ProfileResponse includes claimed checkpoints (added in Phase 6)
 type ProfileResponse struct {
-	ID                 string    `json:"id"`
-	Nickname           string    `json:"nickname"`
-	TotalPoints        int       `json:"total_points"`
-	CreatedAt          time.Time `json:"created_at"`
-	ClaimedCheckpoints []int     `json:"claimed_checkpoints"` // Phase 6
+	ID                 string     `json:"id"`
+	Nickname           string     `json:"nickname"`
+	TotalPoints        int        `json:"total_points"`
+	CreatedAt          time.Time  `json:"created_at"`
+	ClaimedCheckpoints []int      `json:"claimed_checkpoints"` // Phase 6
+	InviteCode         string     `json:"invite_code"`
+	Status             string     `json:"status"`
+	SuspendedUntil     *time.Time `json:"suspended_until,omitempty"`
+	Streak             StreakDTO  `json:"streak"`
+	Locale             string     `json:"locale"` // Preferred locale, empty when following Accept-Language
+}
+
+// RenameRequest is input for rename usecase
+type RenameRequest struct {
+	PlayerID string `json:"-"`
+	Nickname string `json:"nickname" validate:"required,min=3,max=50"`
+}
+
+// RenameResponse is output for rename usecase
+type RenameResponse struct {
+	ID                string    `json:"id"`
+	Nickname          string    `json:"nickname"`
+	PreviousNickname  string    `json:"previous_nickname"`
+	RenameAvailableAt time.Time `json:"rename_available_at"` // Next time the nickname can change
+}
+
+// ChangeLocaleRequest is input for change locale usecase
+type ChangeLocaleRequest struct {
+	PlayerID string `json:"-"`
+	Locale   string `json:"locale" example:"th"` // Empty clears the preference
+}
+
+// LocaleResponse is output for change locale usecase
+type LocaleResponse struct {
+	ID     string `json:"id"`
+	Locale string `json:"locale"`
+}
+
+// ChangeStatusRequest is input for the admin change status usecase
+type ChangeStatusRequest struct {
+	PlayerID       string     `json:"-"`
+	Status         string     `json:"status" example:"suspended"` // active, suspended, banned or deleted
+	Reason         string     `json:"reason" example:"Abusive nickname"`
+	SuspendedUntil *time.Time `json:"suspended_until,omitempty"` // Required for suspended
+}
+
+// PlayerStatusResponse is a player's account status
+type PlayerStatusResponse struct {
+	ID              string     `json:"id"`
+	Nickname        string     `json:"nickname"`
+	Status          string     `json:"status"`
+	SuspendedUntil  *time.Time `json:"suspended_until,omitempty"`
+	Reason          string     `json:"reason"`
+	StatusChangedAt *time.Time `json:"status_changed_at"`
+}
+
+// StreakDTO is the login streak and the boosts streak rewards granted
+type StreakDTO struct {
+	Current             int               `json:"current"`
+	Longest             int               `json:"longest"`
+	Freezes             int               `json:"freezes"`
+	BonusSpins          int               `json:"bonus_spins"`
+	PointsMultiplier    float64           `json:"points_multiplier"`
+	MultiplierExpiresAt *time.Time        `json:"multiplier_expires_at,omitempty"`
+	RewardsGranted      []StreakRewardDTO `json:"rewards_granted,omitempty"` // Only for enter
+}
+
+// StreakRewardDTO is a reward granted for reaching a streak day
+type StreakRewardDTO struct {
+	Day        int     `json:"day"`
+	Type       string  `json:"type"`
+	Amount     int     `json:"amount,omitempty"`
+	Multiplier float64 `json:"multiplier,omitempty"`
+	Duration   string  `json:"duration,omitempty"`
+}
+
+// GetStatsRequest is input for get stats usecase
+type GetStatsRequest struct {
+	PlayerID string `json:"player_id"`
+}
+
+// StatsResponse aggregates a player's spins and reward progress
+type StatsResponse struct {
+	PlayerID             string             `json:"player_id"`
+	TotalSpins           int                `json:"total_spins"`
+	Today                PeriodStatsDTO     `json:"today"`
+	ThisWeek             PeriodStatsDTO     `json:"this_week"` // since the start of Monday, at the streak day boundary
+	AllTime              PeriodStatsDTO     `json:"all_time"`
+	AveragePointsPerSpin float64            `json:"average_points_per_spin"`
+	Distribution         []OutcomeDTO       `json:"distribution"`
+	Streaks              StreaksDTO         `json:"streaks"`
+	FirstSpinAt          *time.Time         `json:"first_spin_at"`
+	LastSpinAt           *time.Time         `json:"last_spin_at"`
+	NextCheckpoint       *NextCheckpointDTO `json:"next_checkpoint"` // null when every checkpoint is claimed
+}
+
+// PeriodStatsDTO is spins and points within a period
+type PeriodStatsDTO struct {
+	Spins  int `json:"spins"`
+	Points int `json:"points"`
+}
+
+// OutcomeDTO is how often the player hit an outcome
+type OutcomeDTO struct {
+	Points     int     `json:"points"`
+	Count      int     `json:"count"`
+	Percentage float64 `json:"percentage"`
+}
+
+// StreaksDTO counts consecutive days (split like login streaks) with at least one spin
+type StreaksDTO struct {
+	Current int `json:"current"`
+	Best    int `json:"best"`
+}
+
+// NextCheckpointDTO is the lowest checkpoint the player has not claimed
+type NextCheckpointDTO struct {
+	CheckpointVal int    `json:"checkpoint_val"`
+	RewardName    string `json:"reward_name"`
+	PointsNeeded  int    `json:"points_needed"` // 0 when it can be claimed now
+}
+
+// GetReferralsRequest is input for get referrals usecase
+type GetReferralsRequest struct {
+	PlayerID string `json:"player_id"`
+}
+
+// ReferralsResponse summarises the players a player referred
+type ReferralsResponse struct {
+	PlayerID     string               `json:"player_id"`
+	InviteCode   string               `json:"invite_code"`
+	Milestone    ReferralMilestoneDTO `json:"milestone"`
+	RewardPoints int                  `json:"reward_points"` // Paid per referee reaching the milestone
+	Total        int                  `json:"total"`
+	Pending      int                  `json:"pending"`
+	Rewarded     int                  `json:"rewarded"`
+	PointsEarned int                  `json:"points_earned"`
+	Referrals    []ReferralDTO        `json:"referrals"` // Most recent first
+}
+
+// ReferralMilestoneDTO is what a referee must reach (whichever comes first; 0 = not used)
+type ReferralMilestoneDTO struct {
+	Spins  int `json:"spins"`
+	Points int `json:"points"`
+}
+
+// ReferralDTO is one referred player and their progress
+type ReferralDTO struct {
+	RefereeID       string     `json:"referee_id"`
+	RefereeNickname string     `json:"referee_nickname"`
+	Status          string     `json:"status"`
+	Spins           int        `json:"spins"`
+	Points          int        `json:"points"`
+	RewardPoints    int        `json:"reward_points"`
+	JoinedAt        time.Time  `json:"joined_at"`
+	RewardedAt      *time.Time `json:"rewarded_at,omitempty"`
 }
@@ -1,52 +1,97 @@
 package enter
 
 import (
+	historydomain "backend/internal/modules/history/domain"
 	"backend/internal/modules/player/application"
 	"backend/internal/modules/player/domain"
+	"backend/internal/shared/constants"
 	shared "backend/internal/shared/domain"
+	"backend/internal/shared/events"
+	"backend/internal/shared/i18n"
+	"backend/internal/shared/logging"
+	"backend/internal/shared/retry"
+	"backend/internal/shared/tracing"
 	"context"
 	"errors"
+	"fmt"
+	"time"
 )
 
 // UseCase handles player enter/resume
 type UseCase struct {
-	playerRepo    domain.PlayerRepository
-	playerFactory *domain.PlayerFactory
+	playerRepo     domain.PlayerRepository
+	playerFactory  *domain.PlayerFactory
+	streakPolicy   *domain.StreakPolicy
+	referralRepo   domain.ReferralRepository
+	referralPolicy *domain.ReferralPolicy
+	spinLogRepo    historydomain.SpinLogRepository // records points rewards; may be nil
+	tx             shared.Transactor
+	publisher      events.Publisher
+	retry          retry.Policy
+	now            func() time.Time
 }
 
-func New(repo domain.PlayerRepository, factory *domain.PlayerFactory) *UseCase {
+func New(
+	repo domain.PlayerRepository,
+	factory *domain.PlayerFactory,
+	streakPolicy *domain.StreakPolicy,
+	referralRepo domain.ReferralRepository,
+	referralPolicy *domain.ReferralPolicy,
+	spinLogRepo historydomain.SpinLogRepository,
+	tx shared.Transactor,
+	publisher events.Publisher,
+	retryPolicy retry.Policy,
+) *UseCase {
 	return &UseCase{
-		playerRepo:    repo,
-		playerFactory: factory,
+		playerRepo:     repo,
+		playerFactory:  factory,
+		streakPolicy:   streakPolicy,
+		referralRepo:   referralRepo,
+		referralPolicy: referralPolicy,
+		spinLogRepo:    spinLogRepo,
+		tx:             tx,
+		publisher:      publisher,
+		retry:          retryPolicy,
+		now:            time.Now,
 	}
 }
 
 // Execute enters existing player or creates new one
-func (uc *UseCase) Execute(ctx context.Context, req application.EnterRequest) (*application.EnterResponse, error) {
+func (uc *UseCase) Execute(ctx context.Context, req application.EnterRequest) (_ *application.EnterResponse, err error) {
+	ctx, span := tracing.Start(ctx, "player.enter.Execute")
+	defer func() { tracing.End(span, err) }()
+
 	// Validate request
 	if req.Nickname == "" {
-		return nil, errors.New("nickname is required")
+		return nil, shared.NewValidationError("nickname is required")
 	}
 
 	// Create nickname VO for searching
 	nicknameVO, err := domain.NewNickname(req.Nickname, uc.playerFactory.NicknameMinLen, uc.playerFactory.NicknameMaxLen)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %v", shared.ErrInvalidNickname, err)
 	}
 
-	// Try to find existing player by nickname
-	existingPlayer, err := uc.playerRepo.FindByNickname(ctx, nicknameVO)
-	if err != nil && !errors.Is(err, shared.ErrPlayerNotFound) {
+	now := uc.now()
+
+	// Resume the existing player, reloading when a concurrent update wins
+	var existingPlayer *domain.Player
+	var granted []domain.StreakReward
+	err = uc.retry.Do(ctx, func(ctx context.Context) error {
+		var err error
+		existingPlayer, granted, err = uc.resume(ctx, nicknameVO, now)
+		return err
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	// If player exists, return existing player
 	if existingPlayer != nil {
-		existingPlayer.Enter() // Record entry
-		err = uc.playerRepo.Update(ctx, existingPlayer)
-		if err != nil {
-			return nil, err
-		}
+		uc.publisher.Publish(ctx, existingPlayer.DomainEvents()...)
+		existingPlayer.ClearEvents()
+
+		logging.FromContext(ctx).InfoContext(ctx, "player entered", "player_id", existingPlayer.ID().String())
 
 		return &application.EnterResponse{
 			ID:          existingPlayer.ID().String(),
@@ -54,25 +99,177 @@ func (uc *UseCase) Execute(ctx context.Context, req application.EnterRequest) (*
 			TotalPoints: existingPlayer.TotalPoints().Value(),
 			CreatedAt:   existingPlayer.CreatedAt(),
 			IsNew:       false,
+			InviteCode:  existingPlayer.InviteCode().String(),
+			Streak:      application.NewStreakDTO(existingPlayer, uc.streakPolicy, now, granted),
 		}, nil
 	}
 
+	// Resolve the referral code first, so a bad code creates no player
+	referrer, err := uc.resolveReferrer(ctx, req.ReferralCode, req.IP, now)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create new player
 	newPlayer, err := uc.playerFactory.CreateNewPlayer(req.Nickname)
 	if err != nil {
 		return nil, err
 	}
+	newPlayer.RecordSignupIP(req.IP)
 
-	err = uc.playerRepo.Store(ctx, newPlayer)
+	var referral *domain.Referral
+	if referrer != nil {
+		referral, err = domain.NewReferral(referrer, newPlayer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The first entry is day one of the streak
+	granted, err = newPlayer.RecordLogin(now, uc.streakPolicy)
 	if err != nil {
 		return nil, err
 	}
 
-	return &application.EnterResponse{
+	// The player, its rewards and its referral are stored together, so a
+	// failed referral leaves no unreferred player behind
+	err = uc.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.playerRepo.Store(ctx, newPlayer); err != nil {
+			return err
+		}
+		if err := uc.logPointsRewards(ctx, newPlayer, granted); err != nil {
+			return err
+		}
+		if referral != nil {
+			return uc.referralRepo.Store(ctx, referral)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.publisher.Publish(ctx, newPlayer.DomainEvents()...)
+	newPlayer.ClearEvents()
+
+	resp := &application.EnterResponse{
 		ID:          newPlayer.ID().String(),
 		Nickname:    newPlayer.Nickname().String(),
 		TotalPoints: newPlayer.TotalPoints().Value(),
 		CreatedAt:   newPlayer.CreatedAt(),
 		IsNew:       true,
-	}, nil
+		InviteCode:  newPlayer.InviteCode().String(),
+		Streak:      application.NewStreakDTO(newPlayer, uc.streakPolicy, now, granted),
+	}
+
+	if referral != nil {
+		uc.publisher.Publish(ctx, referral.DomainEvents()...)
+		referral.ClearEvents()
+		resp.ReferredBy = referral.ReferrerID()
+	}
+
+	logging.FromContext(ctx).InfoContext(ctx, "player created", "player_id", newPlayer.ID().String(), "referred_by", resp.ReferredBy)
+
+	return resp, nil
+}
+
+// resume records an existing player's entry and login, with the points its
+// streak rewards grant logged in the same transaction; it returns a nil
+// player when nobody has the nickname
+func (uc *UseCase) resume(ctx context.Context, nickname *domain.Nickname, now time.Time) (player *domain.Player, granted []domain.StreakReward, err error) {
+	err = uc.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		player, err = uc.playerRepo.FindByNickname(ctx, nickname)
+		if err != nil {
+			if errors.Is(err, shared.ErrPlayerNotFound) {
+				player = nil
+				return nil
+			}
+			return err
+		}
+		i18n.Prefer(ctx, player.Locale())
+
+		// Suspended, banned and deleted players cannot enter
+		if err := player.CheckActive(now); err != nil {
+			return err
+		}
+
+		player.Enter() // Record entry
+		granted, err = player.RecordLogin(now, uc.streakPolicy)
+		if err != nil {
+			return err
+		}
+		if err := uc.playerRepo.Update(ctx, player); err != nil {
+			return err
+		}
+		return uc.logPointsRewards(ctx, player, granted)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return player, granted, nil
+}
+
+// resolveReferrer finds the owner of a referral code and enforces the daily
+// referral caps. It returns nil when no code was given.
+func (uc *UseCase) resolveReferrer(ctx context.Context, code, ip string, now time.Time) (*domain.Player, error) {
+	if code == "" || uc.referralRepo == nil {
+		return nil, nil
+	}
+
+	inviteCode, err := domain.NewInviteCode(code)
+	if err != nil {
+		return nil, err
+	}
+	referrer, err := uc.playerRepo.FindByInviteCode(ctx, inviteCode)
+	if err != nil {
+		if errors.Is(err, shared.ErrPlayerNotFound) {
+			return nil, domain.ErrInvalidReferralCode
+		}
+		return nil, err
+	}
+	if referrer.CheckActive(now) != nil {
+		return nil, domain.ErrInvalidReferralCode
+	}
+
+	since := now.Add(-24 * time.Hour)
+	if limit := uc.referralPolicy.MaxPerReferrerPerDay; limit > 0 {
+		count, err := uc.referralRepo.CountByReferrerSince(ctx, referrer.ID().String(), since)
+		if err != nil {
+			return nil, err
+		}
+		if count >= limit {
+			return nil, domain.ErrReferralLimitReached
+		}
+	}
+	if limit := uc.referralPolicy.MaxPerIPPerDay; limit > 0 && ip != "" {
+		count, err := uc.referralRepo.CountByIPSince(ctx, ip, since)
+		if err != nil {
+			return nil, err
+		}
+		if count >= limit {
+			return nil, domain.ErrReferralLimitReached
+		}
+	}
+
+	return referrer, nil
+}
+
+// logPointsRewards records points granted by streak rewards as BONUS spin logs,
+// so history and total points stay in step
+func (uc *UseCase) logPointsRewards(ctx context.Context, player *domain.Player, granted []domain.StreakReward) error {
+	if uc.spinLogRepo == nil {
+		return nil
+	}
+	for _, reward := range granted {
+		if reward.Kind != domain.StreakRewardPoints {
+			continue
+		}
+		spinLog, err := historydomain.NewSpinLog(player.ID().String(), reward.Amount, constants.SpinSourceBonus)
+		if err != nil {
+			return err
+		}
+		if err := uc.spinLogRepo.Store(ctx, spinLog); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -0,0 +1,146 @@
+package enter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"backend/internal/infrastructure/database"
+	historydomain "backend/internal/modules/history/domain"
+	"backend/internal/modules/player/adapter/repository"
+	"backend/internal/modules/player/application"
+	"backend/internal/modules/player/domain"
+	"backend/internal/shared/contracttest"
+	shared "backend/internal/shared/domain"
+	"backend/internal/shared/retry"
+)
+
+type noopPublisher struct{}
+
+func (noopPublisher) Publish(ctx context.Context, events ...shared.DomainEvent) {}
+
+var errDown = errors.New("database unavailable")
+
+// failingReferralRepo fails every store
+type failingReferralRepo struct {
+	*repository.ReferralRepositoryGorm
+}
+
+func (failingReferralRepo) Store(ctx context.Context, referral *domain.Referral) error {
+	return errDown
+}
+
+type fixture struct {
+	players   *repository.PlayerRepositoryMemory
+	referrals *repository.ReferralRepositoryMemory
+	uc        *UseCase
+}
+
+func newFixture() *fixture {
+	factory := domain.NewPlayerFactory(3, 50)
+	players := repository.NewPlayerRepositoryMemory(factory)
+	referrals := repository.NewReferralRepositoryMemory(players)
+	streaks := &domain.StreakPolicy{Boundary: domain.DayBoundary{Location: time.UTC}}
+	uc := New(players, factory, streaks, referrals, &domain.ReferralPolicy{}, nil, database.NoTransactor{}, noopPublisher{}, retry.Policy{MaxAttempts: 1})
+	return &fixture{players: players, referrals: referrals, uc: uc}
+}
+
+func (f *fixture) enter(t *testing.T, nickname, code, ip string) (*application.EnterResponse, error) {
+	t.Helper()
+	return f.uc.Execute(context.Background(), application.EnterRequest{Nickname: nickname, ReferralCode: code, IP: ip})
+}
+
+func TestEnterRefusesReferralFromReferrersSignupIP(t *testing.T) {
+	f := newFixture()
+	referrer, err := f.enter(t, "alice", "", "203.0.113.7")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A second account from the referrer's network is a self-referral
+	_, err = f.enter(t, "alice2", referrer.InviteCode, "203.0.113.7")
+	if !errors.Is(err, domain.ErrSelfReferral) {
+		t.Fatalf("err = %v, want %v", err, domain.ErrSelfReferral)
+	}
+	nickname, _ := domain.NewNickname("alice2", 3, 50)
+	if _, err := f.players.FindByNickname(context.Background(), nickname); !errors.Is(err, shared.ErrPlayerNotFound) {
+		t.Errorf("refused player was stored (err = %v)", err)
+	}
+
+	// Another network is a genuine referral
+	referee, err := f.enter(t, "bob", referrer.InviteCode, "198.51.100.4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if referee.ReferredBy != referrer.ID {
+		t.Errorf("referred by = %q, want %q", referee.ReferredBy, referrer.ID)
+	}
+}
+
+func TestEnterStoresNoPlayerWhenReferralFails(t *testing.T) {
+	db := contracttest.OpenSQLite(t)
+	ctx := context.Background()
+	factory := domain.NewPlayerFactory(3, 50)
+	players := repository.NewPlayerRepositoryGorm(db, factory)
+	referrals := failingReferralRepo{repository.NewReferralRepositoryGorm(db)}
+	streaks := &domain.StreakPolicy{Boundary: domain.DayBoundary{Location: time.UTC}}
+	uc := New(players, factory, streaks, referrals, &domain.ReferralPolicy{}, nil, database.NewTransactor(db), noopPublisher{}, retry.Policy{MaxAttempts: 1})
+
+	referrer, err := uc.Execute(ctx, application.EnterRequest{Nickname: "alice", IP: "203.0.113.7"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = uc.Execute(ctx, application.EnterRequest{Nickname: "bob", ReferralCode: referrer.InviteCode, IP: "198.51.100.4"})
+	if !errors.Is(err, errDown) {
+		t.Fatalf("err = %v, want %v", err, errDown)
+	}
+
+	// The player is rolled back with the referral, so bob can retry with the code
+	nickname, _ := domain.NewNickname("bob", 3, 50)
+	if _, err := players.FindByNickname(ctx, nickname); !errors.Is(err, shared.ErrPlayerNotFound) {
+		t.Fatalf("player without its referral was stored (err = %v)", err)
+	}
+}
+
+// failingSpinLogRepo fails every store
+type failingSpinLogRepo struct {
+	historydomain.SpinLogRepository
+}
+
+func (failingSpinLogRepo) Store(ctx context.Context, spinLog *historydomain.SpinLog) error {
+	return errDown
+}
+
+func TestResumeKeepsNoRewardWhenItsLogFails(t *testing.T) {
+	db := contracttest.OpenSQLite(t)
+	ctx := context.Background()
+	factory := domain.NewPlayerFactory(3, 50)
+	players := repository.NewPlayerRepositoryGorm(db, factory)
+	streaks := &domain.StreakPolicy{
+		Boundary: domain.DayBoundary{Location: time.UTC},
+		Rewards:  []domain.StreakReward{{Day: 2, Kind: domain.StreakRewardPoints, Amount: 10}},
+	}
+	uc := New(players, factory, streaks, nil, &domain.ReferralPolicy{}, failingSpinLogRepo{}, database.NewTransactor(db), noopPublisher{}, retry.Policy{MaxAttempts: 1})
+
+	day := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	uc.now = func() time.Time { return day }
+	entered, err := uc.Execute(ctx, application.EnterRequest{Nickname: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Day two grants points, but logging them fails: the login is rolled back
+	uc.now = func() time.Time { return day.AddDate(0, 0, 1) }
+	if _, err := uc.Execute(ctx, application.EnterRequest{Nickname: "alice"}); !errors.Is(err, errDown) {
+		t.Fatalf("err = %v, want %v", err, errDown)
+	}
+	id, _ := domain.NewPlayerID(entered.ID)
+	stored, err := players.FindByID(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.TotalPoints().Value() != 0 || stored.Version() != 1 {
+		t.Fatalf("player has %d points at v%d, want 0 at v1", stored.TotalPoints().Value(), stored.Version())
+	}
+}
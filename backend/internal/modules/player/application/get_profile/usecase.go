@@ -5,44 +5,49 @@ import (
 	"backend/internal/modules/player/domain"
 	rewarddomain "backend/internal/modules/reward/domain"
 	shared "backend/internal/shared/domain"
+	"backend/internal/shared/i18n"
+	"backend/internal/shared/tracing"
 	"context"
-	"errors"
+	"time"
 )
 
 // UseCase handles get player profile
 type UseCase struct {
 	playerRepo   domain.PlayerRepository
 	rewardTxRepo interface{}
+	streakPolicy *domain.StreakPolicy
 }
 
-func New(repo domain.PlayerRepository, rewardTxRepo interface{}) *UseCase {
+func New(repo domain.PlayerRepository, rewardTxRepo interface{}, streakPolicy *domain.StreakPolicy) *UseCase {
 	return &UseCase{
 		playerRepo:   repo,
 		rewardTxRepo: rewardTxRepo,
+		streakPolicy: streakPolicy,
 	}
 }
 
 // Execute retrieves player profile
-func (uc *UseCase) Execute(ctx context.Context, req application.GetProfileRequest) (*application.ProfileResponse, error) {
+func (uc *UseCase) Execute(ctx context.Context, req application.GetProfileRequest) (_ *application.ProfileResponse, err error) {
+	ctx, span := tracing.Start(ctx, "player.get_profile.Execute")
+	defer func() { tracing.End(span, err) }()
+
 	// Validate request
 	if req.PlayerID == "" {
-		return nil, errors.New("player ID is required")
+		return nil, shared.NewValidationError("player ID is required")
 	}
 
 	// Parse player ID
 	playerID, err := domain.NewPlayerID(req.PlayerID)
 	if err != nil {
-		return nil, err
+		return nil, shared.ErrPlayerNotFound
 	}
 
-	// Find player
+	// Find player (shared.ErrPlayerNotFound when missing)
 	player, err := uc.playerRepo.FindByID(ctx, playerID)
 	if err != nil {
-		if errors.Is(err, shared.ErrPlayerNotFound) {
-			return nil, shared.ErrPlayerNotFound
-		}
 		return nil, err
 	}
+	i18n.Prefer(ctx, player.Locale())
 
 	// Get claimed checkpoints
 	claimedCheckpoints := []int{}
@@ -54,11 +59,23 @@ func (uc *UseCase) Execute(ctx context.Context, req application.GetProfileReques
 		}
 	}
 
+	now := time.Now()
+	lifecycle := player.Lifecycle()
+	var suspendedUntil *time.Time
+	if lifecycle.StatusAt(now) == domain.PlayerSuspended {
+		suspendedUntil = lifecycle.SuspendedUntil()
+	}
+
 	return &application.ProfileResponse{
 		ID:                 player.ID().String(),
 		Nickname:           player.Nickname().String(),
 		TotalPoints:        player.TotalPoints().Value(),
 		CreatedAt:          player.CreatedAt(),
 		ClaimedCheckpoints: claimedCheckpoints,
+		InviteCode:         player.InviteCode().String(),
+		Status:             string(lifecycle.StatusAt(now)),
+		SuspendedUntil:     suspendedUntil,
+		Streak:             application.NewStreakDTO(player, uc.streakPolicy, now, nil),
+		Locale:             player.Locale(),
 	}, nil
 }
@@ -0,0 +1,89 @@
+package get_referrals
+
+import (
+	"context"
+
+	"backend/internal/modules/player/application"
+	"backend/internal/modules/player/domain"
+	shared "backend/internal/shared/domain"
+	"backend/internal/shared/i18n"
+	"backend/internal/shared/tracing"
+)
+
+// UseCase handles get referral summary
+type UseCase struct {
+	playerRepo   domain.PlayerRepository
+	referralRepo domain.ReferralRepository
+	policy       *domain.ReferralPolicy
+	limit        int
+}
+
+func New(repo domain.PlayerRepository, referralRepo domain.ReferralRepository, policy *domain.ReferralPolicy, limit int) *UseCase {
+	return &UseCase{
+		playerRepo:   repo,
+		referralRepo: referralRepo,
+		policy:       policy,
+		limit:        limit,
+	}
+}
+
+// Execute summarises the players the player referred
+func (uc *UseCase) Execute(ctx context.Context, req application.GetReferralsRequest) (_ *application.ReferralsResponse, err error) {
+	ctx, span := tracing.Start(ctx, "player.get_referrals.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	// Validate request
+	if req.PlayerID == "" {
+		return nil, shared.NewValidationError("player ID is required")
+	}
+	playerID, err := domain.NewPlayerID(req.PlayerID)
+	if err != nil {
+		return nil, shared.ErrPlayerNotFound
+	}
+
+	// Find player (shared.ErrPlayerNotFound when missing)
+	player, err := uc.playerRepo.FindByID(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+	i18n.Prefer(ctx, player.Locale())
+
+	summary, err := uc.referralRepo.SummaryByReferrer(ctx, req.PlayerID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := uc.referralRepo.ListByReferrer(ctx, req.PlayerID, uc.limit)
+	if err != nil {
+		return nil, err
+	}
+
+	referrals := make([]application.ReferralDTO, len(entries))
+	for i, entry := range entries {
+		r := entry.Referral
+		referrals[i] = application.ReferralDTO{
+			RefereeID:       r.RefereeID(),
+			RefereeNickname: entry.RefereeNickname,
+			Status:          string(r.Status()),
+			Spins:           r.RefereeSpins(),
+			Points:          r.RefereePoints(),
+			RewardPoints:    r.RewardPoints(),
+			JoinedAt:        r.CreatedAt(),
+			RewardedAt:      r.RewardedAt(),
+		}
+	}
+
+	return &application.ReferralsResponse{
+		PlayerID:   player.ID().String(),
+		InviteCode: player.InviteCode().String(),
+		Milestone: application.ReferralMilestoneDTO{
+			Spins:  uc.policy.Milestone.Spins,
+			Points: uc.policy.Milestone.Points,
+		},
+		RewardPoints: uc.policy.RewardPoints,
+		Total:        summary.Total,
+		Pending:      summary.Pending,
+		Rewarded:     summary.Rewarded,
+		PointsEarned: summary.PointsEarned,
+		Referrals:    referrals,
+	}, nil
+}
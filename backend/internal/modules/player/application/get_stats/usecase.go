@@ -0,0 +1,131 @@
+package get_stats
+
+import (
+	"context"
+	"math"
+	"slices"
+	"time"
+
+	historydomain "backend/internal/modules/history/domain"
+	"backend/internal/modules/player/application"
+	"backend/internal/modules/player/domain"
+	rewarddomain "backend/internal/modules/reward/domain"
+	shared "backend/internal/shared/domain"
+	"backend/internal/shared/i18n"
+	"backend/internal/shared/tracing"
+)
+
+// UseCase handles get player stats
+type UseCase struct {
+	playerRepo       domain.PlayerRepository
+	spinLogRepo      historydomain.SpinLogRepository
+	rewardTxRepo     rewarddomain.RewardTransactionRepository
+	rewardConfigRepo rewarddomain.RewardConfigRepository
+	days             domain.DayBoundary
+	now              func() time.Time
+}
+
+func New(
+	repo domain.PlayerRepository,
+	spinLogRepo historydomain.SpinLogRepository,
+	rewardTxRepo rewarddomain.RewardTransactionRepository,
+	rewardConfigRepo rewarddomain.RewardConfigRepository,
+	days domain.DayBoundary,
+) *UseCase {
+	return &UseCase{
+		playerRepo:       repo,
+		spinLogRepo:      spinLogRepo,
+		rewardTxRepo:     rewardTxRepo,
+		rewardConfigRepo: rewardConfigRepo,
+		days:             days,
+		now:              time.Now,
+	}
+}
+
+// Execute aggregates the player's spins and finds the next checkpoint
+func (uc *UseCase) Execute(ctx context.Context, req application.GetStatsRequest) (_ *application.StatsResponse, err error) {
+	ctx, span := tracing.Start(ctx, "player.get_stats.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	// Validate request
+	if req.PlayerID == "" {
+		return nil, shared.NewValidationError("player ID is required")
+	}
+	playerID, err := domain.NewPlayerID(req.PlayerID)
+	if err != nil {
+		return nil, shared.ErrPlayerNotFound
+	}
+
+	// Find player (shared.ErrPlayerNotFound when missing)
+	player, err := uc.playerRepo.FindByID(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+	i18n.Prefer(ctx, player.Locale())
+
+	// Aggregate spins, with days split like the login streak's
+	stats, err := uc.spinLogRepo.StatsByPlayer(ctx, req.PlayerID, uc.now(), uc.days)
+	if err != nil {
+		return nil, err
+	}
+
+	distribution := make([]application.OutcomeDTO, len(stats.Distribution))
+	for i, outcome := range stats.Distribution {
+		distribution[i] = application.OutcomeDTO{
+			Points:     outcome.Points,
+			Count:      outcome.Count,
+			Percentage: round2(100 * float64(outcome.Count) / float64(stats.TotalSpins)),
+		}
+	}
+
+	// Next checkpoint is measured against the player's balance, which claims use
+	next, err := uc.nextCheckpoint(ctx, req.PlayerID, player.TotalPoints().Value())
+	if err != nil {
+		return nil, err
+	}
+
+	return &application.StatsResponse{
+		PlayerID:             player.ID().String(),
+		TotalSpins:           stats.TotalSpins,
+		Today:                application.PeriodStatsDTO{Spins: stats.SpinsToday, Points: stats.PointsToday},
+		ThisWeek:             application.PeriodStatsDTO{Spins: stats.SpinsThisWeek, Points: stats.PointsThisWeek},
+		AllTime:              application.PeriodStatsDTO{Spins: stats.TotalSpins, Points: stats.TotalPoints},
+		AveragePointsPerSpin: round2(stats.AveragePoints()),
+		Distribution:         distribution,
+		Streaks:              application.StreaksDTO{Current: stats.CurrentStreak, Best: stats.BestStreak},
+		FirstSpinAt:          stats.FirstSpinAt,
+		LastSpinAt:           stats.LastSpinAt,
+		NextCheckpoint:       next,
+	}, nil
+}
+
+// nextCheckpoint returns the lowest unclaimed checkpoint, or nil when all are claimed
+func (uc *UseCase) nextCheckpoint(ctx context.Context, playerID string, totalPoints int) (*application.NextCheckpointDTO, error) {
+	configs, err := uc.rewardConfigRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	claimed, err := uc.rewardTxRepo.GetClaimedCheckpoints(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(configs, func(a, b *rewarddomain.RewardConfig) int {
+		return a.CheckpointVal() - b.CheckpointVal()
+	})
+	for _, cfg := range configs {
+		if slices.Contains(claimed, cfg.CheckpointVal()) {
+			continue
+		}
+		return &application.NextCheckpointDTO{
+			CheckpointVal: cfg.CheckpointVal(),
+			RewardName:    i18n.Translate(ctx, cfg.NameTranslations(), cfg.RewardName()),
+			PointsNeeded:  max(0, cfg.CheckpointVal()-totalPoints),
+		}, nil
+	}
+	return nil, nil
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,55 @@
+package release_nicknames
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"backend/internal/modules/player/domain"
+)
+
+// UseCase frees the nicknames of players deleted longer than the grace period ago
+type UseCase struct {
+	playerRepo  domain.PlayerRepository
+	gracePeriod time.Duration
+	now         func() time.Time
+}
+
+func New(repo domain.PlayerRepository, gracePeriod time.Duration) *UseCase {
+	return &UseCase{
+		playerRepo:  repo,
+		gracePeriod: gracePeriod,
+		now:         time.Now,
+	}
+}
+
+// Execute releases every nickname past the grace period
+func (uc *UseCase) Execute(ctx context.Context) (int, error) {
+	return uc.playerRepo.ReleaseNicknames(ctx, uc.now().Add(-uc.gracePeriod))
+}
+
+// Run releases due nicknames every interval until ctx is cancelled
+func (uc *UseCase) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			released, err := uc.Execute(ctx)
+			if err != nil {
+				slog.WarnContext(ctx, "failed to release nicknames", "error", err)
+				continue
+			}
+			if released > 0 {
+				slog.InfoContext(ctx, "released nicknames of deleted players", "count", released)
+			}
+		}
+	}
+}
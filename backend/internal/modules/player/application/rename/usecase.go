@@ -0,0 +1,86 @@
+package rename
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"backend/internal/modules/player/application"
+	"backend/internal/modules/player/domain"
+	shared "backend/internal/shared/domain"
+	"backend/internal/shared/events"
+	"backend/internal/shared/i18n"
+	"backend/internal/shared/logging"
+	"backend/internal/shared/tracing"
+)
+
+// UseCase handles player-initiated nickname changes
+type UseCase struct {
+	playerRepo    domain.PlayerRepository
+	playerFactory *domain.PlayerFactory
+	cooldown      time.Duration
+	publisher     events.Publisher
+	now           func() time.Time
+}
+
+func New(repo domain.PlayerRepository, factory *domain.PlayerFactory, cooldown time.Duration, publisher events.Publisher) *UseCase {
+	return &UseCase{
+		playerRepo:    repo,
+		playerFactory: factory,
+		cooldown:      cooldown,
+		publisher:     publisher,
+		now:           time.Now,
+	}
+}
+
+// Execute renames the player, at most once per cooldown
+func (uc *UseCase) Execute(ctx context.Context, req application.RenameRequest) (_ *application.RenameResponse, err error) {
+	ctx, span := tracing.Start(ctx, "player.rename.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	// Validate request
+	playerID, err := domain.NewPlayerID(req.PlayerID)
+	if err != nil {
+		return nil, shared.ErrPlayerNotFound
+	}
+	nickname, err := domain.NewNickname(req.Nickname, uc.playerFactory.NicknameMinLen, uc.playerFactory.NicknameMaxLen)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", shared.ErrInvalidNickname, err)
+	}
+
+	// Find player (shared.ErrPlayerNotFound when missing)
+	player, err := uc.playerRepo.FindByID(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+	i18n.Prefer(ctx, player.Locale())
+	previous := player.Nickname().String()
+
+	if !player.Nickname().Equals(nickname) {
+		taken, err := uc.playerRepo.ExistsByNickname(ctx, nickname)
+		if err != nil {
+			return nil, err
+		}
+		if taken {
+			return nil, domain.ErrNicknameTaken
+		}
+	}
+
+	if err := player.Rename(nickname, uc.cooldown, uc.now()); err != nil {
+		return nil, err
+	}
+	if err := uc.playerRepo.Update(ctx, player); err != nil {
+		return nil, err
+	}
+	uc.publisher.Publish(ctx, player.DomainEvents()...)
+	player.ClearEvents()
+
+	logging.FromContext(ctx).InfoContext(ctx, "player renamed", "player_id", req.PlayerID, "previous_nickname", previous)
+
+	return &application.RenameResponse{
+		ID:                player.ID().String(),
+		Nickname:          player.Nickname().String(),
+		PreviousNickname:  previous,
+		RenameAvailableAt: player.Lifecycle().RenameAvailableAt(uc.cooldown),
+	}, nil
+}
@@ -0,0 +1,38 @@
+package application
+
+import (
+	"time"
+
+	"backend/internal/modules/player/domain"
+)
+
+// NewStreakDTO describes the player's streak as seen at now
+func NewStreakDTO(player *domain.Player, policy *domain.StreakPolicy, now time.Time, granted []domain.StreakReward) StreakDTO {
+	streak := player.Streak()
+	boosts := player.Boosts()
+
+	dto := StreakDTO{
+		Current:          streak.CurrentOn(policy.Boundary.DayOf(now)),
+		Longest:          streak.Longest(),
+		Freezes:          streak.Freezes(),
+		BonusSpins:       boosts.BonusSpins(),
+		PointsMultiplier: boosts.MultiplierAt(now),
+	}
+	if _, until := boosts.Multiplier(); dto.PointsMultiplier > 1 {
+		dto.MultiplierExpiresAt = until
+	}
+
+	for _, reward := range granted {
+		r := StreakRewardDTO{
+			Day:        streak.Current(),
+			Type:       string(reward.Kind),
+			Amount:     reward.Amount,
+			Multiplier: reward.Multiplier,
+		}
+		if reward.Duration > 0 {
+			r.Duration = reward.Duration.String()
+		}
+		dto.RewardsGranted = append(dto.RewardsGranted, r)
+	}
+	return dto
+}
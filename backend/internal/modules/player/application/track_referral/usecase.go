@@ -0,0 +1,142 @@
+package track_referral
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	gamedomain "backend/internal/modules/game/domain"
+	"backend/internal/modules/player/domain"
+	shared "backend/internal/shared/domain"
+	"backend/internal/shared/events"
+	"backend/internal/shared/logging"
+	"backend/internal/shared/retry"
+	"backend/internal/shared/tracing"
+)
+
+// Request is a referee spin
+type Request struct {
+	RefereeID   string
+	TotalPoints int // referee's total after the spin
+}
+
+// UseCase tracks referee progress and pays referrers at the milestone
+type UseCase struct {
+	playerRepo   domain.PlayerRepository
+	referralRepo domain.ReferralRepository
+	tx           shared.Transactor
+	policy       *domain.ReferralPolicy
+	publisher    events.Publisher
+	retry        retry.Policy
+	now          func() time.Time
+}
+
+func New(
+	repo domain.PlayerRepository,
+	referralRepo domain.ReferralRepository,
+	tx shared.Transactor,
+	policy *domain.ReferralPolicy,
+	publisher events.Publisher,
+	retryPolicy retry.Policy,
+) *UseCase {
+	return &UseCase{
+		playerRepo:   repo,
+		referralRepo: referralRepo,
+		tx:           tx,
+		policy:       policy,
+		publisher:    publisher,
+		retry:        retryPolicy,
+		now:          time.Now,
+	}
+}
+
+// HandleEvent tracks every executed spin; subscribe it to game.spin_executed
+func (uc *UseCase) HandleEvent(ctx context.Context, event shared.DomainEvent) {
+	spin, ok := event.(*gamedomain.SpinExecutedEvent)
+	if !ok {
+		return
+	}
+	if err := uc.Execute(ctx, Request{RefereeID: spin.PlayerID, TotalPoints: spin.TotalPointsAfter}); err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "track referral failed", "player_id", spin.PlayerID, "error", err)
+	}
+}
+
+// Execute records a referee spin and pays the referrer once the milestone is reached
+func (uc *UseCase) Execute(ctx context.Context, req Request) (err error) {
+	ctx, span := tracing.Start(ctx, "player.track_referral.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	// Most players were not referred, or their referrer was already paid
+	referral, err := uc.referralRepo.RecordSpin(ctx, req.RefereeID, req.TotalPoints)
+	if err != nil {
+		if errors.Is(err, domain.ErrReferralNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !referral.MilestoneReached(uc.policy.Milestone) {
+		return nil
+	}
+
+	// Milestone reached: mark and pay in one transaction. Marking first makes a
+	// concurrent milestone spin wait and then find the referral paid; a failed
+	// payout rolls the mark back, so the referee's next spin retries it.
+	if err := referral.MarkRewarded(uc.policy.RewardPoints, uc.now()); err != nil {
+		return err
+	}
+	var referrer *domain.Player
+	err = uc.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		marked, err := uc.referralRepo.MarkRewarded(ctx, referral)
+		if err != nil || !marked {
+			return err
+		}
+		referrer, err = uc.payReferrer(ctx, referral)
+		return err
+	})
+	if err != nil || referrer == nil {
+		return err
+	}
+
+	uc.publisher.Publish(ctx, referrer.DomainEvents()...)
+	referrer.ClearEvents()
+	uc.publisher.Publish(ctx, referral.DomainEvents()...)
+	referral.ClearEvents()
+
+	logging.FromContext(ctx).InfoContext(ctx, "referral rewarded",
+		"referrer_id", referral.ReferrerID(),
+		"referee_id", referral.RefereeID(),
+		"points", referral.RewardPoints(),
+	)
+	return nil
+}
+
+// payReferrer adds the reward to the referrer; a lost update to the referrer is
+// retried from a fresh load. The rewarded referral (reward_points,
+// rewarded_at) is the payout's record, so no spin log is written.
+func (uc *UseCase) payReferrer(ctx context.Context, referral *domain.Referral) (*domain.Player, error) {
+	referrerID, err := domain.NewPlayerID(referral.ReferrerID())
+	if err != nil {
+		return nil, err
+	}
+	points, err := shared.NewPoints(referral.RewardPoints())
+	if err != nil {
+		return nil, err
+	}
+
+	var referrer *domain.Player
+	err = uc.retry.Do(ctx, func(ctx context.Context) error {
+		var err error
+		referrer, err = uc.playerRepo.FindByID(ctx, referrerID)
+		if err != nil {
+			return err
+		}
+		if err := referrer.AddPoints(points); err != nil {
+			return err
+		}
+		return uc.playerRepo.Update(ctx, referrer)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return referrer, nil
+}
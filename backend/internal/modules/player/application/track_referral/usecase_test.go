@@ -0,0 +1,102 @@
+package track_referral
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"backend/internal/infrastructure/database"
+	"backend/internal/modules/player/adapter/repository"
+	"backend/internal/modules/player/domain"
+	"backend/internal/shared/contracttest"
+	shared "backend/internal/shared/domain"
+	"backend/internal/shared/retry"
+)
+
+var errDown = errors.New("database unavailable")
+
+// failingPlayerRepo fails every update while down is set
+type failingPlayerRepo struct {
+	*repository.PlayerRepositoryGorm
+	down bool
+}
+
+func (r *failingPlayerRepo) Update(ctx context.Context, player *domain.Player) error {
+	if r.down {
+		return errDown
+	}
+	return r.PlayerRepositoryGorm.Update(ctx, player)
+}
+
+type noopPublisher struct{}
+
+func (noopPublisher) Publish(ctx context.Context, events ...shared.DomainEvent) {}
+
+func TestFailedPayoutIsRetriedOnNextSpin(t *testing.T) {
+	db := contracttest.OpenSQLite(t)
+	ctx := context.Background()
+	factory := domain.NewPlayerFactory(3, 50)
+	players := &failingPlayerRepo{PlayerRepositoryGorm: repository.NewPlayerRepositoryGorm(db, factory)}
+	referrals := repository.NewReferralRepositoryGorm(db)
+
+	referrer, _ := factory.CreateNewPlayer("alice")
+	referee, _ := factory.CreateNewPlayer("bob")
+	for _, p := range []*domain.Player{referrer, referee} {
+		if err := players.Store(ctx, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	referral, _ := domain.NewReferral(referrer, referee)
+	if err := referrals.Store(ctx, referral); err != nil {
+		t.Fatal(err)
+	}
+
+	policy := &domain.ReferralPolicy{Milestone: domain.ReferralMilestone{Spins: 2}, RewardPoints: 100}
+	uc := New(players, referrals, database.NewTransactor(db), policy, noopPublisher{}, retry.Policy{MaxAttempts: 1})
+	spin := func() error {
+		return uc.Execute(ctx, Request{RefereeID: referee.ID().String(), TotalPoints: 10})
+	}
+	assertPaid := func(status domain.ReferralStatus, points int) {
+		t.Helper()
+		stored, err := referrals.FindByReferee(ctx, referee.ID().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		paid, err := players.FindByID(ctx, referrer.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stored.Status() != status || paid.TotalPoints().Value() != points {
+			t.Fatalf("referral %s, referrer %d points; want %s, %d", stored.Status(), paid.TotalPoints().Value(), status, points)
+		}
+	}
+
+	if err := spin(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The payout fails at the milestone: the mark is rolled back with it
+	players.down = true
+	if err := spin(); !errors.Is(err, errDown) {
+		t.Fatalf("err = %v, want %v", err, errDown)
+	}
+	assertPaid(domain.ReferralPending, 0)
+
+	// The next spin pays, and later spins do not pay again
+	players.down = false
+	for range 2 {
+		if err := spin(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	assertPaid(domain.ReferralRewarded, 100)
+
+	// The payout is not a spin the referrer made
+	var spins int64
+	if err := db.Table("spin_logs").Where("player_id = ?", referrer.ID().String()).Count(&spins).Error; err != nil {
+		t.Fatal(err)
+	}
+	if spins != 0 {
+		t.Fatalf("referrer spin logs = %d, want 0", spins)
+	}
+}
@@ -10,9 +10,16 @@ import (
 type Player struct {
 	id          *PlayerID
 	nickname    *Nickname
+	inviteCode  *InviteCode
 	totalPoints *shared.Points
+	streak      LoginStreak
+	boosts      Boosts
+	lifecycle   Lifecycle
+	locale      string // preferred locale; empty follows Accept-Language
+	signupIP    string // where the player signed up; empty when unknown or erased
 	createdAt   time.Time
 	updatedAt   time.Time
+	version     int // incremented by every stored update
 
 	// Transient (not persisted)
 	domainEvents []shared.DomainEvent
@@ -26,13 +33,21 @@ func NewPlayer(id *PlayerID, nickname *Nickname) (*Player, error) {
 	if nickname == nil {
 		return nil, errors.New("nickname cannot be nil")
 	}
+	inviteCode, err := GenerateInviteCode()
+	if err != nil {
+		return nil, err
+	}
 
 	player := &Player{
 		id:           id,
 		nickname:     nickname,
+		inviteCode:   inviteCode,
 		totalPoints:  nil, // Will be set below
+		boosts:       Boosts{multiplier: 1},
+		lifecycle:    Lifecycle{status: PlayerActive},
 		createdAt:    time.Now(),
 		updatedAt:    time.Now(),
+		version:      1,
 		domainEvents: []shared.DomainEvent{},
 	}
 
@@ -54,13 +69,20 @@ func NewPlayer(id *PlayerID, nickname *Nickname) (*Player, error) {
 }
 
 // ReconstructPlayer rebuilds player from persistence (no events emitted)
-func ReconstructPlayer(id *PlayerID, nickname *Nickname, points *shared.Points, createdAt, updatedAt time.Time) *Player {
+func ReconstructPlayer(id *PlayerID, nickname *Nickname, inviteCode *InviteCode, points *shared.Points, streak LoginStreak, boosts Boosts, lifecycle Lifecycle, locale, signupIP string, createdAt, updatedAt time.Time, version int) *Player {
 	return &Player{
 		id:           id,
 		nickname:     nickname,
+		inviteCode:   inviteCode,
 		totalPoints:  points,
+		streak:       streak,
+		boosts:       boosts,
+		lifecycle:    lifecycle,
+		locale:       locale,
+		signupIP:     signupIP,
 		createdAt:    createdAt,
 		updatedAt:    updatedAt,
+		version:      version,
 		domainEvents: []shared.DomainEvent{},
 	}
 }
@@ -74,10 +96,41 @@ func (p *Player) Nickname() *Nickname {
 	return p.nickname
 }
 
+func (p *Player) InviteCode() *InviteCode {
+	return p.inviteCode
+}
+
 func (p *Player) TotalPoints() *shared.Points {
 	return p.totalPoints
 }
 
+func (p *Player) Streak() LoginStreak {
+	return p.streak
+}
+
+func (p *Player) Boosts() Boosts {
+	return p.boosts
+}
+
+func (p *Player) Lifecycle() Lifecycle {
+	return p.lifecycle
+}
+
+// Locale is the player's preferred locale (empty when unset)
+func (p *Player) Locale() string {
+	return p.locale
+}
+
+// SignupIP is where the player signed up (empty when unknown or erased)
+func (p *Player) SignupIP() string {
+	return p.signupIP
+}
+
+// RecordSignupIP records where a new player is signing up from
+func (p *Player) RecordSignupIP(ip string) {
+	p.signupIP = ip
+}
+
 func (p *Player) CreatedAt() time.Time {
 	return p.createdAt
 }
@@ -86,6 +139,16 @@ func (p *Player) UpdatedAt() time.Time {
 	return p.updatedAt
 }
 
+// Version is the stored version this copy was loaded at
+func (p *Player) Version() int {
+	return p.version
+}
+
+// SetVersion records the version after the repository stored an update
+func (p *Player) SetVersion(version int) {
+	p.version = version
+}
+
 // Business behavior
 func (p *Player) AddPoints(amount *shared.Points) error {
 	if amount == nil {
@@ -116,6 +179,71 @@ func (p *Player) Enter() {
 	p.domainEvents = append(p.domainEvents, NewPlayerEnteredEvent(p.id.String()))
 }
 
+// RecordLogin counts now's day in the login streak and applies the rewards
+// earned on the new streak day. It returns the granted rewards; nothing
+// changes when the day was already counted.
+func (p *Player) RecordLogin(now time.Time, policy *StreakPolicy) ([]StreakReward, error) {
+	next, counted, freezesUsed := p.streak.record(policy.Boundary.DayOf(now))
+	if !counted {
+		return nil, nil
+	}
+	p.streak = next
+	p.updatedAt = now
+	p.domainEvents = append(p.domainEvents, NewStreakAdvancedEvent(p.id.String(), next.current, next.longest, freezesUsed))
+
+	rewards := policy.RewardsFor(next.current)
+	for _, reward := range rewards {
+		switch reward.Kind {
+		case StreakRewardBonusSpins:
+			p.boosts.bonusSpins += reward.Amount
+		case StreakRewardMultiplier:
+			until := now.Add(reward.Duration)
+			p.boosts.multiplier = reward.Multiplier
+			p.boosts.multiplierUntil = &until
+		case StreakRewardPoints:
+			amount, err := shared.NewPoints(reward.Amount)
+			if err != nil {
+				return nil, err
+			}
+			if err := p.AddPoints(amount); err != nil {
+				return nil, err
+			}
+		case StreakRewardFreeze:
+			p.streak.freezes += reward.Amount
+			if policy.MaxFreezes > 0 {
+				p.streak.freezes = min(p.streak.freezes, policy.MaxFreezes)
+			}
+		}
+		p.domainEvents = append(p.domainEvents, NewStreakRewardGrantedEvent(p.id.String(), next.current, reward))
+	}
+
+	return rewards, nil
+}
+
+// ChangeLocale sets the preferred locale; callers check it is supported
+// (empty clears the preference)
+func (p *Player) ChangeLocale(locale string, now time.Time) error {
+	if err := p.CheckActive(now); err != nil {
+		return err
+	}
+	if p.locale == locale {
+		return nil
+	}
+	p.locale = locale
+	p.updatedAt = now
+	return nil
+}
+
+// UseBonusSpin consumes one bonus spin
+func (p *Player) UseBonusSpin() error {
+	if p.boosts.bonusSpins <= 0 {
+		return errors.New("no bonus spins left")
+	}
+	p.boosts.bonusSpins--
+	p.updatedAt = time.Now()
+	return nil
+}
+
 // Event management
 func (p *Player) DomainEvents() []shared.DomainEvent {
 	return p.domainEvents
@@ -133,11 +261,20 @@ func (p *Player) IsValid() error {
 	if p.nickname == nil {
 		return errors.New("nickname is required")
 	}
+	if p.inviteCode == nil {
+		return errors.New("invite code is required")
+	}
 	if p.totalPoints == nil {
 		return errors.New("total points cannot be nil")
 	}
 	if p.totalPoints.Value() < 0 {
 		return errors.New("total points cannot be negative")
 	}
+	if _, err := ParsePlayerStatus(string(p.lifecycle.status)); err != nil {
+		return err
+	}
+	if p.streak.current < 0 || p.streak.freezes < 0 || p.boosts.bonusSpins < 0 {
+		return errors.New("streak counters cannot be negative")
+	}
 	return nil
 }
@@ -64,3 +64,192 @@ func NewPointsAddedEvent(playerID string, amount, totalAfter int) *PointsAddedEv
 func (e *PointsAddedEvent) EventType() string {
 	return "player.points_added"
 }
+
+// StreakAdvancedEvent fired when a login extends or restarts the streak
+type StreakAdvancedEvent struct {
+	shared.BaseEvent
+	Current     int
+	Longest     int
+	FreezesUsed int
+}
+
+func NewStreakAdvancedEvent(playerID string, current, longest, freezesUsed int) *StreakAdvancedEvent {
+	event := &StreakAdvancedEvent{
+		Current:     current,
+		Longest:     longest,
+		FreezesUsed: freezesUsed,
+	}
+	event.BaseEvent = shared.NewBaseEvent(playerID)
+	return event
+}
+
+func (e *StreakAdvancedEvent) EventType() string {
+	return "player.streak_advanced"
+}
+
+// StreakRewardGrantedEvent fired when a streak day earns a reward
+type StreakRewardGrantedEvent struct {
+	shared.BaseEvent
+	StreakDay int
+	Reward    StreakReward
+}
+
+func NewStreakRewardGrantedEvent(playerID string, streakDay int, reward StreakReward) *StreakRewardGrantedEvent {
+	event := &StreakRewardGrantedEvent{
+		StreakDay: streakDay,
+		Reward:    reward,
+	}
+	event.BaseEvent = shared.NewBaseEvent(playerID)
+	return event
+}
+
+func (e *StreakRewardGrantedEvent) EventType() string {
+	return "player.streak_reward_granted"
+}
+
+// PlayerReferredEvent fired when a new player joins with an invite code
+type PlayerReferredEvent struct {
+	shared.BaseEvent
+	RefereeID string
+}
+
+func NewPlayerReferredEvent(referrerID, refereeID string) *PlayerReferredEvent {
+	event := &PlayerReferredEvent{
+		RefereeID: refereeID,
+	}
+	event.BaseEvent = shared.NewBaseEvent(referrerID)
+	return event
+}
+
+func (e *PlayerReferredEvent) EventType() string {
+	return "player.referred"
+}
+
+// ReferralRewardedEvent fired when a referee reaches the milestone and the referrer is paid
+type ReferralRewardedEvent struct {
+	shared.BaseEvent
+	RefereeID string
+	Points    int
+}
+
+func NewReferralRewardedEvent(referrerID, refereeID string, points int) *ReferralRewardedEvent {
+	event := &ReferralRewardedEvent{
+		RefereeID: refereeID,
+		Points:    points,
+	}
+	event.BaseEvent = shared.NewBaseEvent(referrerID)
+	return event
+}
+
+func (e *ReferralRewardedEvent) EventType() string {
+	return "player.referral_rewarded"
+}
+
+// PlayerSuspendedEvent fired when an admin suspends a player
+type PlayerSuspendedEvent struct {
+	shared.BaseEvent
+	Until  time.Time
+	Reason string
+}
+
+func NewPlayerSuspendedEvent(playerID string, until time.Time, reason string) *PlayerSuspendedEvent {
+	event := &PlayerSuspendedEvent{
+		Until:  until,
+		Reason: reason,
+	}
+	event.BaseEvent = shared.NewBaseEvent(playerID)
+	return event
+}
+
+func (e *PlayerSuspendedEvent) EventType() string {
+	return "player.suspended"
+}
+
+// PlayerBannedEvent fired when an admin bans a player
+type PlayerBannedEvent struct {
+	shared.BaseEvent
+	Reason string
+}
+
+func NewPlayerBannedEvent(playerID, reason string) *PlayerBannedEvent {
+	event := &PlayerBannedEvent{
+		Reason: reason,
+	}
+	event.BaseEvent = shared.NewBaseEvent(playerID)
+	return event
+}
+
+func (e *PlayerBannedEvent) EventType() string {
+	return "player.banned"
+}
+
+// PlayerReactivatedEvent fired when an admin lifts a suspension or ban
+type PlayerReactivatedEvent struct {
+	shared.BaseEvent
+	Reason string
+}
+
+func NewPlayerReactivatedEvent(playerID, reason string) *PlayerReactivatedEvent {
+	event := &PlayerReactivatedEvent{
+		Reason: reason,
+	}
+	event.BaseEvent = shared.NewBaseEvent(playerID)
+	return event
+}
+
+func (e *PlayerReactivatedEvent) EventType() string {
+	return "player.reactivated"
+}
+
+// PlayerDeletedEvent fired when a player is soft deleted
+type PlayerDeletedEvent struct {
+	shared.BaseEvent
+	Reason string
+}
+
+func NewPlayerDeletedEvent(playerID, reason string) *PlayerDeletedEvent {
+	event := &PlayerDeletedEvent{
+		Reason: reason,
+	}
+	event.BaseEvent = shared.NewBaseEvent(playerID)
+	return event
+}
+
+func (e *PlayerDeletedEvent) EventType() string {
+	return "player.deleted"
+}
+
+// PlayerRenamedEvent fired when a player changes their nickname
+type PlayerRenamedEvent struct {
+	shared.BaseEvent
+	OldNickname string
+	NewNickname string
+}
+
+func NewPlayerRenamedEvent(playerID, oldNickname, newNickname string) *PlayerRenamedEvent {
+	event := &PlayerRenamedEvent{
+		OldNickname: oldNickname,
+		NewNickname: newNickname,
+	}
+	event.BaseEvent = shared.NewBaseEvent(playerID)
+	return event
+}
+
+func (e *PlayerRenamedEvent) EventType() string {
+	return "player.renamed"
+}
+
+// PlayerErasedEvent fired when a player's personal data is erased
+type PlayerErasedEvent struct {
+	shared.BaseEvent
+}
+
+func NewPlayerErasedEvent(playerID string) *PlayerErasedEvent {
+	event := &PlayerErasedEvent{}
+	event.BaseEvent = shared.NewBaseEvent(playerID)
+	return event
+}
+
+func (e *PlayerErasedEvent) EventType() string {
+	return "player.erased"
+}
@@ -33,8 +33,15 @@ func (f *PlayerFactory) CreateNewPlayer(nickname string) (*Player, error) {
 func (f *PlayerFactory) ReconstructPlayer(
 	id string,
 	nickname string,
+	inviteCode string,
 	totalPoints int,
+	streak LoginStreak,
+	boosts Boosts,
+	lifecycle Lifecycle,
+	locale string,
+	signupIP string,
 	createdAt, updatedAt time.Time,
+	version int,
 ) (*Player, error) {
 	playerID, err := NewPlayerID(id)
 	if err != nil {
@@ -46,10 +53,15 @@ func (f *PlayerFactory) ReconstructPlayer(
 		return nil, err
 	}
 
+	inviteCodeVO, err := NewInviteCode(inviteCode)
+	if err != nil {
+		return nil, err
+	}
+
 	points, err := shared.NewPoints(totalPoints)
 	if err != nil {
 		return nil, err
 	}
 
-	return ReconstructPlayer(playerID, nicknameVO, points, createdAt, updatedAt), nil
+	return ReconstructPlayer(playerID, nicknameVO, inviteCodeVO, points, streak, boosts, lifecycle, locale, signupIP, createdAt, updatedAt, version), nil
 }
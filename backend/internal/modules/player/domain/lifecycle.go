@@ -0,0 +1,236 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	shared "backend/internal/shared/domain"
+)
+
+// Lifecycle errors
+var (
+	ErrInvalidStatus       = errors.New("status must be active, suspended, banned or deleted")
+	ErrInvalidStatusChange = errors.New("invalid status change")
+	ErrNicknameTaken       = errors.New("nickname already taken")
+	ErrRenameCooldown      = errors.New("nickname was changed too recently")
+	ErrAlreadyErased       = errors.New("player data already erased")
+	ErrStatusFinal         = errors.New("deleted players cannot change status")
+)
+
+// PlayerStatus is where a player account is in its lifecycle
+type PlayerStatus string
+
+const (
+	PlayerActive    PlayerStatus = "active"
+	PlayerSuspended PlayerStatus = "suspended" // until SuspendedUntil, then active again
+	PlayerBanned    PlayerStatus = "banned"
+	PlayerDeleted   PlayerStatus = "deleted" // soft deleted; final
+)
+
+// ParsePlayerStatus parses a status name
+func ParsePlayerStatus(s string) (PlayerStatus, error) {
+	switch status := PlayerStatus(s); status {
+	case PlayerActive, PlayerSuspended, PlayerBanned, PlayerDeleted:
+		return status, nil
+	default:
+		return "", ErrInvalidStatus
+	}
+}
+
+// Lifecycle is a player's account state and nickname history
+type Lifecycle struct {
+	status             PlayerStatus
+	suspendedUntil     *time.Time
+	reason             string
+	changedAt          *time.Time // last status change, nil while never changed
+	nicknameChangedAt  *time.Time // last rename
+	nicknameReleasedAt *time.Time // set once a deleted player's nickname is free again
+	erasedAt           *time.Time // set once personal data was erased
+}
+
+// NewLifecycle rebuilds a lifecycle from persistence
+func NewLifecycle(
+	status PlayerStatus,
+	suspendedUntil *time.Time,
+	reason string,
+	changedAt, nicknameChangedAt, nicknameReleasedAt, erasedAt *time.Time,
+) Lifecycle {
+	return Lifecycle{
+		status:             status,
+		suspendedUntil:     suspendedUntil,
+		reason:             reason,
+		changedAt:          changedAt,
+		nicknameChangedAt:  nicknameChangedAt,
+		nicknameReleasedAt: nicknameReleasedAt,
+		erasedAt:           erasedAt,
+	}
+}
+
+// Status returns the stored status
+func (l Lifecycle) Status() PlayerStatus {
+	return l.status
+}
+
+// StatusAt returns the status in effect at now; a suspension ends by itself
+func (l Lifecycle) StatusAt(now time.Time) PlayerStatus {
+	if l.status == PlayerSuspended && l.suspendedUntil != nil && !now.Before(*l.suspendedUntil) {
+		return PlayerActive
+	}
+	return l.status
+}
+
+func (l Lifecycle) SuspendedUntil() *time.Time {
+	return l.suspendedUntil
+}
+
+func (l Lifecycle) Reason() string {
+	return l.reason
+}
+
+func (l Lifecycle) ChangedAt() *time.Time {
+	return l.changedAt
+}
+
+func (l Lifecycle) NicknameChangedAt() *time.Time {
+	return l.nicknameChangedAt
+}
+
+func (l Lifecycle) NicknameReleasedAt() *time.Time {
+	return l.nicknameReleasedAt
+}
+
+func (l Lifecycle) ErasedAt() *time.Time {
+	return l.erasedAt
+}
+
+// RenameAvailableAt returns when the nickname may next be changed
+func (l Lifecycle) RenameAvailableAt(cooldown time.Duration) time.Time {
+	if l.nicknameChangedAt == nil {
+		return time.Time{}
+	}
+	return l.nicknameChangedAt.Add(cooldown)
+}
+
+// CheckActive rejects players who may not play at now
+func (p *Player) CheckActive(now time.Time) error {
+	switch p.lifecycle.StatusAt(now) {
+	case PlayerSuspended:
+		return shared.ErrPlayerSuspended
+	case PlayerBanned:
+		return shared.ErrPlayerBanned
+	case PlayerDeleted:
+		return shared.ErrPlayerDeleted
+	default:
+		return nil
+	}
+}
+
+// Suspend blocks the player until the given time
+func (p *Player) Suspend(until time.Time, reason string, now time.Time) error {
+	if !until.After(now) {
+		return fmt.Errorf("%w: suspension must end in the future", ErrInvalidStatusChange)
+	}
+	if err := p.changeStatus(PlayerSuspended, reason, now); err != nil {
+		return err
+	}
+	p.lifecycle.suspendedUntil = &until
+	p.domainEvents = append(p.domainEvents, NewPlayerSuspendedEvent(p.id.String(), until, reason))
+	return nil
+}
+
+// Ban blocks the player indefinitely
+func (p *Player) Ban(reason string, now time.Time) error {
+	if err := p.changeStatus(PlayerBanned, reason, now); err != nil {
+		return err
+	}
+	p.domainEvents = append(p.domainEvents, NewPlayerBannedEvent(p.id.String(), reason))
+	return nil
+}
+
+// Reactivate lifts a suspension or ban
+func (p *Player) Reactivate(reason string, now time.Time) error {
+	if err := p.changeStatus(PlayerActive, reason, now); err != nil {
+		return err
+	}
+	p.domainEvents = append(p.domainEvents, NewPlayerReactivatedEvent(p.id.String(), reason))
+	return nil
+}
+
+// Delete soft deletes the player; the nickname is released after a grace period
+func (p *Player) Delete(reason string, now time.Time) error {
+	if err := p.changeStatus(PlayerDeleted, reason, now); err != nil {
+		return err
+	}
+	p.domainEvents = append(p.domainEvents, NewPlayerDeletedEvent(p.id.String(), reason))
+	return nil
+}
+
+// changeStatus records a status change; deleted players cannot change
+func (p *Player) changeStatus(status PlayerStatus, reason string, now time.Time) error {
+	if p.lifecycle.status == PlayerDeleted {
+		return ErrStatusFinal
+	}
+	if reason == "" {
+		return fmt.Errorf("%w: a reason is required", ErrInvalidStatusChange)
+	}
+	p.lifecycle.status = status
+	p.lifecycle.suspendedUntil = nil
+	p.lifecycle.reason = reason
+	p.lifecycle.changedAt = &now
+	p.updatedAt = now
+	return nil
+}
+
+// Rename changes the nickname, at most once per cooldown. The caller checks
+// the new nickname is not taken.
+func (p *Player) Rename(nickname *Nickname, cooldown time.Duration, now time.Time) error {
+	if nickname == nil {
+		return errors.New("nickname cannot be nil")
+	}
+	if err := p.CheckActive(now); err != nil {
+		return err
+	}
+	if p.nickname.Equals(nickname) {
+		return nil
+	}
+	if now.Before(p.lifecycle.RenameAvailableAt(cooldown)) {
+		return ErrRenameCooldown
+	}
+
+	old := p.nickname
+	p.nickname = nickname
+	p.lifecycle.nicknameChangedAt = &now
+	p.updatedAt = now
+	p.domainEvents = append(p.domainEvents, NewPlayerRenamedEvent(p.id.String(), old.String(), nickname.String()))
+	return nil
+}
+
+// pseudonymFor returns the nickname an erased player is given; derived from
+// the ID so it is stable and does not reveal the old nickname
+func pseudonymFor(id *PlayerID) *Nickname {
+	sum := sha256.Sum256([]byte(id.String()))
+	return &Nickname{value: "erased-" + hex.EncodeToString(sum[:6])}
+}
+
+// Erase handles a data erasure request: the nickname is replaced by a
+// pseudonym, the status reason is cleared and the account deleted. Points,
+// spins and claims are kept.
+func (p *Player) Erase(now time.Time) error {
+	if p.lifecycle.erasedAt != nil {
+		return ErrAlreadyErased
+	}
+
+	p.nickname = pseudonymFor(p.id)
+	p.signupIP = ""
+	p.lifecycle.status = PlayerDeleted
+	p.lifecycle.suspendedUntil = nil
+	p.lifecycle.reason = ""
+	p.lifecycle.changedAt = &now
+	p.lifecycle.erasedAt = &now
+	p.updatedAt = now
+	p.domainEvents = append(p.domainEvents, NewPlayerErasedEvent(p.id.String()))
+	return nil
+}
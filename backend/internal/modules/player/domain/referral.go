@@ -0,0 +1,245 @@
+package domain
+
+import (
+	"crypto/rand"
+	"errors"
+	"strings"
+	"time"
+
+	shared "backend/internal/shared/domain"
+
+	"github.com/google/uuid"
+)
+
+// Referral errors
+var (
+	ErrInvalidReferralCode  = errors.New("invalid referral code")
+	ErrSelfReferral         = errors.New("players cannot refer themselves")
+	ErrReferralLimitReached = errors.New("referral limit reached")
+	ErrReferralNotFound     = errors.New("referral not found")
+)
+
+const inviteCodeLength = 8
+
+// inviteCodeAlphabet leaves out 0/O and 1/I, which are easy to mistype
+const inviteCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// InviteCode is the code a player shares to refer others
+type InviteCode struct {
+	value string
+}
+
+// NewInviteCode parses a code (case-insensitive, surrounding spaces ignored)
+func NewInviteCode(code string) (*InviteCode, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != inviteCodeLength {
+		return nil, ErrInvalidReferralCode
+	}
+	for _, c := range code {
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return nil, ErrInvalidReferralCode
+		}
+	}
+	return &InviteCode{value: code}, nil
+}
+
+// GenerateInviteCode creates a random invite code
+func GenerateInviteCode() (*InviteCode, error) {
+	buf := make([]byte, inviteCodeLength)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+	for i, b := range buf {
+		buf[i] = inviteCodeAlphabet[int(b)%len(inviteCodeAlphabet)]
+	}
+	return &InviteCode{value: string(buf)}, nil
+}
+
+// String returns the string representation
+func (c *InviteCode) String() string {
+	return c.value
+}
+
+// ReferralStatus is whether the referrer has been paid
+type ReferralStatus string
+
+const (
+	ReferralPending  ReferralStatus = "pending"
+	ReferralRewarded ReferralStatus = "rewarded"
+)
+
+// ReferralMilestone is what a referee must reach before the referrer is paid:
+// Spins spins or Points total points, whichever comes first (0 disables a goal)
+type ReferralMilestone struct {
+	Spins  int
+	Points int
+}
+
+// ReachedBy reports whether spins or points meet the milestone
+func (m ReferralMilestone) ReachedBy(spins, points int) bool {
+	return (m.Spins > 0 && spins >= m.Spins) || (m.Points > 0 && points >= m.Points)
+}
+
+// ReferralPolicy holds the configured referral rules
+type ReferralPolicy struct {
+	Milestone    ReferralMilestone
+	RewardPoints int
+	// Caps on new referrals within any 24 hours (0 = no cap)
+	MaxPerReferrerPerDay int
+	MaxPerIPPerDay       int
+}
+
+// Referral links a referrer to a player who joined with their invite code
+type Referral struct {
+	id            string
+	referrerID    string
+	refereeID     string
+	inviteCode    string
+	ipAddress     string
+	status        ReferralStatus
+	refereeSpins  int
+	refereePoints int
+	rewardPoints  int
+	createdAt     time.Time
+	rewardedAt    *time.Time
+
+	// Transient
+	domainEvents []shared.DomainEvent
+}
+
+// NewReferral links a new player to their referrer. A referee who signed up
+// from the referrer's signup IP is taken to be the referrer on a second account.
+func NewReferral(referrer, referee *Player) (*Referral, error) {
+	if referrer == nil || referee == nil {
+		return nil, errors.New("referrer and referee are required")
+	}
+	if referrer.ID().Equals(referee.ID()) {
+		return nil, ErrSelfReferral
+	}
+	if ip := referee.SignupIP(); ip != "" && ip == referrer.SignupIP() {
+		return nil, ErrSelfReferral
+	}
+
+	r := &Referral{
+		id:           uuid.New().String(),
+		referrerID:   referrer.ID().String(),
+		refereeID:    referee.ID().String(),
+		inviteCode:   referrer.InviteCode().String(),
+		ipAddress:    referee.SignupIP(),
+		status:       ReferralPending,
+		createdAt:    time.Now(),
+		domainEvents: []shared.DomainEvent{},
+	}
+	r.domainEvents = append(r.domainEvents, NewPlayerReferredEvent(r.referrerID, r.refereeID))
+	return r, nil
+}
+
+// ReconstructReferral rebuilds a referral from persistence
+func ReconstructReferral(
+	id, referrerID, refereeID, inviteCode, ipAddress string,
+	status ReferralStatus,
+	refereeSpins, refereePoints, rewardPoints int,
+	createdAt time.Time,
+	rewardedAt *time.Time,
+) *Referral {
+	return &Referral{
+		id:            id,
+		referrerID:    referrerID,
+		refereeID:     refereeID,
+		inviteCode:    inviteCode,
+		ipAddress:     ipAddress,
+		status:        status,
+		refereeSpins:  refereeSpins,
+		refereePoints: refereePoints,
+		rewardPoints:  rewardPoints,
+		createdAt:     createdAt,
+		rewardedAt:    rewardedAt,
+		domainEvents:  []shared.DomainEvent{},
+	}
+}
+
+// Accessors (read-only)
+func (r *Referral) ID() string {
+	return r.id
+}
+
+func (r *Referral) ReferrerID() string {
+	return r.referrerID
+}
+
+func (r *Referral) RefereeID() string {
+	return r.refereeID
+}
+
+func (r *Referral) InviteCode() string {
+	return r.inviteCode
+}
+
+func (r *Referral) IPAddress() string {
+	return r.ipAddress
+}
+
+func (r *Referral) Status() ReferralStatus {
+	return r.status
+}
+
+func (r *Referral) RefereeSpins() int {
+	return r.refereeSpins
+}
+
+func (r *Referral) RefereePoints() int {
+	return r.refereePoints
+}
+
+func (r *Referral) RewardPoints() int {
+	return r.rewardPoints
+}
+
+func (r *Referral) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+func (r *Referral) RewardedAt() *time.Time {
+	return r.rewardedAt
+}
+
+// MilestoneReached reports whether the referral is still pending and the
+// referee's recorded progress meets the milestone
+func (r *Referral) MilestoneReached(milestone ReferralMilestone) bool {
+	return r.status == ReferralPending && milestone.ReachedBy(r.refereeSpins, r.refereePoints)
+}
+
+// MarkRewarded records that the referrer was paid points
+func (r *Referral) MarkRewarded(points int, now time.Time) error {
+	if r.status != ReferralPending {
+		return errors.New("referral already rewarded")
+	}
+	r.status = ReferralRewarded
+	r.rewardPoints = points
+	r.rewardedAt = &now
+	r.domainEvents = append(r.domainEvents, NewReferralRewardedEvent(r.referrerID, r.refereeID, points))
+	return nil
+}
+
+// Event management
+func (r *Referral) DomainEvents() []shared.DomainEvent {
+	return r.domainEvents
+}
+
+func (r *Referral) ClearEvents() {
+	r.domainEvents = []shared.DomainEvent{}
+}
+
+// ReferralEntry is a referral with the referee's nickname, for summaries
+type ReferralEntry struct {
+	Referral        *Referral
+	RefereeNickname string
+}
+
+// ReferralSummary counts a referrer's referrals
+type ReferralSummary struct {
+	Total        int
+	Pending      int
+	Rewarded     int
+	PointsEarned int
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // PlayerRepository defines persistence contract
 // Defined in domain, implemented in adapter
@@ -11,12 +14,55 @@ type PlayerRepository interface {
 	// FindByID loads player by ID
 	FindByID(ctx context.Context, id *PlayerID) (*Player, error)
 
-	// FindByNickname loads player by nickname
+	// FindByInviteCode loads the player who owns an invite code
+	FindByInviteCode(ctx context.Context, code *InviteCode) (*Player, error)
+
+	// FindByNickname loads the player holding a nickname (released nicknames are not held)
 	FindByNickname(ctx context.Context, nickname *Nickname) (*Player, error)
 
-	// Update persists changes to existing player
+	// Update persists changes to existing player if it is still at the version
+	// the player was loaded at, then advances the player's version. It returns
+	// shared.ErrConcurrentModification when another update got there first.
 	Update(ctx context.Context, player *Player) error
 
 	// ExistsByNickname checks if nickname is taken
 	ExistsByNickname(ctx context.Context, nickname *Nickname) (bool, error)
-}
\ No newline at end of file
+
+	// ReleaseNicknames frees the nicknames of players deleted before a time
+	// and returns how many were released
+	ReleaseNicknames(ctx context.Context, deletedBefore time.Time) (int, error)
+}
+
+// ReferralRepository defines referral persistence
+type ReferralRepository interface {
+	// Store stores a new referral
+	Store(ctx context.Context, referral *Referral) error
+
+	// FindByReferee loads the referral of a referred player (ErrReferralNotFound when none)
+	FindByReferee(ctx context.Context, refereeID string) (*Referral, error)
+
+	// RecordSpin atomically counts a referee spin and keeps their highest
+	// total, returning the updated referral (ErrReferralNotFound when the
+	// referee has no pending referral)
+	RecordSpin(ctx context.Context, refereeID string, totalPoints int) (*Referral, error)
+
+	// MarkRewarded persists a rewarded referral; it returns false when the
+	// referral was already rewarded, so the referrer is paid at most once
+	MarkRewarded(ctx context.Context, referral *Referral) (bool, error)
+
+	// CountByReferrerSince counts referrals a referrer made since a time (for daily caps)
+	CountByReferrerSince(ctx context.Context, referrerID string, since time.Time) (int, error)
+
+	// CountByIPSince counts referrals signed up from an IP since a time (for daily caps)
+	CountByIPSince(ctx context.Context, ip string, since time.Time) (int, error)
+
+	// SummaryByReferrer counts a referrer's referrals by status
+	SummaryByReferrer(ctx context.Context, referrerID string) (*ReferralSummary, error)
+
+	// ListByReferrer returns a referrer's most recent referrals (all when limit <= 0)
+	ListByReferrer(ctx context.Context, referrerID string, limit int) ([]*ReferralEntry, error)
+
+	// DetachPersonalData clears personal data (the signup IP) from the
+	// player's referral, keeping the link and any reward paid
+	DetachPersonalData(ctx context.Context, refereeID string) error
+}
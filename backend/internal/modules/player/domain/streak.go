@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"time"
+
+	shared "backend/internal/shared/domain"
+)
+
+// DayBoundary decides which day a moment belongs to for login streaks
+type DayBoundary = shared.DayBoundary
+
+// daysBetween returns the number of whole days from a to b (both from DayOf)
+func daysBetween(a, b time.Time) int {
+	return int(b.Sub(a).Hours() / 24)
+}
+
+// LoginStreak counts consecutive days the player entered the game
+type LoginStreak struct {
+	current int
+	longest int
+	lastDay *time.Time // last counted day, nil before the first login
+	freezes int        // streak-freeze items; each covers one missed day
+}
+
+// NewLoginStreak rebuilds a streak from persistence
+func NewLoginStreak(current, longest int, lastDay *time.Time, freezes int) LoginStreak {
+	return LoginStreak{current: current, longest: longest, lastDay: lastDay, freezes: freezes}
+}
+
+func (s LoginStreak) Current() int {
+	return s.current
+}
+
+func (s LoginStreak) Longest() int {
+	return s.longest
+}
+
+func (s LoginStreak) LastDay() *time.Time {
+	return s.lastDay
+}
+
+func (s LoginStreak) Freezes() int {
+	return s.freezes
+}
+
+// CurrentOn returns the streak as seen on today: it is still alive when the
+// player entered yesterday, or when freezes can cover every missed day
+func (s LoginStreak) CurrentOn(today time.Time) int {
+	if s.lastDay == nil {
+		return 0
+	}
+	missed := daysBetween(*s.lastDay, today) - 1
+	if missed <= s.freezes {
+		return s.current
+	}
+	return 0
+}
+
+// record counts a login on today. counted is false when today was already
+// counted; freezesUsed is how many freezes bridged missed days.
+func (s LoginStreak) record(today time.Time) (next LoginStreak, counted bool, freezesUsed int) {
+	next = s
+	if s.lastDay != nil && !today.After(*s.lastDay) {
+		return next, false, 0
+	}
+
+	switch {
+	case s.lastDay == nil:
+		next.current = 1
+	default:
+		missed := daysBetween(*s.lastDay, today) - 1
+		if missed <= s.freezes {
+			next.freezes -= missed
+			next.current++
+			freezesUsed = missed
+		} else {
+			next.current = 1
+		}
+	}
+
+	next.longest = max(next.longest, next.current)
+	next.lastDay = &today
+	return next, true, freezesUsed
+}
+
+// Boosts are spin perks granted by streak rewards
+type Boosts struct {
+	bonusSpins      int        // spins allowed beyond the daily limit
+	multiplier      float64    // applied to spin points while active
+	multiplierUntil *time.Time // nil when no multiplier is active
+}
+
+// NewBoosts rebuilds boosts from persistence
+func NewBoosts(bonusSpins int, multiplier float64, multiplierUntil *time.Time) Boosts {
+	return Boosts{bonusSpins: bonusSpins, multiplier: multiplier, multiplierUntil: multiplierUntil}
+}
+
+func (b Boosts) BonusSpins() int {
+	return b.bonusSpins
+}
+
+// MultiplierAt returns the points multiplier active at now (1 when none)
+func (b Boosts) MultiplierAt(now time.Time) float64 {
+	if b.multiplierUntil == nil || !now.Before(*b.multiplierUntil) || b.multiplier <= 0 {
+		return 1
+	}
+	return b.multiplier
+}
+
+// Multiplier returns the stored multiplier and its expiry
+func (b Boosts) Multiplier() (float64, *time.Time) {
+	return b.multiplier, b.multiplierUntil
+}
+
+// StreakRewardKind is what a streak reward grants
+type StreakRewardKind string
+
+const (
+	StreakRewardBonusSpins StreakRewardKind = "bonus_spins"       // Amount extra spins
+	StreakRewardMultiplier StreakRewardKind = "points_multiplier" // Multiplier for Duration
+	StreakRewardPoints     StreakRewardKind = "points"            // Amount points once
+	StreakRewardFreeze     StreakRewardKind = "freeze"            // Amount streak freezes
+)
+
+// StreakReward is granted when the streak reaches Day (and every Day days when Repeat)
+type StreakReward struct {
+	Day        int
+	Kind       StreakRewardKind
+	Amount     int
+	Multiplier float64
+	Duration   time.Duration
+	Repeat     bool
+}
+
+// StreakPolicy holds the configured streak rules
+type StreakPolicy struct {
+	Boundary   DayBoundary
+	MaxFreezes int
+	Rewards    []StreakReward
+}
+
+// RewardsFor returns the rewards earned on the given streak day
+func (p *StreakPolicy) RewardsFor(day int) []StreakReward {
+	var earned []StreakReward
+	for _, r := range p.Rewards {
+		if r.Day == day || (r.Repeat && day%r.Day == 0) {
+			earned = append(earned, r)
+		}
+	}
+	return earned
+}
@@ -1,44 +1,141 @@
 package player
 
 import (
+	"time"
+
 	"backend/internal/infrastructure/config"
+	historydomain "backend/internal/modules/history/domain"
 	"backend/internal/modules/player/adapter/handler"
-	"backend/internal/modules/player/adapter/repository"
+	"backend/internal/modules/player/application/change_locale"
+	"backend/internal/modules/player/application/change_status"
 	"backend/internal/modules/player/application/enter"
 	"backend/internal/modules/player/application/get_profile"
+	"backend/internal/modules/player/application/get_referrals"
+	"backend/internal/modules/player/application/get_stats"
+	"backend/internal/modules/player/application/release_nicknames"
+	"backend/internal/modules/player/application/rename"
+	"backend/internal/modules/player/application/track_referral"
 	"backend/internal/modules/player/domain"
+	rewarddomain "backend/internal/modules/reward/domain"
+	shared "backend/internal/shared/domain"
+	"backend/internal/shared/events"
+	httputil "backend/internal/shared/http"
+	"backend/internal/shared/i18n"
+	"backend/internal/shared/retry"
 
 	"github.com/gofiber/fiber/v2"
-	"gorm.io/gorm"
 )
 
 // Module wires all player dependencies
 type Module struct {
-	Handler    *handler.PlayerHandler
-	PlayerRepo domain.PlayerRepository
+	Handler      *handler.PlayerHandler
+	PlayerRepo   domain.PlayerRepository
+	ReferralRepo domain.ReferralRepository
+
+	// ReferralTracker pays referrers; subscribe its HandleEvent to game.spin_executed
+	ReferralTracker *track_referral.UseCase
+
+	// NicknameReleaser frees deleted players' nicknames; run it as a background worker
+	NicknameReleaser *release_nicknames.UseCase
 }
 
-func NewModule(db *gorm.DB, cfg *config.Config, rewardTxRepo interface{}) *Module {
-	// Create factory with config
-	factory := domain.NewPlayerFactory(
+// NewModule wires the module on the given repositories (profile and stats
+// read the history and reward repositories); bundle lists the locales players
+// may prefer
+func NewModule(
+	cfg *config.Config,
+	bundle *i18n.Bundle,
+	repo domain.PlayerRepository,
+	referralRepo domain.ReferralRepository,
+	rewardTxRepo rewarddomain.RewardTransactionRepository,
+	spinLogRepo historydomain.SpinLogRepository,
+	rewardConfigRepo rewarddomain.RewardConfigRepository,
+	tx shared.Transactor,
+	publisher events.Publisher,
+) *Module {
+	factory := NewFactory(cfg)
+
+	// Create usecases
+	streakPolicy := newStreakPolicy(cfg.Streaks)
+	referralPolicy := newReferralPolicy(cfg.Referrals)
+	retryPolicy := retry.Policy{MaxAttempts: cfg.Players.UpdateMaxAttempts, Backoff: cfg.Players.UpdateRetryBackoff}
+	enterUC := enter.New(repo, factory, streakPolicy, referralRepo, referralPolicy, spinLogRepo, tx, publisher, retryPolicy)
+	getProfileUC := get_profile.New(repo, rewardTxRepo, streakPolicy)
+	getStatsUC := get_stats.New(repo, spinLogRepo, rewardTxRepo, rewardConfigRepo, streakPolicy.Boundary)
+	getReferralsUC := get_referrals.New(repo, referralRepo, referralPolicy, cfg.Referrals.SummaryLimit)
+	renameUC := rename.New(repo, factory, cfg.Players.RenameCooldown, publisher)
+	changeStatusUC := change_status.New(repo, publisher)
+	changeLocaleUC := change_locale.New(repo, bundle)
+	h := handler.NewPlayerHandler(enterUC, getProfileUC, getStatsUC, getReferralsUC, renameUC, changeStatusUC, changeLocaleUC)
+
+	return &Module{
+		Handler:          h,
+		PlayerRepo:       repo,
+		ReferralRepo:     referralRepo,
+		ReferralTracker:  track_referral.New(repo, referralRepo, tx, referralPolicy, publisher, retryPolicy),
+		NicknameReleaser: release_nicknames.New(repo, cfg.Players.NicknameGracePeriod),
+	}
+}
+
+// NewFactory creates the player factory from the nickname rules; player
+// repositories need it to reconstruct players
+func NewFactory(cfg *config.Config) *domain.PlayerFactory {
+	return domain.NewPlayerFactory(
 		cfg.Validation.Nickname.MinLength,
 		cfg.Validation.Nickname.MaxLength,
 	)
+}
 
-	// Create repository
-	repo := repository.NewPlayerRepositoryGorm(db, factory)
+// newReferralPolicy converts the referral config to the domain policy
+func newReferralPolicy(cfg config.ReferralsConfig) *domain.ReferralPolicy {
+	return &domain.ReferralPolicy{
+		Milestone: domain.ReferralMilestone{
+			Spins:  cfg.MilestoneSpins,
+			Points: cfg.MilestonePoints,
+		},
+		RewardPoints:         cfg.RewardPoints,
+		MaxPerReferrerPerDay: cfg.MaxPerReferrerPerDay,
+		MaxPerIPPerDay:       cfg.MaxPerIPPerDay,
+	}
+}
 
-	// Create usecases
-	enterUC := enter.New(repo, factory)
-	getProfileUC := get_profile.New(repo, rewardTxRepo)
-	h := handler.NewPlayerHandler(enterUC, getProfileUC)
+// newStreakPolicy converts the streak config to the domain policy (validated at startup)
+func newStreakPolicy(cfg config.StreaksConfig) *domain.StreakPolicy {
+	loc, err := time.LoadLocation(cfg.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
 
-	return &Module{
-		Handler:    h,
-		PlayerRepo: repo,
+	rewards := make([]domain.StreakReward, len(cfg.Rewards))
+	for i, r := range cfg.Rewards {
+		rewards[i] = domain.StreakReward{
+			Day:        r.Day,
+			Kind:       domain.StreakRewardKind(r.Type),
+			Amount:     r.Amount,
+			Multiplier: r.Multiplier,
+			Duration:   r.Duration,
+			Repeat:     r.Repeat,
+		}
 	}
+
+	return &domain.StreakPolicy{
+		Boundary:   domain.DayBoundary{Location: loc, Offset: cfg.DayStartsAt},
+		MaxFreezes: cfg.MaxFreezes,
+		Rewards:    rewards,
+	}
+}
+
+// RegisterRoutes registers player routes on a versioned API router
+func (m *Module) RegisterRoutes(router fiber.Router) {
+	m.Handler.RegisterRoutes(router)
+}
+
+// RegisterAdminRoutes registers admin routes on a router guarded by admin auth
+func (m *Module) RegisterAdminRoutes(admin fiber.Router) {
+	m.Handler.RegisterAdminRoutes(admin)
 }
 
-func (m *Module) RegisterRoutes(app *fiber.App) {
-	m.Handler.RegisterRoutes(app)
+// RegisterErrors registers the player module's errors for the HTTP error handler
+func (m *Module) RegisterErrors(r *httputil.ErrorRegistry) {
+	handler.RegisterErrors(r)
 }
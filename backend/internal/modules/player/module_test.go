@@ -0,0 +1,103 @@
+package player_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"backend/internal/infrastructure/config"
+	"backend/internal/infrastructure/database"
+	historyrepo "backend/internal/modules/history/adapter/repository"
+	"backend/internal/modules/player"
+	playerrepo "backend/internal/modules/player/adapter/repository"
+	rewardrepo "backend/internal/modules/reward/adapter/repository"
+	"backend/internal/shared/cursor"
+	"backend/internal/shared/events"
+	httputil "backend/internal/shared/http"
+	"backend/internal/shared/i18n"
+)
+
+// envelope is httputil.Response with the data left raw
+type envelope struct {
+	Success bool                `json:"success"`
+	Data    json.RawMessage     `json:"data"`
+	Error   *httputil.ErrorBody `json:"error"`
+}
+
+func TestResponsesUseEnvelope(t *testing.T) {
+	cfg := &config.Config{
+		Validation: config.ValidationConfig{Nickname: config.NicknameValidationConfig{MinLength: 3, MaxLength: 20}},
+		Players:    config.PlayersConfig{UpdateMaxAttempts: 1},
+		Streaks:    config.StreaksConfig{Timezone: "UTC"},
+	}
+	bundle, err := i18n.Load("en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	codec := cursor.NewCodec("secret")
+	players := playerrepo.NewPlayerRepositoryMemory(player.NewFactory(cfg))
+	configs := rewardrepo.NewRewardConfigRepositoryMemory(nil)
+	m := player.NewModule(
+		cfg,
+		bundle,
+		players,
+		playerrepo.NewReferralRepositoryMemory(players),
+		rewardrepo.NewRewardTransactionRepositoryMemory(configs, players, codec),
+		historyrepo.NewSpinLogRepositoryMemory(players, codec),
+		configs,
+		database.NoTransactor{},
+		events.NewBus(),
+	)
+
+	registry := httputil.NewErrorRegistry()
+	m.RegisterErrors(registry)
+	app := fiber.New(fiber.Config{ErrorHandler: httputil.ErrorHandler(registry)})
+	m.RegisterRoutes(app.Group("/v1"))
+
+	call := func(t *testing.T, method, path, body string, status int) envelope {
+		t.Helper()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		res, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var env envelope
+		if err := json.NewDecoder(res.Body).Decode(&env); err != nil {
+			t.Fatalf("%s %s: decode: %v", method, path, err)
+		}
+		if res.StatusCode != status || env.Success != (status < 400) {
+			t.Fatalf("%s %s: status %d success %v, want %d", method, path, res.StatusCode, env.Success, status)
+		}
+		return env
+	}
+
+	var entered struct {
+		ID    string `json:"id"`
+		IsNew bool   `json:"is_new"`
+	}
+	env := call(t, fiber.MethodPost, "/v1/players/enter", `{"nickname":"alice"}`, fiber.StatusCreated)
+	if err := json.Unmarshal(env.Data, &entered); err != nil || entered.ID == "" || !entered.IsNew {
+		t.Fatalf("created data = %s, want a new player", env.Data)
+	}
+	env = call(t, fiber.MethodPost, "/v1/players/enter", `{"nickname":"alice"}`, fiber.StatusOK)
+	var resumed struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(env.Data, &resumed); err != nil || resumed.ID != entered.ID {
+		t.Fatalf("resumed data = %s, want player %s", env.Data, entered.ID)
+	}
+
+	for _, path := range []string{"/v1/players/" + entered.ID, "/v1/players/" + entered.ID + "/stats", "/v1/players/" + entered.ID + "/referrals"} {
+		if env := call(t, fiber.MethodGet, path, "", fiber.StatusOK); len(env.Data) == 0 {
+			t.Errorf("GET %s: no data", path)
+		}
+	}
+
+	if env := call(t, fiber.MethodGet, "/v1/players/00000000-0000-0000-0000-000000000000", "", fiber.StatusNotFound); env.Error == nil || env.Data != nil {
+		t.Errorf("not found = %+v, want an error and no data", env)
+	}
+}
@@ -0,0 +1,13 @@
+package handler
+
+import (
+	"backend/internal/modules/privacy/domain"
+	"backend/internal/shared/constants"
+	httputil "backend/internal/shared/http"
+)
+
+// RegisterErrors maps the privacy module's errors to HTTP responses
+func RegisterErrors(r *httputil.ErrorRegistry) {
+	r.Register(domain.ErrInvalidExportFormat, constants.StatusBadRequest, constants.ErrCodeValidationFailed, "")
+	r.Register(domain.ErrReasonRequired, constants.StatusBadRequest, constants.ErrCodeValidationFailed, "")
+}
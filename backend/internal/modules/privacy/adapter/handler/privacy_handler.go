@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"backend/internal/modules/privacy/application"
+	"backend/internal/modules/privacy/application/erase_player"
+	"backend/internal/modules/privacy/application/export_data"
+	shared "backend/internal/shared/domain"
+	httputil "backend/internal/shared/http"
+)
+
+type PrivacyHandler struct {
+	exportUC *export_data.UseCase
+	eraseUC  *erase_player.UseCase
+}
+
+// NewPrivacyHandler creates a new privacy handler
+func NewPrivacyHandler(exportUC *export_data.UseCase, eraseUC *erase_player.UseCase) *PrivacyHandler {
+	return &PrivacyHandler{
+		exportUC: exportUC,
+		eraseUC:  eraseUC,
+	}
+}
+
+// Export handles GET /players/:id/export
+// @Summary Export player data
+// @Description Download everything stored about a player: profile, spin logs, reward claims, referrals and the compliance log. Each export is recorded in the compliance log.
+// @Tags Privacy
+// @Produce json
+// @Produce application/zip
+// @Param id path string true "Player ID"
+// @Param format query string false "Bundle format: one JSON document or a ZIP of JSON files" Enums(json, zip) default(json)
+// @Success 200 {object} application.DataBundle "Bundle file (not wrapped in the response envelope)"
+// @Failure 400 {object} object "Invalid format"
+// @Failure 404 {object} object "Player not found"
+// @Failure 500 {object} object "Internal server error"
+// @Router /players/{id}/export [get]
+func (h *PrivacyHandler) Export(c *fiber.Ctx) error {
+	req := application.ExportRequest{
+		PlayerID: c.Params("id"),
+		Format:   c.Query("format"),
+	}
+
+	export, err := h.exportUC.Execute(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, export.ContentType)
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+export.Filename+`"`)
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.Send(export.Body)
+}
+
+// Erase handles POST /admin/players/:id/erase
+// @Summary Erase player data (admin)
+// @Description Pseudonymise the nickname, delete the account and detach personal data. Points, spin logs and reward claims are kept. Each erasure is recorded in the compliance log.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Player ID"
+// @Param X-Admin-Key header string true "Admin API key"
+// @Param request body application.EraseRequest true "Erasure reason"
+// @Success 200 {object} httputil.Response{data=application.EraseResponse} "Data erased"
+// @Failure 400 {object} object "Missing reason"
+// @Failure 401 {object} object "Missing or invalid admin key"
+// @Failure 404 {object} object "Player not found"
+// @Failure 409 {object} object "Already erased, or player updated concurrently"
+// @Failure 500 {object} object "Internal server error"
+// @Router /admin/players/{id}/erase [post]
+func (h *PrivacyHandler) Erase(c *fiber.Ctx) error {
+	var req application.EraseRequest
+	if err := c.BodyParser(&req); err != nil {
+		return shared.NewValidationError("Invalid request body")
+	}
+	req.PlayerID = c.Params("id")
+
+	resp, err := h.eraseUC.Execute(c.UserContext(), req)
+	if err != nil {
+		return err
+	}
+
+	return httputil.Success(c, fiber.StatusOK, resp)
+}
@@ -0,0 +1,13 @@
+package handler
+
+import "github.com/gofiber/fiber/v2"
+
+// RegisterRoutes registers privacy routes
+func (h *PrivacyHandler) RegisterRoutes(router fiber.Router) {
+	router.Get("/players/:id/export", h.Export)
+}
+
+// RegisterAdminRoutes registers privacy admin routes on an authenticated router
+func (h *PrivacyHandler) RegisterAdminRoutes(admin fiber.Router) {
+	admin.Post("/players/:id/erase", h.Erase)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"backend/internal/shared/constants"
+	"time"
+)
+
+// ComplianceLogModel is the GORM database model
+type ComplianceLogModel struct {
+	ID          string    `gorm:"type:uuid;primaryKey"`
+	PlayerID    string    `gorm:"type:uuid;not null;index"`
+	RequestType string    `gorm:"type:varchar(20);not null"`
+	RequestedBy string    `gorm:"type:varchar(20);not null"`
+	Reason      string    `gorm:"type:text;not null;default:''"`
+	CreatedAt   time.Time `gorm:"not null"`
+}
+
+func (ComplianceLogModel) TableName() string {
+	return constants.TableComplianceLog
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"backend/internal/infrastructure/database"
+	"backend/internal/modules/privacy/domain"
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// ComplianceLogRepositoryGorm implements domain.ComplianceLogRepository
+type ComplianceLogRepositoryGorm struct {
+	db *gorm.DB
+}
+
+func NewComplianceLogRepositoryGorm(db *gorm.DB) *ComplianceLogRepositoryGorm {
+	return &ComplianceLogRepositoryGorm{db: db}
+}
+
+// Store appends an entry
+func (r *ComplianceLogRepositoryGorm) Store(ctx context.Context, entry *domain.ComplianceEntry) error {
+	return database.Conn(ctx, r.db).Create(&ComplianceLogModel{
+		ID:          entry.ID(),
+		PlayerID:    entry.PlayerID(),
+		RequestType: string(entry.RequestType()),
+		RequestedBy: string(entry.RequestedBy()),
+		Reason:      entry.Reason(),
+		CreatedAt:   entry.CreatedAt(),
+	}).Error
+}
+
+// ListByPlayer returns a player's entries, oldest first
+func (r *ComplianceLogRepositoryGorm) ListByPlayer(ctx context.Context, playerID string) ([]*domain.ComplianceEntry, error) {
+	var models []ComplianceLogModel
+	err := database.Conn(ctx, r.db).
+		Where("player_id = ?", playerID).
+		Order("created_at ASC, id ASC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*domain.ComplianceEntry, len(models))
+	for i, m := range models {
+		entries[i] = domain.ReconstructComplianceEntry(
+			m.ID,
+			m.PlayerID,
+			domain.RequestType(m.RequestType),
+			domain.Requester(m.RequestedBy),
+			m.Reason,
+			m.CreatedAt,
+		)
+	}
+	return entries, nil
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"backend/internal/modules/privacy/domain"
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+)
+
+// ComplianceLogRepositoryMemory implements domain.ComplianceLogRepository in process memory
+type ComplianceLogRepositoryMemory struct {
+	mu      sync.RWMutex
+	entries []*domain.ComplianceEntry
+}
+
+func NewComplianceLogRepositoryMemory() *ComplianceLogRepositoryMemory {
+	return &ComplianceLogRepositoryMemory{}
+}
+
+// Store appends an entry
+func (r *ComplianceLogRepositoryMemory) Store(ctx context.Context, entry *domain.ComplianceEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+// ListByPlayer returns a player's entries, oldest first
+func (r *ComplianceLogRepositoryMemory) ListByPlayer(ctx context.Context, playerID string) ([]*domain.ComplianceEntry, error) {
+	r.mu.RLock()
+	var entries []*domain.ComplianceEntry
+	for _, entry := range r.entries {
+		if entry.PlayerID() == playerID {
+			entries = append(entries, entry)
+		}
+	}
+	r.mu.RUnlock()
+
+	slices.SortStableFunc(entries, func(a, b *domain.ComplianceEntry) int {
+		if c := a.CreatedAt().Compare(b.CreatedAt()); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID(), b.ID())
+	})
+	return entries, nil
+}
@@ -0,0 +1,112 @@
+package application
+
+import "time"
+
+// ExportRequest for GET /players/:id/export
+type ExportRequest struct {
+	PlayerID string `params:"id"`
+	Format   string `query:"format"` // json (default) or zip
+}
+
+// DataExport is a rendered export ready to download
+type DataExport struct {
+	Filename    string
+	ContentType string
+	Body        []byte
+}
+
+// DataBundle is everything stored about a player
+type DataBundle struct {
+	GeneratedAt        time.Time            `json:"generated_at"`
+	Player             PlayerDataDTO        `json:"player"`
+	SpinLogs           []SpinLogDataDTO     `json:"spin_logs"`
+	RewardTransactions []RewardDataDTO      `json:"reward_transactions"`
+	Referrals          ReferralsDataDTO     `json:"referrals"`
+	ComplianceLog      []ComplianceEntryDTO `json:"compliance_log"`
+}
+
+// PlayerDataDTO is the stored player record
+type PlayerDataDTO struct {
+	ID                  string     `json:"id"`
+	Nickname            string     `json:"nickname"`
+	InviteCode          string     `json:"invite_code"`
+	TotalPoints         int        `json:"total_points"`
+	Status              string     `json:"status"`
+	StatusReason        string     `json:"status_reason,omitempty"`
+	SuspendedUntil      *time.Time `json:"suspended_until,omitempty"`
+	StatusChangedAt     *time.Time `json:"status_changed_at,omitempty"`
+	NicknameChangedAt   *time.Time `json:"nickname_changed_at,omitempty"`
+	ErasedAt            *time.Time `json:"erased_at,omitempty"`
+	CurrentStreak       int        `json:"current_streak"`
+	LongestStreak       int        `json:"longest_streak"`
+	LastLoginDay        *time.Time `json:"last_login_day,omitempty"`
+	StreakFreezes       int        `json:"streak_freezes"`
+	BonusSpins          int        `json:"bonus_spins"`
+	PointsMultiplier    float64    `json:"points_multiplier"`
+	MultiplierExpiresAt *time.Time `json:"multiplier_expires_at,omitempty"`
+	Locale              string     `json:"locale,omitempty"`
+	SignupIP            string     `json:"signup_ip,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+// SpinLogDataDTO is one spin, bonus or reward points entry
+type SpinLogDataDTO struct {
+	ID           string    `json:"id"`
+	PointsGained int       `json:"points_gained"`
+	Source       string    `json:"source"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// RewardDataDTO is one reward claim
+type RewardDataDTO struct {
+	ID            string    `json:"id"`
+	CheckpointVal int       `json:"checkpoint_val"`
+	RewardName    string    `json:"reward_name"`
+	ClaimedAt     time.Time `json:"claimed_at"`
+}
+
+// ReferralsDataDTO holds who referred the player and whom they referred.
+// Referred players are listed by ID only; their nicknames are their own data.
+type ReferralsDataDTO struct {
+	ReferredBy *ReferralDataDTO  `json:"referred_by,omitempty"`
+	Referred   []ReferralDataDTO `json:"referred"`
+}
+
+// ReferralDataDTO is one referral
+type ReferralDataDTO struct {
+	ReferrerID    string     `json:"referrer_id"`
+	RefereeID     string     `json:"referee_id"`
+	InviteCode    string     `json:"invite_code"`
+	IPAddress     string     `json:"ip_address,omitempty"` // only on the player's own signup
+	Status        string     `json:"status"`
+	RefereeSpins  int        `json:"referee_spins"`
+	RefereePoints int        `json:"referee_points"`
+	RewardPoints  int        `json:"reward_points"`
+	CreatedAt     time.Time  `json:"created_at"`
+	RewardedAt    *time.Time `json:"rewarded_at,omitempty"`
+}
+
+// ComplianceEntryDTO is one export or erasure request
+type ComplianceEntryDTO struct {
+	ID          string    `json:"id"`
+	RequestType string    `json:"request_type"`
+	RequestedBy string    `json:"requested_by"`
+	Reason      string    `json:"reason,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// EraseRequest for POST /admin/players/:id/erase
+type EraseRequest struct {
+	PlayerID string `json:"-"`
+	Reason   string `json:"reason" validate:"required,max=500"` // e.g. the ticket of the player's request
+}
+
+// EraseResponse is output for erase usecase
+type EraseResponse struct {
+	ID          string    `json:"id"`
+	Nickname    string    `json:"nickname"` // the pseudonym
+	Status      string    `json:"status"`
+	TotalPoints int       `json:"total_points"`
+	ErasedAt    time.Time `json:"erased_at"`
+}
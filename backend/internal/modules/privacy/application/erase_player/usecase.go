@@ -0,0 +1,131 @@
+package erase_player
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	playerdomain "backend/internal/modules/player/domain"
+	"backend/internal/modules/privacy/application"
+	"backend/internal/modules/privacy/domain"
+	shared "backend/internal/shared/domain"
+	"backend/internal/shared/events"
+	"backend/internal/shared/logging"
+	"backend/internal/shared/retry"
+	"backend/internal/shared/tracing"
+)
+
+// maxReasonLength bounds the stored reason
+const maxReasonLength = 500
+
+// UseCase handles erasure requests: personal data is pseudonymised or
+// detached, while points, spin logs and reward claims are kept for accounting
+type UseCase struct {
+	playerRepo     playerdomain.PlayerRepository
+	referralRepo   playerdomain.ReferralRepository
+	complianceRepo domain.ComplianceLogRepository
+	tx             shared.Transactor
+	publisher      events.Publisher
+	retry          retry.Policy
+	now            func() time.Time
+}
+
+func New(
+	playerRepo playerdomain.PlayerRepository,
+	referralRepo playerdomain.ReferralRepository,
+	complianceRepo domain.ComplianceLogRepository,
+	tx shared.Transactor,
+	publisher events.Publisher,
+	retryPolicy retry.Policy,
+) *UseCase {
+	return &UseCase{
+		playerRepo:     playerRepo,
+		referralRepo:   referralRepo,
+		complianceRepo: complianceRepo,
+		tx:             tx,
+		publisher:      publisher,
+		retry:          retryPolicy,
+		now:            time.Now,
+	}
+}
+
+// Execute erases the player's personal data
+func (uc *UseCase) Execute(ctx context.Context, req application.EraseRequest) (_ *application.EraseResponse, err error) {
+	ctx, span := tracing.Start(ctx, "privacy.erase_player.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	// Validate request
+	playerID, err := playerdomain.NewPlayerID(req.PlayerID)
+	if err != nil {
+		return nil, shared.ErrPlayerNotFound
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, domain.ErrReasonRequired
+	}
+	if len(reason) > maxReasonLength {
+		return nil, fmt.Errorf("%w: reason must be at most %d characters", domain.ErrReasonRequired, maxReasonLength)
+	}
+
+	// Erase, retried from a fresh load when a concurrent update wins
+	var player *playerdomain.Player
+	var entry *domain.ComplianceEntry
+	err = uc.retry.Do(ctx, func(ctx context.Context) error {
+		var err error
+		player, entry, err = uc.erase(ctx, playerID, reason)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.publisher.Publish(ctx, player.DomainEvents()...)
+	player.ClearEvents()
+
+	logging.FromContext(ctx).InfoContext(ctx, "player data erased",
+		"player_id", playerID.String(),
+		"compliance_entry", entry.ID(),
+	)
+
+	lifecycle := player.Lifecycle()
+	return &application.EraseResponse{
+		ID:          player.ID().String(),
+		Nickname:    player.Nickname().String(),
+		Status:      string(lifecycle.Status()),
+		TotalPoints: player.TotalPoints().Value(),
+		ErasedAt:    *lifecycle.ErasedAt(),
+	}, nil
+}
+
+// erase loads and erases the player and, in the same transaction, records the
+// erasure and detaches referral data, so the log and the data never disagree
+func (uc *UseCase) erase(ctx context.Context, playerID *playerdomain.PlayerID, reason string) (*playerdomain.Player, *domain.ComplianceEntry, error) {
+	var player *playerdomain.Player
+	var entry *domain.ComplianceEntry
+	err := uc.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Find player (shared.ErrPlayerNotFound when missing)
+		var err error
+		player, err = uc.playerRepo.FindByID(ctx, playerID)
+		if err != nil {
+			return err
+		}
+		if err := player.Erase(uc.now()); err != nil {
+			return err
+		}
+
+		// shared.ErrConcurrentModification when another update won
+		if err := uc.playerRepo.Update(ctx, player); err != nil {
+			return err
+		}
+
+		entry = domain.NewComplianceEntry(playerID.String(), domain.RequestErasure, domain.RequestedByAdmin, reason)
+		if err := uc.complianceRepo.Store(ctx, entry); err != nil {
+			return err
+		}
+		return uc.referralRepo.DetachPersonalData(ctx, playerID.String())
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return player, entry, nil
+}
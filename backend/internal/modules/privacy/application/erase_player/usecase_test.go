@@ -0,0 +1,144 @@
+package erase_player
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"backend/internal/infrastructure/database"
+	playerrepo "backend/internal/modules/player/adapter/repository"
+	playerdomain "backend/internal/modules/player/domain"
+	"backend/internal/modules/privacy/adapter/repository"
+	"backend/internal/modules/privacy/application"
+	"backend/internal/modules/privacy/domain"
+	shared "backend/internal/shared/domain"
+	"backend/internal/shared/retry"
+)
+
+// racingPlayerRepo lets another request credit the player just before each of
+// the first races updates, so those updates lose on the version check
+type racingPlayerRepo struct {
+	*playerrepo.PlayerRepositoryMemory
+	races int
+}
+
+func (r *racingPlayerRepo) Update(ctx context.Context, player *playerdomain.Player) error {
+	if r.races > 0 {
+		r.races--
+		other, err := r.PlayerRepositoryMemory.FindByID(ctx, player.ID())
+		if err != nil {
+			return err
+		}
+		points, _ := shared.NewPoints(10)
+		if err := other.AddPoints(points); err != nil {
+			return err
+		}
+		if err := r.PlayerRepositoryMemory.Update(ctx, other); err != nil {
+			return err
+		}
+	}
+	return r.PlayerRepositoryMemory.Update(ctx, player)
+}
+
+type noopPublisher struct{}
+
+func (noopPublisher) Publish(ctx context.Context, events ...shared.DomainEvent) {}
+
+type fixture struct {
+	players    *racingPlayerRepo
+	referrals  *playerrepo.ReferralRepositoryMemory
+	compliance *repository.ComplianceLogRepositoryMemory
+	referee    *playerdomain.Player
+	uc         *UseCase
+}
+
+// newFixture stores a referee who signed up with a referrer's code from an IP
+func newFixture(t *testing.T, races, attempts int) *fixture {
+	t.Helper()
+	ctx := context.Background()
+	factory := playerdomain.NewPlayerFactory(3, 50)
+	players := &racingPlayerRepo{PlayerRepositoryMemory: playerrepo.NewPlayerRepositoryMemory(factory), races: races}
+	referrals := playerrepo.NewReferralRepositoryMemory(players.PlayerRepositoryMemory)
+	compliance := repository.NewComplianceLogRepositoryMemory()
+
+	referrer, _ := factory.CreateNewPlayer("alice")
+	referee, _ := factory.CreateNewPlayer("bob")
+	referrer.RecordSignupIP("198.51.100.4")
+	referee.RecordSignupIP("203.0.113.7")
+	for _, p := range []*playerdomain.Player{referrer, referee} {
+		if err := players.Store(ctx, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	referral, err := playerdomain.NewReferral(referrer, referee)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := referrals.Store(ctx, referral); err != nil {
+		t.Fatal(err)
+	}
+
+	uc := New(players, referrals, compliance, database.NoTransactor{}, noopPublisher{}, retry.Policy{MaxAttempts: attempts})
+	return &fixture{players: players, referrals: referrals, compliance: compliance, referee: referee, uc: uc}
+}
+
+func (f *fixture) erase() (*application.EraseResponse, error) {
+	return f.uc.Execute(context.Background(), application.EraseRequest{PlayerID: f.referee.ID().String(), Reason: "user request"})
+}
+
+func (f *fixture) assertLogged(t *testing.T, entries int, ip string) {
+	t.Helper()
+	ctx := context.Background()
+	logged, err := f.compliance.ListByPlayer(ctx, f.referee.ID().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logged) != entries {
+		t.Errorf("compliance entries = %d, want %d", len(logged), entries)
+	}
+	for _, e := range logged {
+		if e.RequestType() != domain.RequestErasure {
+			t.Errorf("request type = %s, want %s", e.RequestType(), domain.RequestErasure)
+		}
+	}
+	referral, err := f.referrals.FindByReferee(ctx, f.referee.ID().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if referral.IPAddress() != ip {
+		t.Errorf("referral IP = %q, want %q", referral.IPAddress(), ip)
+	}
+}
+
+func TestEraseRetriesAfterConcurrentUpdate(t *testing.T) {
+	f := newFixture(t, 1, 3)
+
+	resp, err := f.erase()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The concurrent credit is kept, not overwritten by a stale copy
+	if resp.TotalPoints != 10 {
+		t.Errorf("total points = %d, want 10", resp.TotalPoints)
+	}
+	f.assertLogged(t, 1, "")
+}
+
+func TestEraseLogsNothingWhenUpdateLoses(t *testing.T) {
+	f := newFixture(t, 2, 2)
+
+	_, err := f.erase()
+	if !errors.Is(err, shared.ErrConcurrentModification) {
+		t.Fatalf("err = %v, want %v", err, shared.ErrConcurrentModification)
+	}
+	// Nothing was erased, so nothing is logged or detached
+	f.assertLogged(t, 0, "203.0.113.7")
+
+	stored, err := f.players.FindByID(context.Background(), f.referee.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Nickname().String() != "bob" {
+		t.Errorf("nickname = %q, want unchanged", stored.Nickname().String())
+	}
+}
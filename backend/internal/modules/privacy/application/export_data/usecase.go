@@ -0,0 +1,280 @@
+package export_data
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	historydomain "backend/internal/modules/history/domain"
+	playerdomain "backend/internal/modules/player/domain"
+	"backend/internal/modules/privacy/application"
+	"backend/internal/modules/privacy/domain"
+	rewarddomain "backend/internal/modules/reward/domain"
+	shared "backend/internal/shared/domain"
+	"backend/internal/shared/logging"
+	"backend/internal/shared/tracing"
+)
+
+const (
+	FormatJSON = "json"
+	FormatZIP  = "zip"
+)
+
+// UseCase bundles everything stored about a player (data subject access)
+type UseCase struct {
+	playerRepo     playerdomain.PlayerRepository
+	referralRepo   playerdomain.ReferralRepository
+	spinLogRepo    historydomain.SpinLogRepository
+	rewardTxRepo   rewarddomain.RewardTransactionRepository
+	complianceRepo domain.ComplianceLogRepository
+	batchSize      int
+	now            func() time.Time
+}
+
+func New(
+	playerRepo playerdomain.PlayerRepository,
+	referralRepo playerdomain.ReferralRepository,
+	spinLogRepo historydomain.SpinLogRepository,
+	rewardTxRepo rewarddomain.RewardTransactionRepository,
+	complianceRepo domain.ComplianceLogRepository,
+	batchSize int,
+) *UseCase {
+	return &UseCase{
+		playerRepo:     playerRepo,
+		referralRepo:   referralRepo,
+		spinLogRepo:    spinLogRepo,
+		rewardTxRepo:   rewardTxRepo,
+		complianceRepo: complianceRepo,
+		batchSize:      batchSize,
+		now:            time.Now,
+	}
+}
+
+// Execute collects the player's data, records the request in the compliance
+// log and renders the bundle as one JSON document or a ZIP of JSON files
+func (uc *UseCase) Execute(ctx context.Context, req application.ExportRequest) (_ *application.DataExport, err error) {
+	ctx, span := tracing.Start(ctx, "privacy.export_data.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	// Validate request
+	format := req.Format
+	if format == "" {
+		format = FormatJSON
+	}
+	if format != FormatJSON && format != FormatZIP {
+		return nil, domain.ErrInvalidExportFormat
+	}
+	playerID, err := playerdomain.NewPlayerID(req.PlayerID)
+	if err != nil {
+		return nil, shared.ErrPlayerNotFound
+	}
+
+	// Collect (deleted and erased players may still ask for their data)
+	player, err := uc.playerRepo.FindByID(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := uc.now()
+	bundle := application.DataBundle{
+		GeneratedAt: now,
+		Player:      toPlayerData(player),
+	}
+	if bundle.SpinLogs, err = uc.spinLogs(ctx, playerID.String()); err != nil {
+		return nil, err
+	}
+	if bundle.RewardTransactions, err = uc.rewards(ctx, playerID.String()); err != nil {
+		return nil, err
+	}
+	if bundle.Referrals, err = uc.referrals(ctx, playerID.String()); err != nil {
+		return nil, err
+	}
+
+	// Record the request before handing anything out; the bundle includes it
+	entry := domain.NewComplianceEntry(playerID.String(), domain.RequestExport, domain.RequestedByPlayer, "")
+	if err := uc.complianceRepo.Store(ctx, entry); err != nil {
+		return nil, err
+	}
+	entries, err := uc.complianceRepo.ListByPlayer(ctx, playerID.String())
+	if err != nil {
+		return nil, err
+	}
+	bundle.ComplianceLog = make([]application.ComplianceEntryDTO, len(entries))
+	for i, e := range entries {
+		bundle.ComplianceLog[i] = application.ComplianceEntryDTO{
+			ID:          e.ID(),
+			RequestType: string(e.RequestType()),
+			RequestedBy: string(e.RequestedBy()),
+			Reason:      e.Reason(),
+			CreatedAt:   e.CreatedAt(),
+		}
+	}
+
+	logging.FromContext(ctx).InfoContext(ctx, "player data exported",
+		"player_id", playerID.String(),
+		"format", format,
+		"spin_logs", len(bundle.SpinLogs),
+		"reward_transactions", len(bundle.RewardTransactions),
+	)
+
+	filename := fmt.Sprintf("player-%s-%s.%s", playerID.String(), now.UTC().Format("20060102"), format)
+	if format == FormatZIP {
+		body, err := renderZIP(bundle)
+		if err != nil {
+			return nil, err
+		}
+		return &application.DataExport{Filename: filename, ContentType: "application/zip", Body: body}, nil
+	}
+
+	body, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return &application.DataExport{Filename: filename, ContentType: "application/json", Body: body}, nil
+}
+
+// spinLogs reads all of the player's spin logs, oldest first
+func (uc *UseCase) spinLogs(ctx context.Context, playerID string) ([]application.SpinLogDataDTO, error) {
+	logs := []application.SpinLogDataDTO{}
+	err := uc.spinLogRepo.StreamForExport(ctx, playerID, historydomain.SpinLogFilter{}, uc.batchSize, func(batch []*historydomain.SpinLogWithPlayer) error {
+		for _, item := range batch {
+			logs = append(logs, application.SpinLogDataDTO{
+				ID:           item.SpinLog.ID().String(),
+				PointsGained: item.SpinLog.PointsGained(),
+				Source:       string(item.SpinLog.Source()),
+				CreatedAt:    item.SpinLog.CreatedAt(),
+			})
+		}
+		return nil
+	})
+	return logs, err
+}
+
+// rewards reads all of the player's reward claims
+func (uc *UseCase) rewards(ctx context.Context, playerID string) ([]application.RewardDataDTO, error) {
+	claims, err := uc.rewardTxRepo.ListByPlayer(ctx, playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	rewards := make([]application.RewardDataDTO, len(claims))
+	for i, claim := range claims {
+		rewards[i] = application.RewardDataDTO{
+			ID:            claim.Transaction.ID().String(),
+			CheckpointVal: claim.Transaction.CheckpointVal(),
+			RewardName:    claim.RewardName,
+			ClaimedAt:     claim.Transaction.ClaimedAt(),
+		}
+	}
+	return rewards, nil
+}
+
+// referrals reads who referred the player and every player they referred
+func (uc *UseCase) referrals(ctx context.Context, playerID string) (application.ReferralsDataDTO, error) {
+	data := application.ReferralsDataDTO{Referred: []application.ReferralDataDTO{}}
+
+	referredBy, err := uc.referralRepo.FindByReferee(ctx, playerID)
+	switch {
+	case err == nil:
+		dto := toReferralData(referredBy)
+		dto.IPAddress = referredBy.IPAddress()
+		data.ReferredBy = &dto
+	case !errors.Is(err, playerdomain.ErrReferralNotFound):
+		return data, err
+	}
+
+	referred, err := uc.referralRepo.ListByReferrer(ctx, playerID, 0)
+	if err != nil {
+		return data, err
+	}
+	for _, entry := range referred {
+		data.Referred = append(data.Referred, toReferralData(entry.Referral))
+	}
+	return data, nil
+}
+
+func toPlayerData(player *playerdomain.Player) application.PlayerDataDTO {
+	streak := player.Streak()
+	boosts := player.Boosts()
+	multiplier, multiplierUntil := boosts.Multiplier()
+	lifecycle := player.Lifecycle()
+
+	return application.PlayerDataDTO{
+		ID:                  player.ID().String(),
+		Nickname:            player.Nickname().String(),
+		InviteCode:          player.InviteCode().String(),
+		TotalPoints:         player.TotalPoints().Value(),
+		Status:              string(lifecycle.Status()),
+		StatusReason:        lifecycle.Reason(),
+		SuspendedUntil:      lifecycle.SuspendedUntil(),
+		StatusChangedAt:     lifecycle.ChangedAt(),
+		NicknameChangedAt:   lifecycle.NicknameChangedAt(),
+		ErasedAt:            lifecycle.ErasedAt(),
+		CurrentStreak:       streak.Current(),
+		LongestStreak:       streak.Longest(),
+		LastLoginDay:        streak.LastDay(),
+		StreakFreezes:       streak.Freezes(),
+		BonusSpins:          boosts.BonusSpins(),
+		PointsMultiplier:    multiplier,
+		MultiplierExpiresAt: multiplierUntil,
+		Locale:              player.Locale(),
+		SignupIP:            player.SignupIP(),
+		CreatedAt:           player.CreatedAt(),
+		UpdatedAt:           player.UpdatedAt(),
+	}
+}
+
+// toReferralData maps a referral without the signup IP, which belongs to the referee
+func toReferralData(r *playerdomain.Referral) application.ReferralDataDTO {
+	return application.ReferralDataDTO{
+		ReferrerID:    r.ReferrerID(),
+		RefereeID:     r.RefereeID(),
+		InviteCode:    r.InviteCode(),
+		Status:        string(r.Status()),
+		RefereeSpins:  r.RefereeSpins(),
+		RefereePoints: r.RefereePoints(),
+		RewardPoints:  r.RewardPoints(),
+		CreatedAt:     r.CreatedAt(),
+		RewardedAt:    r.RewardedAt(),
+	}
+}
+
+// renderZIP writes each section of the bundle as its own JSON file
+func renderZIP(bundle application.DataBundle) ([]byte, error) {
+	files := []struct {
+		name string
+		data any
+	}{
+		{"player.json", bundle.Player},
+		{"spin_logs.json", bundle.SpinLogs},
+		{"reward_transactions.json", bundle.RewardTransactions},
+		{"referrals.json", bundle.Referrals},
+		{"compliance_log.json", bundle.ComplianceLog},
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range files {
+		w, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     f.name,
+			Method:   zip.Deflate,
+			Modified: bundle.GeneratedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(f.data); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
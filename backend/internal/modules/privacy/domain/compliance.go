@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Privacy errors
+var (
+	ErrInvalidExportFormat = errors.New("format must be json or zip")
+	ErrReasonRequired      = errors.New("a reason is required")
+)
+
+// RequestType is the kind of data subject request
+type RequestType string
+
+const (
+	RequestExport  RequestType = "export"
+	RequestErasure RequestType = "erasure"
+)
+
+// Requester is who made a request
+type Requester string
+
+const (
+	RequestedByPlayer Requester = "player"
+	RequestedByAdmin  Requester = "admin"
+)
+
+// ComplianceEntry records one export or erasure request. Entries are never
+// changed or removed, and outlive the player's personal data.
+type ComplianceEntry struct {
+	id          string
+	playerID    string
+	requestType RequestType
+	requestedBy Requester
+	reason      string
+	createdAt   time.Time
+}
+
+// NewComplianceEntry records a request made now
+func NewComplianceEntry(playerID string, requestType RequestType, requestedBy Requester, reason string) *ComplianceEntry {
+	return &ComplianceEntry{
+		id:          uuid.New().String(),
+		playerID:    playerID,
+		requestType: requestType,
+		requestedBy: requestedBy,
+		reason:      reason,
+		createdAt:   time.Now(),
+	}
+}
+
+// ReconstructComplianceEntry rebuilds an entry from persistence
+func ReconstructComplianceEntry(id, playerID string, requestType RequestType, requestedBy Requester, reason string, createdAt time.Time) *ComplianceEntry {
+	return &ComplianceEntry{
+		id:          id,
+		playerID:    playerID,
+		requestType: requestType,
+		requestedBy: requestedBy,
+		reason:      reason,
+		createdAt:   createdAt,
+	}
+}
+
+// Accessors (read-only)
+func (e *ComplianceEntry) ID() string {
+	return e.id
+}
+
+func (e *ComplianceEntry) PlayerID() string {
+	return e.playerID
+}
+
+func (e *ComplianceEntry) RequestType() RequestType {
+	return e.requestType
+}
+
+func (e *ComplianceEntry) RequestedBy() Requester {
+	return e.requestedBy
+}
+
+func (e *ComplianceEntry) Reason() string {
+	return e.reason
+}
+
+func (e *ComplianceEntry) CreatedAt() time.Time {
+	return e.createdAt
+}
+
+// ComplianceLogRepository defines persistence contract (append only)
+type ComplianceLogRepository interface {
+	// Store appends an entry
+	Store(ctx context.Context, entry *ComplianceEntry) error
+
+	// ListByPlayer returns a player's entries, oldest first
+	ListByPlayer(ctx context.Context, playerID string) ([]*ComplianceEntry, error)
+}
@@ -0,0 +1,59 @@
+package privacy
+
+import (
+	"backend/internal/infrastructure/config"
+	historydomain "backend/internal/modules/history/domain"
+	playerdomain "backend/internal/modules/player/domain"
+	"backend/internal/modules/privacy/adapter/handler"
+	"backend/internal/modules/privacy/application/erase_player"
+	"backend/internal/modules/privacy/application/export_data"
+	"backend/internal/modules/privacy/domain"
+	rewarddomain "backend/internal/modules/reward/domain"
+	shared "backend/internal/shared/domain"
+	"backend/internal/shared/events"
+	httputil "backend/internal/shared/http"
+	"backend/internal/shared/retry"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Module wires data export and erasure (needs the player, history and reward repos)
+type Module struct {
+	Handler *handler.PrivacyHandler
+}
+
+func NewModule(
+	cfg *config.Config,
+	complianceRepo domain.ComplianceLogRepository,
+	playerRepo playerdomain.PlayerRepository,
+	referralRepo playerdomain.ReferralRepository,
+	spinLogRepo historydomain.SpinLogRepository,
+	rewardTxRepo rewarddomain.RewardTransactionRepository,
+	tx shared.Transactor,
+	publisher events.Publisher,
+) *Module {
+	// Erasure updates the player, so it retries like the player module's updates
+	retryPolicy := retry.Policy{MaxAttempts: cfg.Players.UpdateMaxAttempts, Backoff: cfg.Players.UpdateRetryBackoff}
+
+	exportUC := export_data.New(playerRepo, referralRepo, spinLogRepo, rewardTxRepo, complianceRepo, cfg.Export.BatchSize)
+	eraseUC := erase_player.New(playerRepo, referralRepo, complianceRepo, tx, publisher, retryPolicy)
+
+	return &Module{
+		Handler: handler.NewPrivacyHandler(exportUC, eraseUC),
+	}
+}
+
+// RegisterRoutes registers privacy routes on a versioned API router
+func (m *Module) RegisterRoutes(router fiber.Router) {
+	m.Handler.RegisterRoutes(router)
+}
+
+// RegisterAdminRoutes registers admin routes on a router guarded by admin auth
+func (m *Module) RegisterAdminRoutes(admin fiber.Router) {
+	m.Handler.RegisterAdminRoutes(admin)
+}
+
+// RegisterErrors registers the privacy module's errors for the HTTP error handler
+func (m *Module) RegisterErrors(r *httputil.ErrorRegistry) {
+	handler.RegisterErrors(r)
+}
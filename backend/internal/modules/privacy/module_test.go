@@ -0,0 +1,70 @@
+package privacy_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"backend/internal/infrastructure/config"
+	"backend/internal/infrastructure/database"
+	historyrepo "backend/internal/modules/history/adapter/repository"
+	playerrepo "backend/internal/modules/player/adapter/repository"
+	playerdomain "backend/internal/modules/player/domain"
+	"backend/internal/modules/privacy"
+	privacyrepo "backend/internal/modules/privacy/adapter/repository"
+	rewardrepo "backend/internal/modules/reward/adapter/repository"
+	"backend/internal/shared/cursor"
+	"backend/internal/shared/events"
+	httputil "backend/internal/shared/http"
+)
+
+func TestEraseUsesEnvelope(t *testing.T) {
+	cfg := &config.Config{
+		Players: config.PlayersConfig{UpdateMaxAttempts: 1},
+		Export:  config.ExportConfig{BatchSize: 100},
+	}
+	codec := cursor.NewCodec("secret")
+	factory := playerdomain.NewPlayerFactory(3, 20)
+	players := playerrepo.NewPlayerRepositoryMemory(factory)
+	configs := rewardrepo.NewRewardConfigRepositoryMemory(nil)
+	m := privacy.NewModule(
+		cfg,
+		privacyrepo.NewComplianceLogRepositoryMemory(),
+		players,
+		playerrepo.NewReferralRepositoryMemory(players),
+		historyrepo.NewSpinLogRepositoryMemory(players, codec),
+		rewardrepo.NewRewardTransactionRepositoryMemory(configs, players, codec),
+		database.NoTransactor{},
+		events.NewBus(),
+	)
+	player, _ := factory.CreateNewPlayer("alice")
+	if err := players.Store(context.Background(), player); err != nil {
+		t.Fatal(err)
+	}
+
+	registry := httputil.NewErrorRegistry()
+	m.RegisterErrors(registry)
+	app := fiber.New(fiber.Config{ErrorHandler: httputil.ErrorHandler(registry)})
+	m.RegisterAdminRoutes(app.Group("/v1/admin"))
+
+	req := httptest.NewRequest(fiber.MethodPost, "/v1/admin/players/"+player.ID().String()+"/erase", strings.NewReader(`{"reason":"ticket 42"}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var env struct {
+		Success bool            `json:"success"`
+		Data    json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&env); err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != fiber.StatusOK || !env.Success || len(env.Data) == 0 {
+		t.Fatalf("status %d, body %+v; want 200 with data", res.StatusCode, env)
+	}
+}
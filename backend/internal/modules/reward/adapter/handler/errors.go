@@ -0,0 +1,14 @@
+package handler
+
+import (
+	"backend/internal/shared/constants"
+	shared "backend/internal/shared/domain"
+	httputil "backend/internal/shared/http"
+)
+
+// RegisterErrors maps the reward module's errors to HTTP responses
+func RegisterErrors(r *httputil.ErrorRegistry) {
+	r.Register(shared.ErrInvalidCheckpoint, constants.StatusBadRequest, constants.ErrCodeInvalidCheckpoint, "Invalid checkpoint value")
+	r.Register(shared.ErrInsufficientPoints, constants.StatusBadRequest, constants.ErrCodeInsufficientPoints, "Not enough points to claim this reward")
+	r.Register(shared.ErrAlreadyClaimed, constants.StatusConflict, constants.ErrCodeAlreadyClaimed, "Reward already claimed")
+}
@@ -1,14 +1,12 @@
 package handler
 
 import (
-	"errors"
-
 	"github.com/gofiber/fiber/v2"
 
 	"backend/internal/modules/reward/application"
 	"backend/internal/modules/reward/application/claim"
+	"backend/internal/modules/reward/application/export_rewards"
 	"backend/internal/modules/reward/application/get_history"
-	"backend/internal/shared/constants"
 	shared "backend/internal/shared/domain"
 	httputil "backend/internal/shared/http"
 )
@@ -16,13 +14,15 @@ import (
 type RewardHandler struct {
 	claimUC      *claim.UseCase
 	getHistoryUC *get_history.UseCase
+	exportUC     *export_rewards.UseCase
 }
 
 // NewRewardHandler creates a new reward handler
-func NewRewardHandler(claimUC *claim.UseCase, getHistoryUC *get_history.UseCase) *RewardHandler {
+func NewRewardHandler(claimUC *claim.UseCase, getHistoryUC *get_history.UseCase, exportUC *export_rewards.UseCase) *RewardHandler {
 	return &RewardHandler{
 		claimUC:      claimUC,
 		getHistoryUC: getHistoryUC,
+		exportUC:     exportUC,
 	}
 }
 
@@ -33,44 +33,32 @@ func NewRewardHandler(claimUC *claim.UseCase, getHistoryUC *get_history.UseCase)
 // @Accept json
 // @Produce json
 // @Param request body application.ClaimRequest true "Claim reward request"
-// @Success 200 {object} application.ClaimResponse
+// @Param Idempotency-Key header string false "Replays the first response when a claim is retried with the same key"
+// @Success 200 {object} httputil.Response{data=application.ClaimResponse}
 // @Failure 400 {object} object "Insufficient points or invalid checkpoint"
+// @Failure 403 {object} object "Player suspended, banned or deleted"
 // @Failure 404 {object} object "Player not found"
-// @Failure 409 {object} object "Already claimed"
+// @Failure 409 {object} object "Already claimed, or Idempotency-Key conflict"
 // @Failure 500 {object} object "Internal server error"
 // @Router /rewards/claim [post]
 func (h *RewardHandler) Claim(c *fiber.Ctx) error {
 	// 1. Parse request body
 	var req application.ClaimRequest
 	if err := c.BodyParser(&req); err != nil {
-		return httputil.BadRequest(c, constants.ErrCodeValidationFailed, "Invalid request body")
+		return shared.NewValidationError("Invalid request body")
 	}
 
 	// 2. Execute usecase
-	resp, err := h.claimUC.Execute(c.Context(), claim.Request{
+	resp, err := h.claimUC.Execute(c.UserContext(), claim.Request{
 		PlayerID:      req.PlayerID,
 		CheckpointVal: req.CheckpointVal,
 	})
-
-	// 3. Handle errors
 	if err != nil {
-		if errors.Is(err, shared.ErrPlayerNotFound) {
-			return httputil.NotFound(c, constants.ErrCodePlayerNotFound, "Player not found")
-		}
-		if errors.Is(err, shared.ErrInvalidCheckpoint) {
-			return httputil.BadRequest(c, "INVALID_CHECKPOINT", "Invalid checkpoint value")
-		}
-		if errors.Is(err, shared.ErrInsufficientPoints) {
-			return httputil.BadRequest(c, "INSUFFICIENT_POINTS", "Not enough points to claim this reward")
-		}
-		if errors.Is(err, shared.ErrAlreadyClaimed) {
-			return httputil.Conflict(c, "ALREADY_CLAIMED", "Reward already claimed")
-		}
-		return httputil.Error(c, constants.StatusInternalServerError, "INTERNAL_ERROR", "Failed to claim reward")
+		return err
 	}
 
-	// 4. Return success response
-	return c.JSON(application.ClaimResponse{
+	// 3. Return success response
+	return httputil.Success(c, fiber.StatusOK, application.ClaimResponse{
 		ID:            resp.ID,
 		CheckpointVal: resp.CheckpointVal,
 		RewardName:    resp.RewardName,
@@ -80,28 +68,37 @@ func (h *RewardHandler) Claim(c *fiber.Ctx) error {
 
 // GetHistory handles GET /rewards/:player_id
 // @Summary Get reward claim history
-// @Description Get all rewards claimed by a player
+// @Description Get cursor-paginated rewards claimed by a player, newest first
 // @Tags Rewards
 // @Accept json
 // @Produce json
 // @Param player_id path string true "Player ID"
-// @Success 200 {object} application.GetHistoryResponse
-// @Failure 400 {object} object "Invalid player ID"
+// @Param limit query int false "Number of items per page" default(20)
+// @Param cursor query string false "next_cursor or prev_cursor from a previous response"
+// @Success 200 {object} httputil.Response{data=application.GetHistoryResponse}
+// @Failure 400 {object} object "Invalid player ID or cursor"
 // @Failure 500 {object} object "Internal server error"
 // @Router /rewards/{player_id} [get]
 func (h *RewardHandler) GetHistory(c *fiber.Ctx) error {
 	// 1. Parse player_id from URL
 	playerID := c.Params("player_id")
 	if playerID == "" {
-		return httputil.BadRequest(c, constants.ErrCodeValidationFailed, "Player ID is required")
+		return shared.NewValidationError("Player ID is required")
+	}
+
+	var req application.GetHistoryRequest
+	if err := c.QueryParser(&req); err != nil {
+		return shared.Invalid(err)
 	}
 
 	// 2. Execute usecase
-	resp, err := h.getHistoryUC.Execute(c.Context(), get_history.Request{
+	resp, err := h.getHistoryUC.Execute(c.UserContext(), get_history.Request{
 		PlayerID: playerID,
+		Limit:    req.Limit,
+		Cursor:   req.Cursor,
 	})
 	if err != nil {
-		return httputil.Error(c, constants.StatusInternalServerError, "INTERNAL_ERROR", "Failed to get reward history")
+		return err
 	}
 
 	// 3. Map to response
@@ -117,7 +114,46 @@ func (h *RewardHandler) GetHistory(c *fiber.Ctx) error {
 	}
 
 	// 4. Return response
-	return c.JSON(application.GetHistoryResponse{
-		Data: dtos,
+	return httputil.Success(c, fiber.StatusOK, application.GetHistoryResponse{
+		Data:       dtos,
+		NextCursor: resp.NextCursor,
+		PrevCursor: resp.PrevCursor,
+		HasMore:    resp.HasMore,
+		HasPrev:    resp.HasPrev,
 	})
 }
+
+// Export handles GET /admin/rewards/export
+// @Summary Export reward claims (admin)
+// @Description Stream all reward claims matching the filters as CSV or NDJSON, oldest first. Columns and timezone default to export.yaml.
+// @Tags Admin
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param X-Admin-Key header string true "Admin API key"
+// @Param format query string false "Output format" Enums(csv, ndjson) default(csv)
+// @Param columns query string false "Comma-separated columns (id, player_id, nickname, checkpoint_val, reward_name, reward_description, claimed_at)"
+// @Param tz query string false "IANA timezone for timestamps, e.g. Asia/Bangkok"
+// @Param player_id query string false "Only this player's claims"
+// @Param from query string false "Only claims at or after this RFC3339 timestamp"
+// @Param to query string false "Only claims before this RFC3339 timestamp"
+// @Param checkpoint_val query int false "Only claims of this checkpoint"
+// @Param nickname_prefix query string false "Only players whose nickname starts with this prefix"
+// @Success 200 {file} file
+// @Failure 400 {object} object "Invalid filters, format, columns or timezone"
+// @Failure 401 {object} object "Missing or invalid admin key"
+// @Router /admin/rewards/export [get]
+func (h *RewardHandler) Export(c *fiber.Ctx) error {
+	// 1. Parse and validate the request before anything is streamed
+	var req application.ExportRequest
+	if err := c.QueryParser(&req); err != nil {
+		return shared.Invalid(err)
+	}
+
+	job, err := h.exportUC.Prepare(req)
+	if err != nil {
+		return err
+	}
+
+	// 2. Stream rows as they are read
+	return httputil.Export(c, job)
+}
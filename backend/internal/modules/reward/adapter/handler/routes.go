@@ -3,9 +3,14 @@ package handler
 import "github.com/gofiber/fiber/v2"
 
 // RegisterRoutes registers reward routes
-func (h *RewardHandler) RegisterRoutes(app *fiber.App) {
-	rewards := app.Group("/rewards")
+func (h *RewardHandler) RegisterRoutes(router fiber.Router) {
+	rewards := router.Group("/rewards")
 
 	rewards.Post("/claim", h.Claim)
 	rewards.Get("/:player_id", h.GetHistory)
 }
+
+// RegisterAdminRoutes registers reward admin routes on an authenticated router
+func (h *RewardHandler) RegisterAdminRoutes(admin fiber.Router) {
+	admin.Get("/rewards/export", h.Export)
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	rewarddomain "backend/internal/modules/reward/domain"
+	"backend/internal/shared/contracttest"
+	"backend/internal/shared/cursor"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// testCheckpoints are far above any configured checkpoint so GORM tests
+// never collide with seeded rows
+var testCheckpoints = []int{900001, 900002, 900003}
+
+func testRewardConfigs(t *testing.T) []*rewarddomain.RewardConfig {
+	t.Helper()
+	configs := make([]*rewarddomain.RewardConfig, len(testCheckpoints))
+	for i, checkpointVal := range testCheckpoints {
+		config, err := rewarddomain.NewRewardConfig(checkpointVal, fmt.Sprintf("Contract reward %d", i+1), "contract test")
+		if err != nil {
+			t.Fatal(err)
+		}
+		configs[i] = config.WithTranslations(
+			map[string]string{"th": fmt.Sprintf("รางวัลทดสอบ %d", i+1)},
+			map[string]string{"th": "ทดสอบสัญญา"},
+		)
+	}
+	return configs
+}
+
+// seedRewardConfigs inserts the test configs and removes them when the test ends
+func seedRewardConfigs(t *testing.T, db *gorm.DB) []*rewarddomain.RewardConfig {
+	t.Helper()
+	configs := testRewardConfigs(t)
+	for _, config := range configs {
+		model := RewardConfigModel{
+			CheckpointVal:         config.CheckpointVal(),
+			RewardName:            config.RewardName(),
+			RewardDescription:     config.RewardDescription(),
+			RewardNameI18n:        config.NameTranslations(),
+			RewardDescriptionI18n: config.DescriptionTranslations(),
+		}
+		if err := db.Save(&model).Error; err != nil {
+			t.Fatalf("seed reward config: %v", err)
+		}
+	}
+	t.Cleanup(func() {
+		db.Where("checkpoint_val IN ?", testCheckpoints).Delete(&RewardConfigModel{})
+	})
+	return configs
+}
+
+func TestRewardConfigRepositoryMemoryContract(t *testing.T) {
+	configs := testRewardConfigs(t)
+	contracttest.RewardConfigRepository(t, NewRewardConfigRepositoryMemory(configs), configs)
+}
+
+func TestRewardConfigRepositoryGormContract(t *testing.T) {
+	testRewardConfigRepositoryGorm(t, contracttest.OpenPostgres(t))
+}
+
+func TestRewardConfigRepositorySQLiteContract(t *testing.T) {
+	testRewardConfigRepositoryGorm(t, contracttest.OpenSQLite(t))
+}
+
+func testRewardConfigRepositoryGorm(t *testing.T, db *gorm.DB) {
+	configs := seedRewardConfigs(t, db)
+	contracttest.RewardConfigRepository(t, NewRewardConfigRepositoryGorm(db), configs)
+}
+
+func TestRewardTransactionRepositoryMemoryContract(t *testing.T) {
+	contracttest.RewardTransactionRepository(t, func(t *testing.T) contracttest.RewardTransactionFixture {
+		players := &nicknames{byID: make(map[string]string)}
+		configs := NewRewardConfigRepositoryMemory(testRewardConfigs(t))
+		return contracttest.RewardTransactionFixture{
+			Repo:        NewRewardTransactionRepositoryMemory(configs, players, cursor.NewCodec("contract-test")),
+			AddPlayer:   players.add,
+			Checkpoints: testCheckpoints,
+		}
+	})
+}
+
+func TestRewardTransactionRepositoryGormContract(t *testing.T) {
+	testRewardTransactionRepositoryGorm(t, contracttest.OpenPostgres(t))
+}
+
+func TestRewardTransactionRepositorySQLiteContract(t *testing.T) {
+	testRewardTransactionRepositoryGorm(t, contracttest.OpenSQLite(t))
+}
+
+func testRewardTransactionRepositoryGorm(t *testing.T, db *gorm.DB) {
+	seedRewardConfigs(t, db)
+	contracttest.RewardTransactionRepository(t, func(t *testing.T) contracttest.RewardTransactionFixture {
+		return contracttest.RewardTransactionFixture{
+			Repo: NewRewardTransactionRepositoryGorm(db, nil, cursor.NewCodec("contract-test")),
+			AddPlayer: func(nickname string) string {
+				id := uuid.NewString()
+				if err := db.Exec("INSERT INTO players (id, nickname) VALUES (?, ?)", id, nickname).Error; err != nil {
+					t.Fatalf("insert player: %v", err)
+				}
+				t.Cleanup(func() {
+					db.Exec("DELETE FROM reward_transactions WHERE player_id = ?", id)
+					db.Exec("DELETE FROM players WHERE id = ?", id)
+				})
+				return id
+			},
+			Checkpoints: testCheckpoints,
+		}
+	})
+}
+
+// nicknames stands in for the player repository
+type nicknames struct {
+	mu   sync.Mutex
+	byID map[string]string
+}
+
+func (n *nicknames) add(nickname string) string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	id := uuid.NewString()
+	n.byID[id] = nickname
+	return id
+}
+
+func (n *nicknames) Nickname(playerID string) string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.byID[playerID]
+}
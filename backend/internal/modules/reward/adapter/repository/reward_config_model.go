@@ -9,6 +9,10 @@ type RewardConfigModel struct {
 	CheckpointVal     int    `gorm:"type:integer;primaryKey"`
 	RewardName        string `gorm:"type:varchar(100);not null"`
 	RewardDescription string `gorm:"type:text"`
+
+	// Translations by locale, stored as JSON objects
+	RewardNameI18n        map[string]string `gorm:"column:reward_name_i18n;serializer:json;not null"`
+	RewardDescriptionI18n map[string]string `gorm:"column:reward_description_i18n;serializer:json;not null"`
 }
 
 // TableName specifies the table name
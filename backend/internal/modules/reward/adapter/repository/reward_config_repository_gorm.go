@@ -6,6 +6,7 @@ import (
 
 	"gorm.io/gorm"
 
+	"backend/internal/infrastructure/database"
 	rewarddomain "backend/internal/modules/reward/domain"
 	shared "backend/internal/shared/domain"
 )
@@ -23,7 +24,7 @@ func NewRewardConfigRepositoryGorm(db *gorm.DB) *RewardConfigRepositoryGorm {
 // FindByCheckpoint loads reward config for checkpoint
 func (r *RewardConfigRepositoryGorm) FindByCheckpoint(ctx context.Context, checkpointVal int) (*rewarddomain.RewardConfig, error) {
 	var model RewardConfigModel
-	result := r.db.WithContext(ctx).Where("checkpoint_val = ?", checkpointVal).First(&model)
+	result := database.Conn(ctx, r.db).Where("checkpoint_val = ?", checkpointVal).First(&model)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, shared.ErrInvalidCheckpoint
@@ -31,28 +32,20 @@ func (r *RewardConfigRepositoryGorm) FindByCheckpoint(ctx context.Context, check
 		return nil, result.Error
 	}
 
-	return rewarddomain.NewRewardConfig(
-		model.CheckpointVal,
-		model.RewardName,
-		model.RewardDescription,
-	)
+	return toRewardConfigDomain(&model)
 }
 
 // FindAll returns all reward configs
 func (r *RewardConfigRepositoryGorm) FindAll(ctx context.Context) ([]*rewarddomain.RewardConfig, error) {
 	var models []RewardConfigModel
-	err := r.db.WithContext(ctx).Order("checkpoint_val ASC").Find(&models).Error
+	err := database.Conn(ctx, r.db).Order("checkpoint_val ASC").Find(&models).Error
 	if err != nil {
 		return nil, err
 	}
 
 	configs := make([]*rewarddomain.RewardConfig, 0, len(models))
 	for _, model := range models {
-		config, err := rewarddomain.NewRewardConfig(
-			model.CheckpointVal,
-			model.RewardName,
-			model.RewardDescription,
-		)
+		config, err := toRewardConfigDomain(&model)
 		if err != nil {
 			continue
 		}
@@ -61,3 +54,12 @@ func (r *RewardConfigRepositoryGorm) FindAll(ctx context.Context) ([]*rewarddoma
 
 	return configs, nil
 }
+
+// toRewardConfigDomain converts model to domain
+func toRewardConfigDomain(model *RewardConfigModel) (*rewarddomain.RewardConfig, error) {
+	config, err := rewarddomain.NewRewardConfig(model.CheckpointVal, model.RewardName, model.RewardDescription)
+	if err != nil {
+		return nil, err
+	}
+	return config.WithTranslations(model.RewardNameI18n, model.RewardDescriptionI18n), nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	rewarddomain "backend/internal/modules/reward/domain"
+	shared "backend/internal/shared/domain"
+)
+
+// RewardConfigRepositoryMemory implements RewardConfigRepository in process
+// memory; it is seeded once, like the reward_config table
+type RewardConfigRepositoryMemory struct {
+	mu      sync.RWMutex
+	configs map[int]RewardConfigModel
+}
+
+// NewRewardConfigRepositoryMemory creates a repository holding configs
+func NewRewardConfigRepositoryMemory(configs []*rewarddomain.RewardConfig) *RewardConfigRepositoryMemory {
+	r := &RewardConfigRepositoryMemory{configs: make(map[int]RewardConfigModel, len(configs))}
+	for _, config := range configs {
+		r.configs[config.CheckpointVal()] = RewardConfigModel{
+			CheckpointVal:     config.CheckpointVal(),
+			RewardName:        config.RewardName(),
+			RewardDescription: config.RewardDescription(),
+
+			RewardNameI18n:        config.NameTranslations(),
+			RewardDescriptionI18n: config.DescriptionTranslations(),
+		}
+	}
+	return r
+}
+
+// FindByCheckpoint loads reward config for checkpoint
+func (r *RewardConfigRepositoryMemory) FindByCheckpoint(ctx context.Context, checkpointVal int) (*rewarddomain.RewardConfig, error) {
+	model, ok := r.find(checkpointVal)
+	if !ok {
+		return nil, shared.ErrInvalidCheckpoint
+	}
+	return toRewardConfigDomain(&model)
+}
+
+// FindAll returns all reward configs
+func (r *RewardConfigRepositoryMemory) FindAll(ctx context.Context) ([]*rewarddomain.RewardConfig, error) {
+	r.mu.RLock()
+	checkpoints := make([]int, 0, len(r.configs))
+	for checkpointVal := range r.configs {
+		checkpoints = append(checkpoints, checkpointVal)
+	}
+	r.mu.RUnlock()
+	slices.Sort(checkpoints)
+
+	configs := make([]*rewarddomain.RewardConfig, 0, len(checkpoints))
+	for _, checkpointVal := range checkpoints {
+		config, err := r.FindByCheckpoint(ctx, checkpointVal)
+		if err != nil {
+			continue
+		}
+		configs = append(configs, config)
+	}
+	return configs, nil
+}
+
+// find returns the stored config for a checkpoint
+func (r *RewardConfigRepositoryMemory) find(checkpointVal int) (RewardConfigModel, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	model, ok := r.configs[checkpointVal]
+	return model, ok
+}
@@ -3,20 +3,40 @@ package repository
 import (
 	"context"
 	"errors"
+	"net/url"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 
+	"backend/internal/infrastructure/database"
 	rewarddomain "backend/internal/modules/reward/domain"
+	"backend/internal/shared/cursor"
+	shared "backend/internal/shared/domain"
 )
 
+// rewardHistoryKeyset orders a player's claims newest first
+var rewardHistoryKeyset = database.Keyset{
+	Scope: "reward.history",
+	Columns: []database.KeyColumn{
+		{Column: "reward_transactions.claimed_at", Type: database.KeyTime, Desc: true},
+		{Column: "reward_transactions.id", Type: database.KeyString, Desc: true},
+	},
+}
+
 // RewardTransactionRepositoryGorm implements RewardTransactionRepository
 type RewardTransactionRepositoryGorm struct {
-	db *gorm.DB
+	db      *gorm.DB
+	reads   *database.Router // reward history may be served by replicas
+	cursors *cursor.Codec
 }
 
-// NewRewardTransactionRepositoryGorm creates a new repository
-func NewRewardTransactionRepositoryGorm(db *gorm.DB) *RewardTransactionRepositoryGorm {
-	return &RewardTransactionRepositoryGorm{db: db}
+// NewRewardTransactionRepositoryGorm creates a new repository; reads may be nil
+func NewRewardTransactionRepositoryGorm(db *gorm.DB, reads *database.Router, cursors *cursor.Codec) *RewardTransactionRepositoryGorm {
+	if reads == nil {
+		reads = database.NewRouter(db, nil, 0)
+	}
+	return &RewardTransactionRepositoryGorm{db: db, reads: reads, cursors: cursors}
 }
 
 // Store persists a new reward transaction
@@ -28,14 +48,14 @@ func (r *RewardTransactionRepositoryGorm) Store(ctx context.Context, tx *rewardd
 		ClaimedAt:     tx.ClaimedAt(),
 	}
 
-	result := r.db.WithContext(ctx).Create(model)
+	result := database.Conn(ctx, r.db).Create(model)
 	return result.Error
 }
 
 // FindByID loads transaction by ID
 func (r *RewardTransactionRepositoryGorm) FindByID(ctx context.Context, id *rewarddomain.RewardTransactionID) (*rewarddomain.RewardTransaction, error) {
 	var model RewardTransactionModel
-	result := r.db.WithContext(ctx).Where("id = ?", id.String()).First(&model)
+	result := database.Conn(ctx, r.db).Where("id = ?", id.String()).First(&model)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, errors.New("reward transaction not found")
@@ -54,7 +74,7 @@ func (r *RewardTransactionRepositoryGorm) FindByID(ctx context.Context, id *rewa
 // ExistsByPlayerAndCheckpoint checks if player already claimed this checkpoint
 func (r *RewardTransactionRepositoryGorm) ExistsByPlayerAndCheckpoint(ctx context.Context, playerID string, checkpointVal int) (bool, error) {
 	var count int64
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Model(&RewardTransactionModel{}).
 		Where("player_id = ? AND checkpoint_val = ?", playerID, checkpointVal).
 		Count(&count).Error
@@ -64,7 +84,7 @@ func (r *RewardTransactionRepositoryGorm) ExistsByPlayerAndCheckpoint(ctx contex
 // GetClaimedCheckpoints returns just the checkpoint values
 func (r *RewardTransactionRepositoryGorm) GetClaimedCheckpoints(ctx context.Context, playerID string) ([]int, error) {
 	var checkpoints []int
-	err := r.db.WithContext(ctx).
+	err := database.Conn(ctx, r.db).
 		Model(&RewardTransactionModel{}).
 		Where("player_id = ?", playerID).
 		Order("checkpoint_val ASC").
@@ -75,16 +95,119 @@ func (r *RewardTransactionRepositoryGorm) GetClaimedCheckpoints(ctx context.Cont
 // ListByPlayer returns all rewards claimed by player with config info
 func (r *RewardTransactionRepositoryGorm) ListByPlayer(ctx context.Context, playerID string) ([]*rewarddomain.RewardTransactionWithConfig, error) {
 	var models []*RewardTransactionModel
-	err := r.db.WithContext(ctx).
-		Preload("RewardConfig").
-		Where("player_id = ?", playerID).
-		Order("claimed_at DESC").
-		Find(&models).Error
+	err := r.reads.ReadFor(ctx, playerID, func(db *gorm.DB) error {
+		models = nil
+		return db.WithContext(ctx).
+			Preload("RewardConfig").
+			Where("player_id = ?", playerID).
+			Order("claimed_at DESC").
+			Find(&models).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toWithConfig(models), nil
+}
+
+// ListByPlayerCursor returns a page of the player's claims, newest first
+func (r *RewardTransactionRepositoryGorm) ListByPlayerCursor(ctx context.Context, playerID string, params shared.CursorParams) (*rewarddomain.RewardTransactionCursorResult, error) {
+	req := database.PageRequest{Limit: params.Limit, Cursor: params.Cursor, Filter: url.Values{"player_id": {playerID}}.Encode()}
+	pos, err := rewardHistoryKeyset.Position(r.cursors, req)
+	if err != nil {
+		return nil, err
+	}
 
+	var page *database.Page[RewardTransactionModel]
+	err = r.reads.ReadFor(ctx, playerID, func(db *gorm.DB) error {
+		query := db.WithContext(ctx).
+			Preload("RewardConfig").
+			Where("reward_transactions.player_id = ?", playerID)
+		page, err = database.Paginate(query, r.cursors, rewardHistoryKeyset, req, pos, func(m *RewardTransactionModel) []any {
+			return []any{m.ClaimedAt, m.ID}
+		})
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	models := make([]*RewardTransactionModel, len(page.Items))
+	for i := range page.Items {
+		models[i] = &page.Items[i]
+	}
+
+	return &rewarddomain.RewardTransactionCursorResult{
+		Data:       toWithConfig(models),
+		NextCursor: page.NextCursor,
+		PrevCursor: page.PrevCursor,
+		HasMore:    page.HasMore,
+		HasPrev:    page.HasPrev,
+	}, nil
+}
+
+// rewardExportRow is a claim joined with its reward config and player, read by exports
+type rewardExportRow struct {
+	ID                string
+	PlayerID          string
+	Nickname          string
+	CheckpointVal     int
+	RewardName        string
+	RewardDescription string
+	ClaimedAt         time.Time
+}
+
+// StreamForExport passes claims matching filter to fn in batches, oldest first
+func (r *RewardTransactionRepositoryGorm) StreamForExport(ctx context.Context, filter rewarddomain.RewardTransactionFilter, batchSize int, fn func([]*rewarddomain.RewardTransactionWithConfig) error) error {
+	query := r.reads.Stream(ctx).
+		Model(&RewardTransactionModel{}).
+		Select("reward_transactions.id, reward_transactions.player_id, COALESCE(players.nickname, '') AS nickname, " +
+			"reward_transactions.checkpoint_val, COALESCE(reward_config.reward_name, '') AS reward_name, " +
+			"COALESCE(reward_config.reward_description, '') AS reward_description, reward_transactions.claimed_at").
+		Joins("LEFT JOIN players ON players.id = reward_transactions.player_id").
+		Joins("LEFT JOIN reward_config ON reward_config.checkpoint_val = reward_transactions.checkpoint_val")
+	if filter.PlayerID != "" {
+		query = query.Where("reward_transactions.player_id = ?", filter.PlayerID)
+	}
+	if filter.From != nil {
+		query = query.Where("reward_transactions.claimed_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("reward_transactions.claimed_at < ?", *filter.To)
+	}
+	if filter.CheckpointVal != nil {
+		query = query.Where("reward_transactions.checkpoint_val = ?", *filter.CheckpointVal)
+	}
+	if filter.NicknamePrefix != "" {
+		query = query.Where("players.nickname LIKE ? ESCAPE '\\'", escapeLike(filter.NicknamePrefix)+"%")
+	}
+	query = query.Order("reward_transactions.claimed_at ASC, reward_transactions.id ASC")
+
+	return database.Stream(ctx, query, batchSize, func(batch []rewardExportRow) error {
+		items := make([]*rewarddomain.RewardTransactionWithConfig, len(batch))
+		for i, row := range batch {
+			tx, err := rewarddomain.ReconstructRewardTransaction(row.ID, row.PlayerID, row.CheckpointVal, row.ClaimedAt)
+			if err != nil {
+				return err
+			}
+			items[i] = &rewarddomain.RewardTransactionWithConfig{
+				Transaction:       tx,
+				RewardName:        row.RewardName,
+				RewardDescription: row.RewardDescription,
+				PlayerNickname:    row.Nickname,
+			}
+		}
+		return fn(items)
+	})
+}
+
+// escapeLike escapes LIKE wildcards so a prefix is matched literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// toWithConfig maps models to domain results, skipping rows that fail reconstruction
+func toWithConfig(models []*RewardTransactionModel) []*rewarddomain.RewardTransactionWithConfig {
 	results := make([]*rewarddomain.RewardTransactionWithConfig, 0, len(models))
 	for _, model := range models {
 		tx, err := rewarddomain.ReconstructRewardTransaction(
@@ -97,19 +220,16 @@ func (r *RewardTransactionRepositoryGorm) ListByPlayer(ctx context.Context, play
 			continue
 		}
 
-		rewardName := ""
-		rewardDesc := ""
+		item := &rewarddomain.RewardTransactionWithConfig{Transaction: tx}
 		if model.RewardConfig != nil {
-			rewardName = model.RewardConfig.RewardName
-			rewardDesc = model.RewardConfig.RewardDescription
+			item.RewardName = model.RewardConfig.RewardName
+			item.RewardDescription = model.RewardConfig.RewardDescription
+			item.RewardNameTranslations = model.RewardConfig.RewardNameI18n
+			item.RewardDescriptionTranslations = model.RewardConfig.RewardDescriptionI18n
 		}
 
-		results = append(results, &rewarddomain.RewardTransactionWithConfig{
-			Transaction:       tx,
-			RewardName:        rewardName,
-			RewardDescription: rewardDesc,
-		})
+		results = append(results, item)
 	}
 
-	return results, nil
+	return results
 }
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	"backend/internal/infrastructure/database"
+	rewarddomain "backend/internal/modules/reward/domain"
+	"backend/internal/shared/cursor"
+	shared "backend/internal/shared/domain"
+)
+
+var errCheckpointClaimed = errors.New("checkpoint already claimed")
+
+// NicknameLookup resolves a player's nickname ("" when unknown); memory
+// repositories use it in place of a join on players
+type NicknameLookup interface {
+	Nickname(playerID string) string
+}
+
+// RewardTransactionRepositoryMemory implements RewardTransactionRepository in
+// process memory; it keeps one claim per player and checkpoint, like the table
+type RewardTransactionRepositoryMemory struct {
+	mu        sync.RWMutex
+	txs       []RewardTransactionModel
+	configs   *RewardConfigRepositoryMemory // reward names
+	nicknames NicknameLookup
+	cursors   *cursor.Codec
+}
+
+// NewRewardTransactionRepositoryMemory creates an empty repository
+func NewRewardTransactionRepositoryMemory(configs *RewardConfigRepositoryMemory, nicknames NicknameLookup, cursors *cursor.Codec) *RewardTransactionRepositoryMemory {
+	return &RewardTransactionRepositoryMemory{configs: configs, nicknames: nicknames, cursors: cursors}
+}
+
+// Store persists a new reward transaction
+func (r *RewardTransactionRepositoryMemory) Store(ctx context.Context, tx *rewarddomain.RewardTransaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, m := range r.txs {
+		if m.PlayerID == tx.PlayerID() && m.CheckpointVal == tx.CheckpointVal() {
+			return errCheckpointClaimed
+		}
+	}
+	r.txs = append(r.txs, RewardTransactionModel{
+		ID:            tx.ID().String(),
+		PlayerID:      tx.PlayerID(),
+		CheckpointVal: tx.CheckpointVal(),
+		ClaimedAt:     tx.ClaimedAt(),
+	})
+	return nil
+}
+
+// FindByID loads transaction by ID
+func (r *RewardTransactionRepositoryMemory) FindByID(ctx context.Context, id *rewarddomain.RewardTransactionID) (*rewarddomain.RewardTransaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, m := range r.txs {
+		if m.ID == id.String() {
+			return rewarddomain.ReconstructRewardTransaction(m.ID, m.PlayerID, m.CheckpointVal, m.ClaimedAt)
+		}
+	}
+	return nil, errors.New("reward transaction not found")
+}
+
+// ExistsByPlayerAndCheckpoint checks if player already claimed this checkpoint
+func (r *RewardTransactionRepositoryMemory) ExistsByPlayerAndCheckpoint(ctx context.Context, playerID string, checkpointVal int) (bool, error) {
+	for _, m := range r.byPlayer(playerID) {
+		if m.CheckpointVal == checkpointVal {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// GetClaimedCheckpoints returns just the checkpoint values
+func (r *RewardTransactionRepositoryMemory) GetClaimedCheckpoints(ctx context.Context, playerID string) ([]int, error) {
+	var checkpoints []int
+	for _, m := range r.byPlayer(playerID) {
+		checkpoints = append(checkpoints, m.CheckpointVal)
+	}
+	slices.Sort(checkpoints)
+	return checkpoints, nil
+}
+
+// ListByPlayer returns all rewards claimed by player with config info
+func (r *RewardTransactionRepositoryMemory) ListByPlayer(ctx context.Context, playerID string) ([]*rewarddomain.RewardTransactionWithConfig, error) {
+	models := r.byPlayer(playerID)
+	slices.SortFunc(models, func(a, b *RewardTransactionModel) int {
+		return b.ClaimedAt.Compare(a.ClaimedAt)
+	})
+	return toWithConfig(models), nil
+}
+
+// ListByPlayerCursor returns a page of the player's claims, newest first
+func (r *RewardTransactionRepositoryMemory) ListByPlayerCursor(ctx context.Context, playerID string, params shared.CursorParams) (*rewarddomain.RewardTransactionCursorResult, error) {
+	req := database.PageRequest{Limit: params.Limit, Cursor: params.Cursor, Filter: url.Values{"player_id": {playerID}}.Encode()}
+	pos, err := rewardHistoryKeyset.Position(r.cursors, req)
+	if err != nil {
+		return nil, err
+	}
+
+	page := database.PaginateSlice(r.byPlayer(playerID), r.cursors, rewardHistoryKeyset, req, pos, func(m **RewardTransactionModel) []any {
+		return []any{(*m).ClaimedAt, (*m).ID}
+	})
+
+	return &rewarddomain.RewardTransactionCursorResult{
+		Data:       toWithConfig(page.Items),
+		NextCursor: page.NextCursor,
+		PrevCursor: page.PrevCursor,
+		HasMore:    page.HasMore,
+		HasPrev:    page.HasPrev,
+	}, nil
+}
+
+// StreamForExport passes claims matching filter to fn in batches, oldest first
+func (r *RewardTransactionRepositoryMemory) StreamForExport(ctx context.Context, filter rewarddomain.RewardTransactionFilter, batchSize int, fn func([]*rewarddomain.RewardTransactionWithConfig) error) error {
+	var items []*rewarddomain.RewardTransactionWithConfig
+	for _, m := range r.byPlayer(filter.PlayerID) {
+		if filter.From != nil && m.ClaimedAt.Before(*filter.From) {
+			continue
+		}
+		if filter.To != nil && !m.ClaimedAt.Before(*filter.To) {
+			continue
+		}
+		if filter.CheckpointVal != nil && m.CheckpointVal != *filter.CheckpointVal {
+			continue
+		}
+		nickname := r.nicknames.Nickname(m.PlayerID)
+		if filter.NicknamePrefix != "" && !strings.HasPrefix(nickname, filter.NicknamePrefix) {
+			continue
+		}
+
+		withConfig := toWithConfig([]*RewardTransactionModel{m})
+		if len(withConfig) == 0 {
+			continue
+		}
+		withConfig[0].PlayerNickname = nickname
+		items = append(items, withConfig[0])
+	}
+	slices.SortFunc(items, func(a, b *rewarddomain.RewardTransactionWithConfig) int {
+		if c := a.Transaction.ClaimedAt().Compare(b.Transaction.ClaimedAt()); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Transaction.ID().String(), b.Transaction.ID().String())
+	})
+
+	return database.StreamSlice(ctx, items, batchSize, fn)
+}
+
+// byPlayer returns copies of the claims of playerID ("" for all) with their
+// reward config attached
+func (r *RewardTransactionRepositoryMemory) byPlayer(playerID string) []*RewardTransactionModel {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var models []*RewardTransactionModel
+	for _, m := range r.txs {
+		if playerID != "" && m.PlayerID != playerID {
+			continue
+		}
+		if config, ok := r.configs.find(m.CheckpointVal); ok {
+			m.RewardConfig = &config
+		}
+		models = append(models, &m)
+	}
+	return models
+}
@@ -2,10 +2,15 @@ package claim
 
 import (
 	"context"
+	"time"
 
 	"backend/internal/modules/player/domain"
 	rewarddomain "backend/internal/modules/reward/domain"
 	shared "backend/internal/shared/domain"
+	"backend/internal/shared/events"
+	"backend/internal/shared/i18n"
+	"backend/internal/shared/logging"
+	"backend/internal/shared/tracing"
 )
 
 // Request for claim reward
@@ -27,6 +32,7 @@ type UseCase struct {
 	rewardTxRepo     rewarddomain.RewardTransactionRepository
 	rewardConfigRepo rewarddomain.RewardConfigRepository
 	playerRepo       domain.PlayerRepository
+	publisher        events.Publisher
 }
 
 // New creates a new claim use case
@@ -34,32 +40,41 @@ func New(
 	txRepo rewarddomain.RewardTransactionRepository,
 	configRepo rewarddomain.RewardConfigRepository,
 	playerRepo domain.PlayerRepository,
+	publisher events.Publisher,
 ) *UseCase {
 	return &UseCase{
 		rewardTxRepo:     txRepo,
 		rewardConfigRepo: configRepo,
 		playerRepo:       playerRepo,
+		publisher:        publisher,
 	}
 }
 
 // Execute claims a reward for player
-func (uc *UseCase) Execute(ctx context.Context, req Request) (*Response, error) {
+func (uc *UseCase) Execute(ctx context.Context, req Request) (_ *Response, err error) {
+	ctx, span := tracing.Start(ctx, "reward.claim.Execute")
+	defer func() { tracing.End(span, err) }()
+
 	// 1. Parse player ID
 	playerID, err := domain.NewPlayerID(req.PlayerID)
 	if err != nil {
 		return nil, shared.ErrPlayerNotFound
 	}
 
-	// 2. Get player
+	// 2. Get player (suspended, banned and deleted players cannot claim)
 	player, err := uc.playerRepo.FindByID(ctx, playerID)
 	if err != nil {
-		return nil, shared.ErrPlayerNotFound
+		return nil, err
+	}
+	i18n.Prefer(ctx, player.Locale())
+	if err := player.CheckActive(time.Now()); err != nil {
+		return nil, err
 	}
 
-	// 3. Get reward config for checkpoint
+	// 3. Get reward config for checkpoint (shared.ErrInvalidCheckpoint when unknown)
 	config, err := uc.rewardConfigRepo.FindByCheckpoint(ctx, req.CheckpointVal)
 	if err != nil {
-		return nil, shared.ErrInvalidCheckpoint
+		return nil, err
 	}
 
 	// 4. Check if player has enough points
@@ -69,7 +84,10 @@ func (uc *UseCase) Execute(ctx context.Context, req Request) (*Response, error)
 	}
 
 	// 5. Check if already claimed
-	exists, _ := uc.rewardTxRepo.ExistsByPlayerAndCheckpoint(ctx, req.PlayerID, req.CheckpointVal)
+	exists, err := uc.rewardTxRepo.ExistsByPlayerAndCheckpoint(ctx, req.PlayerID, req.CheckpointVal)
+	if err != nil {
+		return nil, err
+	}
 	if exists {
 		return nil, shared.ErrAlreadyClaimed
 	}
@@ -84,12 +102,20 @@ func (uc *UseCase) Execute(ctx context.Context, req Request) (*Response, error)
 	if err := uc.rewardTxRepo.Store(ctx, tx); err != nil {
 		return nil, err
 	}
+	uc.publisher.Publish(ctx, tx.DomainEvents()...)
+	tx.ClearEvents()
+
+	logging.FromContext(ctx).InfoContext(ctx, "reward claimed",
+		"player_id", req.PlayerID,
+		"transaction_id", tx.ID().String(),
+		"checkpoint_val", tx.CheckpointVal(),
+	)
 
 	// 8. Return response
 	return &Response{
 		ID:            tx.ID().String(),
 		CheckpointVal: tx.CheckpointVal(),
-		RewardName:    config.RewardName(),
+		RewardName:    i18n.Translate(ctx, config.NameTranslations(), config.RewardName()),
 		ClaimedAt:     tx.ClaimedAt().Format("2006-01-02T15:04:05Z07:00"),
 	}, nil
 }
@@ -17,6 +17,20 @@ type ClaimResponse struct {
 // GetHistoryRequest for GET /rewards/:player_id
 type GetHistoryRequest struct {
 	PlayerID string `params:"player_id"`
+	Limit    int    `query:"limit"`
+	Cursor   string `query:"cursor"`
+}
+
+// ExportRequest selects the claims and encoding of GET /admin/rewards/export
+type ExportRequest struct {
+	PlayerID       string `query:"player_id"`
+	From           string `query:"from"` // RFC3339, inclusive
+	To             string `query:"to"`   // RFC3339, exclusive
+	CheckpointVal  *int   `query:"checkpoint_val"`
+	NicknamePrefix string `query:"nickname_prefix"`
+	Format         string `query:"format"`  // csv (default) or ndjson
+	Columns        string `query:"columns"` // comma-separated, defaults to export.yaml
+	Timezone       string `query:"tz"`      // IANA zone, defaults to export.yaml
 }
 
 // RewardHistoryDTO
@@ -30,7 +44,11 @@ type RewardHistoryDTO struct {
 
 // GetHistoryResponse
 type GetHistoryResponse struct {
-	Data []RewardHistoryDTO `json:"data"`
+	Data       []RewardHistoryDTO `json:"data"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+	PrevCursor string             `json:"prev_cursor,omitempty"`
+	HasMore    bool               `json:"has_more"`
+	HasPrev    bool               `json:"has_prev"`
 }
 
 // GetConfigRequest for GET /rewards/config
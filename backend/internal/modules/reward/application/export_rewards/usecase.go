@@ -0,0 +1,84 @@
+package export_rewards
+
+import (
+	"context"
+	"time"
+
+	"backend/internal/modules/reward/application"
+	rewarddomain "backend/internal/modules/reward/domain"
+	shared "backend/internal/shared/domain"
+	"backend/internal/shared/export"
+	"backend/internal/shared/tracing"
+)
+
+type row = *rewarddomain.RewardTransactionWithConfig
+
+// columns are the fields a reward export can contain
+var columns = []export.Column[row]{
+	{Name: "id", Value: func(r row) any { return r.Transaction.ID().String() }},
+	{Name: "player_id", Value: func(r row) any { return r.Transaction.PlayerID() }},
+	{Name: "nickname", Value: func(r row) any { return r.PlayerNickname }},
+	{Name: "checkpoint_val", Value: func(r row) any { return r.Transaction.CheckpointVal() }},
+	{Name: "reward_name", Value: func(r row) any { return r.RewardName }},
+	{Name: "reward_description", Value: func(r row) any { return r.RewardDescription }},
+	{Name: "claimed_at", Value: func(r row) any { return r.Transaction.ClaimedAt() }},
+}
+
+// UseCase streams reward claims as CSV or NDJSON
+type UseCase struct {
+	rewardTxRepo rewarddomain.RewardTransactionRepository
+	defaults     export.Options
+}
+
+// New creates a new export use case
+func New(repo rewarddomain.RewardTransactionRepository, defaults export.Options) *UseCase {
+	return &UseCase{
+		rewardTxRepo: repo,
+		defaults:     defaults,
+	}
+}
+
+// Prepare validates the request and returns a job that streams the matching
+// claims; nothing is read until the job runs
+func (uc *UseCase) Prepare(req application.ExportRequest) (*export.Job, error) {
+	// 1. Parse filters
+	filter := rewarddomain.RewardTransactionFilter{
+		PlayerID:       req.PlayerID,
+		CheckpointVal:  req.CheckpointVal,
+		NicknamePrefix: req.NicknamePrefix,
+	}
+	if req.From != "" {
+		from, err := time.Parse(time.RFC3339, req.From)
+		if err != nil {
+			return nil, shared.NewValidationError("from must be an RFC3339 timestamp")
+		}
+		filter.From = &from
+	}
+	if req.To != "" {
+		to, err := time.Parse(time.RFC3339, req.To)
+		if err != nil {
+			return nil, shared.NewValidationError("to must be an RFC3339 timestamp")
+		}
+		filter.To = &to
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, shared.Invalid(err)
+	}
+
+	// 2. Resolve format, columns and timezone
+	opts, err := export.ResolveOptions(uc.defaults, req.Format, req.Columns, req.Timezone)
+	if err != nil {
+		return nil, shared.Invalid(err)
+	}
+	selected, err := export.SelectColumns(columns, opts.Columns)
+	if err != nil {
+		return nil, shared.Invalid(err)
+	}
+
+	return export.NewJob("reward_history", opts, selected, func(ctx context.Context, batchSize int, fn func([]row) error) (err error) {
+		ctx, span := tracing.Start(ctx, "reward.export_rewards.Run")
+		defer func() { tracing.End(span, err) }()
+
+		return uc.rewardTxRepo.StreamForExport(ctx, filter, batchSize, fn)
+	}), nil
+}
@@ -3,17 +3,27 @@ package get_history
 import (
 	"context"
 
+	playerdomain "backend/internal/modules/player/domain"
 	rewarddomain "backend/internal/modules/reward/domain"
+	shared "backend/internal/shared/domain"
+	"backend/internal/shared/i18n"
+	"backend/internal/shared/tracing"
 )
 
 // Request for get history
 type Request struct {
 	PlayerID string
+	Limit    int
+	Cursor   string
 }
 
 // Response for get history
 type Response struct {
-	Data []RewardHistoryItem
+	Data       []RewardHistoryItem
+	NextCursor string
+	PrevCursor string
+	HasMore    bool
+	HasPrev    bool
 }
 
 // RewardHistoryItem represents a single history entry
@@ -27,38 +37,59 @@ type RewardHistoryItem struct {
 
 // UseCase handles reward history retrieval
 type UseCase struct {
-	rewardTxRepo rewarddomain.RewardTransactionRepository
+	rewardTxRepo  rewarddomain.RewardTransactionRepository
+	playerRepo    playerdomain.PlayerRepository
+	paginationCfg shared.PaginationConfig
 }
 
 // New creates a new get history use case
-func New(repo rewarddomain.RewardTransactionRepository) *UseCase {
+func New(repo rewarddomain.RewardTransactionRepository, playerRepo playerdomain.PlayerRepository, cfg shared.PaginationConfig) *UseCase {
 	return &UseCase{
-		rewardTxRepo: repo,
+		rewardTxRepo:  repo,
+		playerRepo:    playerRepo,
+		paginationCfg: cfg,
 	}
 }
 
 // Execute retrieves player's reward claim history
-func (uc *UseCase) Execute(ctx context.Context, req Request) (*Response, error) {
-	// 1. Get all claimed rewards with config info
-	results, err := uc.rewardTxRepo.ListByPlayer(ctx, req.PlayerID)
+func (uc *UseCase) Execute(ctx context.Context, req Request) (_ *Response, err error) {
+	ctx, span := tracing.Start(ctx, "reward.get_history.Execute")
+	defer func() { tracing.End(span, err) }()
+
+	// 1. Render in the player's preferred locale; unknown players simply
+	// have no history, so lookup errors are ignored
+	if playerID, err := playerdomain.NewPlayerID(req.PlayerID); err == nil {
+		if player, err := uc.playerRepo.FindByID(ctx, playerID); err == nil {
+			i18n.Prefer(ctx, player.Locale())
+		}
+	}
+
+	// 2. Get a page of claimed rewards with config info
+	params := shared.NewCursorParams(req.Limit, req.Cursor, uc.paginationCfg)
+	page, err := uc.rewardTxRepo.ListByPlayerCursor(ctx, req.PlayerID, params)
 	if err != nil {
 		return nil, err
 	}
+	results := page.Data
 
-	// 2. Map to DTOs
+	// 3. Map to DTOs
 	items := make([]RewardHistoryItem, 0, len(results))
 	for _, result := range results {
 		items = append(items, RewardHistoryItem{
 			ID:                result.Transaction.ID().String(),
 			CheckpointVal:     result.Transaction.CheckpointVal(),
-			RewardName:        result.RewardName,
-			RewardDescription: result.RewardDescription,
+			RewardName:        i18n.Translate(ctx, result.RewardNameTranslations, result.RewardName),
+			RewardDescription: i18n.Translate(ctx, result.RewardDescriptionTranslations, result.RewardDescription),
 			ClaimedAt:         result.Transaction.ClaimedAt().Format("2006-01-02T15:04:05Z07:00"),
 		})
 	}
 
-	// 3. Return response
+	// 4. Return response
 	return &Response{
-		Data: items,
+		Data:       items,
+		NextCursor: page.NextCursor,
+		PrevCursor: page.PrevCursor,
+		HasMore:    page.HasMore,
+		HasPrev:    page.HasPrev,
 	}, nil
 }
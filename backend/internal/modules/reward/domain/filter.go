@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// RewardTransactionFilter narrows reward exports; zero values mean "no filter"
+type RewardTransactionFilter struct {
+	PlayerID       string
+	From           *time.Time // inclusive, on claimed_at
+	To             *time.Time // exclusive, on claimed_at
+	CheckpointVal  *int
+	NicknamePrefix string
+}
+
+// Validate checks the filter is consistent
+func (f RewardTransactionFilter) Validate() error {
+	if f.From != nil && f.To != nil && !f.From.Before(*f.To) {
+		return errors.New("from must be before to")
+	}
+	if f.CheckpointVal != nil && *f.CheckpointVal <= 0 {
+		return errors.New("checkpoint_val must be positive")
+	}
+	return nil
+}
@@ -10,6 +10,10 @@ type RewardConfig struct {
 	checkpointVal     int
 	rewardName        string
 	rewardDescription string
+
+	// Translations by locale; the fields above are the default locale's text
+	nameTranslations        map[string]string
+	descriptionTranslations map[string]string
 }
 
 // NewRewardConfig creates a new reward config
@@ -43,6 +47,23 @@ func (r *RewardConfig) RewardDescription() string {
 	return r.rewardDescription
 }
 
+// WithTranslations sets the reward's name and description by locale
+func (r *RewardConfig) WithTranslations(names, descriptions map[string]string) *RewardConfig {
+	r.nameTranslations = names
+	r.descriptionTranslations = descriptions
+	return r
+}
+
+// NameTranslations returns the reward name by locale
+func (r *RewardConfig) NameTranslations() map[string]string {
+	return r.nameTranslations
+}
+
+// DescriptionTranslations returns the reward description by locale
+func (r *RewardConfig) DescriptionTranslations() map[string]string {
+	return r.descriptionTranslations
+}
+
 // RewardConfigRepository defines persistence contract
 type RewardConfigRepository interface {
 	// FindByCheckpoint loads reward config for checkpoint
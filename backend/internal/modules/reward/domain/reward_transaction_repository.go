@@ -1,6 +1,10 @@
 package domain
 
-import "context"
+import (
+	"context"
+
+	shared "backend/internal/shared/domain"
+)
 
 // RewardTransactionRepository defines persistence contract
 type RewardTransactionRepository interface {
@@ -18,6 +22,22 @@ type RewardTransactionRepository interface {
 
 	// ListByPlayer returns all rewards claimed by player with config info
 	ListByPlayer(ctx context.Context, playerID string) ([]*RewardTransactionWithConfig, error)
+
+	// ListByPlayerCursor returns cursor-paginated rewards claimed by player, newest first
+	ListByPlayerCursor(ctx context.Context, playerID string, params shared.CursorParams) (*RewardTransactionCursorResult, error)
+
+	// StreamForExport passes claims matching filter to fn in batches, oldest
+	// first, without loading the whole result
+	StreamForExport(ctx context.Context, filter RewardTransactionFilter, batchSize int, fn func([]*RewardTransactionWithConfig) error) error
+}
+
+// RewardTransactionCursorResult contains cursor-paginated results
+type RewardTransactionCursorResult struct {
+	Data       []*RewardTransactionWithConfig
+	NextCursor string
+	PrevCursor string
+	HasMore    bool
+	HasPrev    bool
 }
 
 // RewardTransactionWithConfig includes reward config details
@@ -25,4 +45,9 @@ type RewardTransactionWithConfig struct {
 	Transaction       *RewardTransaction
 	RewardName        string
 	RewardDescription string
+	PlayerNickname    string // only set by exports
+
+	// Reward name and description by locale
+	RewardNameTranslations        map[string]string
+	RewardDescriptionTranslations map[string]string
 }
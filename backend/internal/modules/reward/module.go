@@ -1,17 +1,21 @@
 package reward
 
 import (
-	"gorm.io/gorm"
+	"context"
 
 	"github.com/gofiber/fiber/v2"
 
 	"backend/internal/infrastructure/config"
 	playerdomain "backend/internal/modules/player/domain"
 	"backend/internal/modules/reward/adapter/handler"
-	"backend/internal/modules/reward/adapter/repository"
 	"backend/internal/modules/reward/application/claim"
+	"backend/internal/modules/reward/application/export_rewards"
 	"backend/internal/modules/reward/application/get_history"
 	"backend/internal/modules/reward/domain"
+	shared "backend/internal/shared/domain"
+	"backend/internal/shared/events"
+	"backend/internal/shared/export"
+	httputil "backend/internal/shared/http"
 )
 
 // Module represents the reward module
@@ -23,17 +27,21 @@ type Module struct {
 
 // NewModule creates a new reward module
 func NewModule(
-	db *gorm.DB,
 	cfg *config.Config,
+	configRepo domain.RewardConfigRepository,
+	txRepo domain.RewardTransactionRepository,
 	playerRepo playerdomain.PlayerRepository,
+	publisher events.Publisher,
 ) *Module {
-	configRepo := repository.NewRewardConfigRepositoryGorm(db)
-	txRepo := repository.NewRewardTransactionRepositoryGorm(db)
+	claimUC := claim.New(txRepo, configRepo, playerRepo, publisher)
+	getHistoryUC := get_history.New(txRepo, playerRepo, shared.PaginationConfig{
+		DefaultLimit: cfg.Pagination.DefaultLimit,
+		MaxLimit:     cfg.Pagination.MaxLimit,
+	})
 
-	claimUC := claim.New(txRepo, configRepo, playerRepo)
-	getHistoryUC := get_history.New(txRepo)
+	exportUC := export_rewards.New(txRepo, export.DefaultOptions(cfg.Export.Timezone, cfg.Export.BatchSize, cfg.Export.RewardColumns))
 
-	h := handler.NewRewardHandler(claimUC, getHistoryUC)
+	h := handler.NewRewardHandler(claimUC, getHistoryUC, exportUC)
 
 	return &Module{
 		Handler:          h,
@@ -42,7 +50,27 @@ func NewModule(
 	}
 }
 
-// RegisterRoutes registers reward routes
-func (m *Module) RegisterRoutes(app *fiber.App) {
-	m.Handler.RegisterRoutes(app)
+// RegisterRoutes registers reward routes on a versioned API router
+func (m *Module) RegisterRoutes(router fiber.Router) {
+	m.Handler.RegisterRoutes(router)
+}
+
+// RegisterAdminRoutes registers admin routes on a router guarded by admin auth
+func (m *Module) RegisterAdminRoutes(admin fiber.Router) {
+	m.Handler.RegisterAdminRoutes(admin)
+}
+
+// RegisterReadPinning calls pin with the player of every claimed reward so
+// their next reads see it (read-your-writes)
+func (m *Module) RegisterReadPinning(bus *events.Bus, pin func(playerID string)) {
+	bus.Subscribe("reward.claimed", func(ctx context.Context, event shared.DomainEvent) {
+		if e, ok := event.(*domain.RewardClaimedEvent); ok {
+			pin(e.PlayerID)
+		}
+	})
+}
+
+// RegisterErrors registers the reward module's errors for the HTTP error handler
+func (m *Module) RegisterErrors(r *httputil.ErrorRegistry) {
+	handler.RegisterErrors(r)
 }
@@ -0,0 +1,116 @@
+package reward_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"backend/internal/adapter/http/middleware"
+	"backend/internal/infrastructure/config"
+	playerrepo "backend/internal/modules/player/adapter/repository"
+	playerdomain "backend/internal/modules/player/domain"
+	"backend/internal/modules/reward"
+	rewardrepo "backend/internal/modules/reward/adapter/repository"
+	rewarddomain "backend/internal/modules/reward/domain"
+	"backend/internal/shared/cursor"
+	"backend/internal/shared/events"
+	httputil "backend/internal/shared/http"
+)
+
+const adminKey = "test-admin-key-test-admin-key-0000"
+
+// newApp serves the module on /v1 with the admin routes under /v1/admin
+func newApp() *fiber.App {
+	cfg := &config.Config{
+		Pagination: config.PaginationConfig{DefaultLimit: 20, MaxLimit: 100},
+		Export:     config.ExportConfig{Timezone: "UTC", BatchSize: 100, RewardColumns: []string{"id", "player_id", "nickname"}},
+	}
+	players := playerrepo.NewPlayerRepositoryMemory(playerdomain.NewPlayerFactory(3, 20))
+	configs := rewardrepo.NewRewardConfigRepositoryMemory(nil)
+	txs := rewardrepo.NewRewardTransactionRepositoryMemory(configs, players, cursor.NewCodec("secret"))
+	m := reward.NewModule(cfg, configs, txs, players, events.NewBus())
+
+	registry := httputil.NewErrorRegistry()
+	m.RegisterErrors(registry)
+	app := fiber.New(fiber.Config{ErrorHandler: httputil.ErrorHandler(registry)})
+	v1 := app.Group("/v1")
+	m.RegisterRoutes(v1)
+	m.RegisterAdminRoutes(v1.Group("/admin", middleware.AdminAuth(adminKey)))
+
+	return app
+}
+
+func TestExportRequiresAdminKey(t *testing.T) {
+	app := newApp()
+
+	tests := []struct {
+		name   string
+		path   string
+		key    string
+		status int
+		csv    bool
+	}{
+		{"no key", "/v1/admin/rewards/export", "", fiber.StatusUnauthorized, false},
+		{"wrong key", "/v1/admin/rewards/export", "nope", fiber.StatusUnauthorized, false},
+		{"admin key", "/v1/admin/rewards/export", adminKey, fiber.StatusOK, true},
+		// Not an export any more: claim history of an unknown player "export"
+		{"public path", "/v1/rewards/export", "", fiber.StatusOK, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodGet, tt.path, nil)
+			if tt.key != "" {
+				req.Header.Set(middleware.HeaderAdminKey, tt.key)
+			}
+			res, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, _ := io.ReadAll(res.Body)
+			if res.StatusCode != tt.status {
+				t.Fatalf("status = %d, want %d (%s)", res.StatusCode, tt.status, body)
+			}
+			if csv := strings.HasPrefix(res.Header.Get(fiber.HeaderContentType), "text/csv"); csv != tt.csv {
+				t.Errorf("served CSV = %v, want %v (%s)", csv, tt.csv, body)
+			}
+		})
+	}
+}
+
+func TestResponsesUseEnvelope(t *testing.T) {
+	res, err := newApp().Test(httptest.NewRequest(fiber.MethodGet, "/v1/rewards/00000000-0000-0000-0000-000000000000", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var env struct {
+		Success bool            `json:"success"`
+		Data    json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&env); err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != fiber.StatusOK || !env.Success || len(env.Data) == 0 {
+		t.Fatalf("status %d, body %+v; want 200 with data", res.StatusCode, env)
+	}
+}
+
+func TestClaimPinsPlayerReads(t *testing.T) {
+	players := playerrepo.NewPlayerRepositoryMemory(playerdomain.NewPlayerFactory(3, 20))
+	configs := rewardrepo.NewRewardConfigRepositoryMemory(nil)
+	txs := rewardrepo.NewRewardTransactionRepositoryMemory(configs, players, cursor.NewCodec("secret"))
+	bus := events.NewBus()
+	m := reward.NewModule(&config.Config{}, configs, txs, players, bus)
+
+	var pinned []string
+	m.RegisterReadPinning(bus, func(playerID string) { pinned = append(pinned, playerID) })
+	bus.Publish(context.Background(), rewarddomain.NewRewardClaimedEvent("p1", 1000, "Bronze"))
+
+	if len(pinned) != 1 || pinned[0] != "p1" {
+		t.Fatalf("pinned %v, want [p1]", pinned)
+	}
+}
@@ -6,4 +6,8 @@ const (
     TableSpinLogs            = "spin_logs"
     TableRewardConfig        = "reward_config"
     TableRewardTransactions  = "reward_transactions"
+    TableIdempotencyKeys     = "idempotency_keys"
+    TableRateLimitBuckets    = "rate_limit_buckets"
+    TableReferrals           = "referrals"
+    TableComplianceLog       = "compliance_log"
 )
\ No newline at end of file
@@ -8,4 +8,30 @@ const (
     ErrCodeDailyLimitExceeded  = "DAILY_LIMIT_EXCEEDED"
     ErrCodeInvalidCheckpoint   = "INVALID_CHECKPOINT"
     ErrCodeValidationFailed    = "VALIDATION_FAILED"
+    ErrCodeRateLimitExceeded   = "RATE_LIMIT_EXCEEDED"
+    ErrCodeInternal            = "INTERNAL_ERROR"
+
+    // Player lifecycle errors
+    ErrCodePlayerSuspended = "PLAYER_SUSPENDED"
+    ErrCodePlayerBanned    = "PLAYER_BANNED"
+    ErrCodePlayerDeleted   = "PLAYER_DELETED"
+    ErrCodeNicknameTaken   = "NICKNAME_TAKEN"
+    ErrCodeRenameCooldown  = "RENAME_COOLDOWN"
+    ErrCodeUnauthorized    = "UNAUTHORIZED"
+
+    // Optimistic concurrency: the player changed while the request ran
+    ErrCodeConcurrentModification = "CONCURRENT_MODIFICATION"
+
+    // Referral errors
+    ErrCodeInvalidReferralCode  = "INVALID_REFERRAL_CODE"
+    ErrCodeSelfReferral         = "SELF_REFERRAL"
+    ErrCodeReferralLimitReached = "REFERRAL_LIMIT_REACHED"
+
+    // Privacy errors
+    ErrCodeAlreadyErased = "ALREADY_ERASED"
+
+    // Idempotency-Key errors
+    ErrCodeInvalidIdempotencyKey = "INVALID_IDEMPOTENCY_KEY"
+    ErrCodeIdempotencyKeyReused  = "IDEMPOTENCY_KEY_REUSED"
+    ErrCodeIdempotencyKeyInUse   = "IDEMPOTENCY_KEY_IN_USE"
 )
\ No newline at end of file
@@ -0,0 +1,66 @@
+// Package contracttest holds the behaviour every repository implementation
+// must share. Each suite takes a constructor and runs as subtests, so the
+// in-memory and GORM implementations are held to the same contract; GORM
+// suites run against a freshly migrated SQLite file, and against the migrated
+// Postgres named by TEST_DATABASE_URL when it is set.
+package contracttest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"backend/internal/infrastructure/database"
+	"backend/internal/infrastructure/database/migrations"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// OpenPostgres connects to TEST_DATABASE_URL and skips the test when it is not set
+func OpenPostgres(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	return db
+}
+
+// OpenSQLite creates a SQLite file in the test's temp dir and migrates it
+func OpenSQLite(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := database.OpenSQLite(filepath.Join(t.TempDir(), "contract.db"), logger.Default.LogMode(logger.Silent))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	migrator, err := migrations.NewMigrator(db)
+	if err != nil {
+		t.Fatalf("create migrator: %v", err)
+	}
+	if err := migrator.Up(); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return db
+}
+
+// UniqueName returns prefix plus random hex, so tests sharing a database
+// never see each other's rows
+func UniqueName(prefix string) string {
+	b := make([]byte, 6)
+	_, _ = rand.Read(b)
+	return prefix + hex.EncodeToString(b)
+}
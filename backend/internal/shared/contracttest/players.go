@@ -0,0 +1,204 @@
+package contracttest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	playerdomain "backend/internal/modules/player/domain"
+	shared "backend/internal/shared/domain"
+)
+
+// PlayerRepository checks a PlayerRepository; newRepo is called once per subtest
+func PlayerRepository(t *testing.T, newRepo func(t *testing.T) playerdomain.PlayerRepository) {
+	factory := playerdomain.NewPlayerFactory(3, 50)
+	ctx := context.Background()
+
+	store := func(t *testing.T, repo playerdomain.PlayerRepository) *playerdomain.Player {
+		t.Helper()
+		player, err := factory.CreateNewPlayer(UniqueName("ct-"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		player.RecordSignupIP("203.0.113.7")
+		if err := repo.Store(ctx, player); err != nil {
+			t.Fatalf("store: %v", err)
+		}
+		return player
+	}
+
+	t.Run("finds stored players", func(t *testing.T) {
+		repo := newRepo(t)
+		player := store(t, repo)
+
+		byID, err := repo.FindByID(ctx, player.ID())
+		if err != nil {
+			t.Fatalf("find by id: %v", err)
+		}
+		if byID.Nickname().String() != player.Nickname().String() || byID.Version() != 1 || byID.SignupIP() != "203.0.113.7" {
+			t.Fatalf("loaded %s v%d from %q, want %s v1 from 203.0.113.7", byID.Nickname(), byID.Version(), byID.SignupIP(), player.Nickname())
+		}
+		if _, err := repo.FindByNickname(ctx, player.Nickname()); err != nil {
+			t.Fatalf("find by nickname: %v", err)
+		}
+		if _, err := repo.FindByInviteCode(ctx, player.InviteCode()); err != nil {
+			t.Fatalf("find by invite code: %v", err)
+		}
+		if exists, err := repo.ExistsByNickname(ctx, player.Nickname()); err != nil || !exists {
+			t.Fatalf("exists = %v, %v; want true", exists, err)
+		}
+	})
+
+	t.Run("reports unknown players", func(t *testing.T) {
+		repo := newRepo(t)
+		if _, err := repo.FindByID(ctx, playerdomain.GeneratePlayerID()); !errors.Is(err, shared.ErrPlayerNotFound) {
+			t.Fatalf("find by id: got %v, want ErrPlayerNotFound", err)
+		}
+		nickname, _ := playerdomain.NewNickname(UniqueName("ct-"), 3, 50)
+		if _, err := repo.FindByNickname(ctx, nickname); !errors.Is(err, shared.ErrPlayerNotFound) {
+			t.Fatalf("find by nickname: got %v, want ErrPlayerNotFound", err)
+		}
+		if exists, err := repo.ExistsByNickname(ctx, nickname); err != nil || exists {
+			t.Fatalf("exists = %v, %v; want false", exists, err)
+		}
+	})
+
+	t.Run("rejects a taken nickname", func(t *testing.T) {
+		repo := newRepo(t)
+		player := store(t, repo)
+
+		twin, err := factory.CreateNewPlayer(player.Nickname().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.Store(ctx, twin); err == nil {
+			t.Fatal("stored a second player with the same nickname")
+		}
+	})
+
+	t.Run("update advances the version", func(t *testing.T) {
+		repo := newRepo(t)
+		player := store(t, repo)
+
+		loaded, err := repo.FindByID(ctx, player.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		points, _ := shared.NewPoints(150)
+		if err := loaded.AddPoints(points); err != nil {
+			t.Fatal(err)
+		}
+		if err := loaded.ChangeLocale("th", time.Now()); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.Update(ctx, loaded); err != nil {
+			t.Fatalf("update: %v", err)
+		}
+		if loaded.Version() != 2 {
+			t.Fatalf("version after update = %d, want 2", loaded.Version())
+		}
+
+		reloaded, err := repo.FindByID(ctx, player.ID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reloaded.TotalPoints().Value() != 150 || reloaded.Version() != 2 || reloaded.Locale() != "th" {
+			t.Fatalf("reloaded %d points v%d locale %q, want 150 v2 th", reloaded.TotalPoints().Value(), reloaded.Version(), reloaded.Locale())
+		}
+	})
+
+	t.Run("update rejects a stale copy", func(t *testing.T) {
+		repo := newRepo(t)
+		player := store(t, repo)
+
+		first, _ := repo.FindByID(ctx, player.ID())
+		second, _ := repo.FindByID(ctx, player.ID())
+		if err := repo.Update(ctx, first); err != nil {
+			t.Fatalf("first update: %v", err)
+		}
+		if err := repo.Update(ctx, second); !errors.Is(err, shared.ErrConcurrentModification) {
+			t.Fatalf("stale update: got %v, want ErrConcurrentModification", err)
+		}
+	})
+
+	t.Run("one of many concurrent updates wins", func(t *testing.T) {
+		repo := newRepo(t)
+		player := store(t, repo)
+
+		const writers = 8
+		copies := make([]*playerdomain.Player, writers)
+		for i := range copies {
+			loaded, err := repo.FindByID(ctx, player.ID())
+			if err != nil {
+				t.Fatal(err)
+			}
+			copies[i] = loaded
+		}
+
+		errs := make(chan error, writers)
+		var wg sync.WaitGroup
+		for _, p := range copies {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs <- repo.Update(ctx, p)
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		won := 0
+		for err := range errs {
+			switch {
+			case err == nil:
+				won++
+			case !errors.Is(err, shared.ErrConcurrentModification):
+				t.Fatalf("update: %v", err)
+			}
+		}
+		if won != 1 {
+			t.Fatalf("%d updates won, want 1", won)
+		}
+	})
+
+	t.Run("update reports a missing player", func(t *testing.T) {
+		repo := newRepo(t)
+		player, err := factory.CreateNewPlayer(UniqueName("ct-"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.Update(ctx, player); !errors.Is(err, shared.ErrPlayerNotFound) {
+			t.Fatalf("got %v, want ErrPlayerNotFound", err)
+		}
+	})
+
+	t.Run("releases nicknames of deleted players", func(t *testing.T) {
+		repo := newRepo(t)
+		player := store(t, repo)
+
+		loaded, _ := repo.FindByID(ctx, player.ID())
+		if err := loaded.Delete("contract test", time.Now().Add(-time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.Update(ctx, loaded); err != nil {
+			t.Fatalf("update: %v", err)
+		}
+
+		released, err := repo.ReleaseNicknames(ctx, time.Now())
+		if err != nil {
+			t.Fatalf("release: %v", err)
+		}
+		if released < 1 {
+			t.Fatalf("released %d nicknames, want at least 1", released)
+		}
+		if exists, _ := repo.ExistsByNickname(ctx, player.Nickname()); exists {
+			t.Fatal("released nickname is still taken")
+		}
+		// Releasing bumps the version, so the copy loaded before is stale
+		if err := repo.Update(ctx, loaded); !errors.Is(err, shared.ErrConcurrentModification) {
+			t.Fatalf("update after release: got %v, want ErrConcurrentModification", err)
+		}
+	})
+}
@@ -0,0 +1,203 @@
+package contracttest
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	rewarddomain "backend/internal/modules/reward/domain"
+	shared "backend/internal/shared/domain"
+)
+
+// RewardConfigRepository checks a RewardConfigRepository holding at least
+// seeded; their translations must round-trip too
+func RewardConfigRepository(t *testing.T, repo rewarddomain.RewardConfigRepository, seeded []*rewarddomain.RewardConfig) {
+	ctx := context.Background()
+
+	t.Run("finds configs by checkpoint", func(t *testing.T) {
+		for _, want := range seeded {
+			got, err := repo.FindByCheckpoint(ctx, want.CheckpointVal())
+			if err != nil {
+				t.Fatalf("checkpoint %d: %v", want.CheckpointVal(), err)
+			}
+			if got.RewardName() != want.RewardName() || got.RewardDescription() != want.RewardDescription() {
+				t.Fatalf("checkpoint %d = %q/%q, want %q/%q", want.CheckpointVal(), got.RewardName(), got.RewardDescription(), want.RewardName(), want.RewardDescription())
+			}
+			if !maps.Equal(got.NameTranslations(), want.NameTranslations()) || !maps.Equal(got.DescriptionTranslations(), want.DescriptionTranslations()) {
+				t.Fatalf("checkpoint %d translations = %v/%v, want %v/%v", want.CheckpointVal(), got.NameTranslations(), got.DescriptionTranslations(), want.NameTranslations(), want.DescriptionTranslations())
+			}
+		}
+		if _, err := repo.FindByCheckpoint(ctx, -1); !errors.Is(err, shared.ErrInvalidCheckpoint) {
+			t.Fatalf("unknown checkpoint: got %v, want ErrInvalidCheckpoint", err)
+		}
+	})
+
+	t.Run("lists all configs in checkpoint order", func(t *testing.T) {
+		all, err := repo.FindAll(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var checkpoints []int
+		for _, config := range all {
+			checkpoints = append(checkpoints, config.CheckpointVal())
+		}
+		if !slices.IsSorted(checkpoints) {
+			t.Fatalf("checkpoints %v are not ascending", checkpoints)
+		}
+		for _, want := range seeded {
+			if !slices.Contains(checkpoints, want.CheckpointVal()) {
+				t.Fatalf("checkpoint %d missing from %v", want.CheckpointVal(), checkpoints)
+			}
+		}
+	})
+}
+
+// RewardTransactionFixture is a reward transaction repository plus the
+// players and reward configs its rows refer to
+type RewardTransactionFixture struct {
+	Repo rewarddomain.RewardTransactionRepository
+	// AddPlayer makes a player with nickname exist and returns its ID
+	AddPlayer func(nickname string) string
+	// Checkpoints are at least three configured checkpoints in ascending
+	// order, each with a translated reward name
+	Checkpoints []int
+}
+
+// RewardTransactionRepository checks a RewardTransactionRepository; newFixture
+// is called once per subtest
+func RewardTransactionRepository(t *testing.T, newFixture func(t *testing.T) RewardTransactionFixture) {
+	ctx := context.Background()
+	base := time.Now().UTC().Truncate(time.Second)
+
+	claim := func(t *testing.T, repo rewarddomain.RewardTransactionRepository, playerID string, checkpointVal int, at time.Time) *rewarddomain.RewardTransaction {
+		t.Helper()
+		tx, err := rewarddomain.ReconstructRewardTransaction(rewarddomain.GenerateRewardTransactionID().String(), playerID, checkpointVal, at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.Store(ctx, tx); err != nil {
+			t.Fatalf("store: %v", err)
+		}
+		return tx
+	}
+
+	t.Run("stores claims once per checkpoint", func(t *testing.T) {
+		f := newFixture(t)
+		playerID := f.AddPlayer(UniqueName("ct-"))
+		first := claim(t, f.Repo, playerID, f.Checkpoints[1], base.Add(-time.Minute))
+		claim(t, f.Repo, playerID, f.Checkpoints[0], base)
+
+		if _, err := f.Repo.FindByID(ctx, first.ID()); err != nil {
+			t.Fatalf("find by id: %v", err)
+		}
+		if exists, err := f.Repo.ExistsByPlayerAndCheckpoint(ctx, playerID, f.Checkpoints[1]); err != nil || !exists {
+			t.Fatalf("exists = %v, %v; want true", exists, err)
+		}
+		if exists, err := f.Repo.ExistsByPlayerAndCheckpoint(ctx, playerID, f.Checkpoints[2]); err != nil || exists {
+			t.Fatalf("exists = %v, %v; want false", exists, err)
+		}
+		claimed, err := f.Repo.GetClaimedCheckpoints(ctx, playerID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(claimed, f.Checkpoints[:2]) {
+			t.Fatalf("claimed = %v, want %v", claimed, f.Checkpoints[:2])
+		}
+
+		again, _ := rewarddomain.ReconstructRewardTransaction(rewarddomain.GenerateRewardTransactionID().String(), playerID, f.Checkpoints[1], base)
+		if err := f.Repo.Store(ctx, again); err == nil {
+			t.Fatal("stored a second claim for the same checkpoint")
+		}
+	})
+
+	t.Run("lists claims newest first with reward names", func(t *testing.T) {
+		f := newFixture(t)
+		playerID := f.AddPlayer(UniqueName("ct-"))
+		older := claim(t, f.Repo, playerID, f.Checkpoints[0], base.Add(-time.Minute))
+		newer := claim(t, f.Repo, playerID, f.Checkpoints[1], base)
+
+		list, err := f.Repo.ListByPlayer(ctx, playerID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []string{newer.ID().String(), older.ID().String()}
+		if !slices.Equal(rewardIDs(list), want) {
+			t.Fatalf("listed %v, want %v", rewardIDs(list), want)
+		}
+		for _, item := range list {
+			if item.RewardName == "" || len(item.RewardNameTranslations) == 0 {
+				t.Fatalf("claim %s has no reward name or translations", item.Transaction.ID())
+			}
+		}
+	})
+
+	t.Run("pages claims in both directions", func(t *testing.T) {
+		f := newFixture(t)
+		playerID := f.AddPlayer(UniqueName("ct-"))
+		var want []string
+		for i, checkpointVal := range f.Checkpoints[:3] {
+			tx := claim(t, f.Repo, playerID, checkpointVal, base.Add(-time.Duration(i)*time.Minute))
+			want = append(want, tx.ID().String())
+		}
+
+		first, err := f.Repo.ListByPlayerCursor(ctx, playerID, shared.CursorParams{Limit: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(rewardIDs(first.Data), want[:2]) || !first.HasMore || first.HasPrev {
+			t.Fatalf("first page = %v (more %v, prev %v), want %v with more", rewardIDs(first.Data), first.HasMore, first.HasPrev, want[:2])
+		}
+		second, err := f.Repo.ListByPlayerCursor(ctx, playerID, shared.CursorParams{Limit: 2, Cursor: first.NextCursor})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(rewardIDs(second.Data), want[2:]) || second.HasMore || !second.HasPrev {
+			t.Fatalf("second page = %v (more %v, prev %v), want %v with prev", rewardIDs(second.Data), second.HasMore, second.HasPrev, want[2:])
+		}
+		back, err := f.Repo.ListByPlayerCursor(ctx, playerID, shared.CursorParams{Limit: 2, Cursor: second.PrevCursor})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(rewardIDs(back.Data), want[:2]) || back.HasPrev {
+			t.Fatalf("back = %v (prev %v), want %v without prev", rewardIDs(back.Data), back.HasPrev, want[:2])
+		}
+	})
+
+	t.Run("streams exports oldest first with nicknames", func(t *testing.T) {
+		f := newFixture(t)
+		nickname := UniqueName("ct-")
+		playerID := f.AddPlayer(nickname)
+		var want []string
+		for i, checkpointVal := range f.Checkpoints[:3] {
+			want = append(want, claim(t, f.Repo, playerID, checkpointVal, base.Add(time.Duration(i)*time.Minute)).ID().String())
+		}
+
+		var got []string
+		err := f.Repo.StreamForExport(ctx, rewarddomain.RewardTransactionFilter{PlayerID: playerID}, 2, func(batch []*rewarddomain.RewardTransactionWithConfig) error {
+			for _, item := range batch {
+				if item.PlayerNickname != nickname || item.RewardName == "" {
+					t.Errorf("claim %s: nickname %q, reward %q", item.Transaction.ID(), item.PlayerNickname, item.RewardName)
+				}
+			}
+			got = append(got, rewardIDs(batch)...)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(got, want) {
+			t.Fatalf("streamed %v, want %v", got, want)
+		}
+	})
+}
+
+func rewardIDs(items []*rewarddomain.RewardTransactionWithConfig) []string {
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.Transaction.ID().String()
+	}
+	return ids
+}
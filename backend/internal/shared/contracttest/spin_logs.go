@@ -0,0 +1,346 @@
+package contracttest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	historydomain "backend/internal/modules/history/domain"
+	"backend/internal/shared/constants"
+	"backend/internal/shared/cursor"
+	shared "backend/internal/shared/domain"
+)
+
+// SpinLogFixture is a spin log repository plus a way to create the players
+// its rows belong to
+type SpinLogFixture struct {
+	Repo historydomain.SpinLogRepository
+	// AddPlayer makes a player with nickname exist and returns its ID
+	AddPlayer func(nickname string) string
+}
+
+// SpinLogRepository checks a SpinLogRepository; newFixture is called once per subtest
+func SpinLogRepository(t *testing.T, newFixture func(t *testing.T) SpinLogFixture) {
+	ctx := context.Background()
+	base := time.Now().UTC().Truncate(time.Second)
+
+	store := func(t *testing.T, repo historydomain.SpinLogRepository, playerID string, points int, source constants.SpinSource, at time.Time) *historydomain.SpinLog {
+		t.Helper()
+		spinLog, err := historydomain.ReconstructSpinLog(historydomain.GenerateSpinLogID().String(), playerID, points, string(source), at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.Store(ctx, spinLog); err != nil {
+			t.Fatalf("store: %v", err)
+		}
+		return spinLog
+	}
+
+	t.Run("pages newest first in both directions", func(t *testing.T) {
+		f := newFixture(t)
+		playerID := f.AddPlayer(UniqueName("ct-"))
+
+		// Two logs share a timestamp so the id has to break the tie
+		var logs []*historydomain.SpinLog
+		for _, minutes := range []int{1, 2, 3, 3, 4, 5} {
+			logs = append(logs, store(t, f.Repo, playerID, 100, constants.SpinSourceGame, base.Add(-time.Duration(minutes)*time.Minute)))
+		}
+		slices.SortFunc(logs, func(a, b *historydomain.SpinLog) int {
+			if c := b.CreatedAt().Compare(a.CreatedAt()); c != 0 {
+				return c
+			}
+			return strings.Compare(b.ID().String(), a.ID().String())
+		})
+		want := spinLogIDs(logs)
+
+		var pages []*historydomain.SpinLogCursorResult
+		next := ""
+		for {
+			page, err := f.Repo.ListByPlayerCursor(ctx, playerID, shared.CursorParams{Limit: 2, Cursor: next}, historydomain.SpinLogFilter{})
+			if err != nil {
+				t.Fatalf("page %d: %v", len(pages)+1, err)
+			}
+			pages = append(pages, page)
+			if !page.HasMore {
+				break
+			}
+			if len(pages) > len(want) {
+				t.Fatal("pagination does not end")
+			}
+			next = page.NextCursor
+		}
+
+		var got []string
+		for _, page := range pages {
+			for _, item := range page.Data {
+				got = append(got, item.SpinLog.ID().String())
+			}
+		}
+		if !slices.Equal(got, want) {
+			t.Fatalf("paged ids = %v, want %v", got, want)
+		}
+		if pages[0].HasPrev || pages[0].PrevCursor != "" {
+			t.Fatal("first page has a previous page")
+		}
+
+		// Going back from the last page returns the same pages
+		last := pages[len(pages)-1]
+		prev, err := f.Repo.ListByPlayerCursor(ctx, playerID, shared.CursorParams{Limit: 2, Cursor: last.PrevCursor}, historydomain.SpinLogFilter{})
+		if err != nil {
+			t.Fatalf("prev page: %v", err)
+		}
+		if !slices.Equal(resultIDs(prev), want[2:4]) || !prev.HasPrev || !prev.HasMore {
+			t.Fatalf("prev page = %v (prev %v, more %v), want %v with both", resultIDs(prev), prev.HasPrev, prev.HasMore, want[2:4])
+		}
+		first, err := f.Repo.ListByPlayerCursor(ctx, playerID, shared.CursorParams{Limit: 2, Cursor: prev.PrevCursor}, historydomain.SpinLogFilter{})
+		if err != nil {
+			t.Fatalf("first page: %v", err)
+		}
+		if !slices.Equal(resultIDs(first), want[:2]) || first.HasPrev {
+			t.Fatalf("first page = %v (prev %v), want %v without prev", resultIDs(first), first.HasPrev, want[:2])
+		}
+	})
+
+	t.Run("rejects a cursor from another listing", func(t *testing.T) {
+		f := newFixture(t)
+		playerID := f.AddPlayer(UniqueName("ct-"))
+		for i := range 3 {
+			store(t, f.Repo, playerID, 100, constants.SpinSourceGame, base.Add(-time.Duration(i)*time.Minute))
+		}
+
+		page, err := f.Repo.ListByPlayerCursor(ctx, playerID, shared.CursorParams{Limit: 1}, historydomain.SpinLogFilter{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		other := f.AddPlayer(UniqueName("ct-"))
+		if _, err := f.Repo.ListByPlayerCursor(ctx, other, shared.CursorParams{Limit: 1, Cursor: page.NextCursor}, historydomain.SpinLogFilter{}); !errors.Is(err, cursor.ErrMismatch) {
+			t.Fatalf("other player: got %v, want ErrMismatch", err)
+		}
+		filter := historydomain.SpinLogFilter{Source: constants.SpinSourceGame}
+		if _, err := f.Repo.ListByPlayerCursor(ctx, playerID, shared.CursorParams{Limit: 1, Cursor: page.NextCursor}, filter); !errors.Is(err, cursor.ErrMismatch) {
+			t.Fatalf("other filter: got %v, want ErrMismatch", err)
+		}
+	})
+
+	t.Run("filters listings", func(t *testing.T) {
+		f := newFixture(t)
+		// Every row belongs to prefix so other subtests' rows never match;
+		// the underscore must match literally, not as a LIKE wildcard
+		prefix := UniqueName("ct-")
+		alice := f.AddPlayer(prefix + "-al_ce")
+		bob := f.AddPlayer(prefix + "-alxce")
+		l1 := store(t, f.Repo, alice, 100, constants.SpinSourceGame, base.Add(-4*time.Minute))
+		l2 := store(t, f.Repo, alice, 500, constants.SpinSourceGame, base.Add(-3*time.Minute))
+		l3 := store(t, f.Repo, alice, 500, constants.SpinSourceBonus, base.Add(-2*time.Minute))
+		l4 := store(t, f.Repo, bob, 300, constants.SpinSourceAdmin, base.Add(-time.Minute))
+		l5 := store(t, f.Repo, bob, 50, constants.SpinSourceGame, base)
+
+		at := func(minutes int) *time.Time {
+			t := base.Add(-time.Duration(minutes) * time.Minute)
+			return &t
+		}
+		points := func(n int) *int { return &n }
+
+		tests := []struct {
+			name   string
+			filter historydomain.SpinLogFilter
+			want   []*historydomain.SpinLog // newest first
+		}{
+			{"prefix only", historydomain.SpinLogFilter{}, []*historydomain.SpinLog{l5, l4, l3, l2, l1}},
+			{"from is inclusive", historydomain.SpinLogFilter{From: at(2)}, []*historydomain.SpinLog{l5, l4, l3}},
+			{"to is exclusive", historydomain.SpinLogFilter{To: at(2)}, []*historydomain.SpinLog{l2, l1}},
+			{"date range", historydomain.SpinLogFilter{From: at(3), To: at(1)}, []*historydomain.SpinLog{l3, l2}},
+			{"source", historydomain.SpinLogFilter{Source: constants.SpinSourceBonus}, []*historydomain.SpinLog{l3}},
+			{"min points", historydomain.SpinLogFilter{MinPoints: points(300)}, []*historydomain.SpinLog{l4, l3, l2}},
+			{"max points", historydomain.SpinLogFilter{MaxPoints: points(300)}, []*historydomain.SpinLog{l5, l4, l1}},
+			{"exact points", historydomain.SpinLogFilter{MinPoints: points(500), MaxPoints: points(500)}, []*historydomain.SpinLog{l3, l2}},
+			{"nickname prefix", historydomain.SpinLogFilter{NicknamePrefix: prefix + "-al_"}, []*historydomain.SpinLog{l3, l2, l1}},
+			{"combined", historydomain.SpinLogFilter{NicknamePrefix: prefix + "-al_", Source: constants.SpinSourceGame, MinPoints: points(200)}, []*historydomain.SpinLog{l2}},
+			{"no match", historydomain.SpinLogFilter{Source: constants.SpinSourceAdmin, MaxPoints: points(100)}, nil},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				filter := tt.filter
+				if filter.NicknamePrefix == "" {
+					filter.NicknamePrefix = prefix
+				}
+				page, err := f.Repo.ListAllCursor(ctx, shared.CursorParams{Limit: 10}, filter)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if want := spinLogIDs(tt.want); !slices.Equal(resultIDs(page), want) {
+					t.Fatalf("ids = %v, want %v", resultIDs(page), want)
+				}
+			})
+		}
+
+		page, err := f.Repo.ListAllCursor(ctx, shared.CursorParams{Limit: 10}, historydomain.SpinLogFilter{NicknamePrefix: prefix + "-al_"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if page.Data[0].PlayerNickname != prefix+"-al_ce" {
+			t.Fatalf("nickname = %q, want %q", page.Data[0].PlayerNickname, prefix+"-al_ce")
+		}
+
+		// The personal listing applies the same filters to one player
+		personal, err := f.Repo.ListByPlayerCursor(ctx, alice, shared.CursorParams{Limit: 10}, historydomain.SpinLogFilter{Source: constants.SpinSourceGame, To: at(3)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := spinLogIDs([]*historydomain.SpinLog{l1}); !slices.Equal(resultIDs(personal), want) {
+			t.Fatalf("personal ids = %v, want %v", resultIDs(personal), want)
+		}
+
+		// So does the export, oldest first
+		var exported []string
+		err = f.Repo.StreamForExport(ctx, "", historydomain.SpinLogFilter{NicknamePrefix: prefix, MinPoints: points(300)}, 10, func(batch []*historydomain.SpinLogWithPlayer) error {
+			for _, item := range batch {
+				exported = append(exported, item.SpinLog.ID().String())
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := spinLogIDs([]*historydomain.SpinLog{l2, l3, l4}); !slices.Equal(exported, want) {
+			t.Fatalf("exported ids = %v, want %v", exported, want)
+		}
+	})
+
+	t.Run("counts only today's game spins", func(t *testing.T) {
+		f := newFixture(t)
+		playerID := f.AddPlayer(UniqueName("ct-"))
+		now := time.Now()
+		store(t, f.Repo, playerID, 100, constants.SpinSourceGame, now)
+		store(t, f.Repo, playerID, 100, constants.SpinSourceGame, now)
+		store(t, f.Repo, playerID, 100, constants.SpinSourceBonus, now)
+		store(t, f.Repo, playerID, 100, constants.SpinSourceGame, now.Truncate(24*time.Hour).Add(-time.Hour))
+
+		count, err := f.Repo.CountTodayByPlayer(ctx, playerID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != 2 {
+			t.Fatalf("count = %d, want 2", count)
+		}
+	})
+
+	t.Run("aggregates stats", func(t *testing.T) {
+		f := newFixture(t)
+		playerID := f.AddPlayer(UniqueName("ct-"))
+
+		// Wednesday; the week started on Monday the 9th
+		now := time.Date(2026, 3, 11, 12, 0, 0, 0, time.UTC)
+		day := func(d, hour int) time.Time { return time.Date(2026, 3, d, hour, 0, 0, 0, time.UTC) }
+		store(t, f.Repo, playerID, 100, constants.SpinSourceGame, day(6, 9))
+		store(t, f.Repo, playerID, 100, constants.SpinSourceGame, day(7, 9))
+		store(t, f.Repo, playerID, 200, constants.SpinSourceGame, day(8, 9))
+		store(t, f.Repo, playerID, 100, constants.SpinSourceGame, day(10, 9))
+		store(t, f.Repo, playerID, 100, constants.SpinSourceGame, day(11, 8))
+		store(t, f.Repo, playerID, 300, constants.SpinSourceGame, day(11, 10))
+
+		stats, err := f.Repo.StatsByPlayer(ctx, playerID, now, shared.DayBoundary{Location: time.UTC})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stats.TotalSpins != 6 || stats.TotalPoints != 900 {
+			t.Errorf("totals = %d spins, %d points; want 6, 900", stats.TotalSpins, stats.TotalPoints)
+		}
+		if stats.SpinsToday != 2 || stats.PointsToday != 400 {
+			t.Errorf("today = %d spins, %d points; want 2, 400", stats.SpinsToday, stats.PointsToday)
+		}
+		if stats.SpinsThisWeek != 3 || stats.PointsThisWeek != 500 {
+			t.Errorf("this week = %d spins, %d points; want 3, 500", stats.SpinsThisWeek, stats.PointsThisWeek)
+		}
+		if stats.FirstSpinAt == nil || !stats.FirstSpinAt.Equal(day(6, 9)) || stats.LastSpinAt == nil || !stats.LastSpinAt.Equal(day(11, 10)) {
+			t.Errorf("first/last = %v/%v, want %v/%v", stats.FirstSpinAt, stats.LastSpinAt, day(6, 9), day(11, 10))
+		}
+		wantDistribution := []historydomain.OutcomeCount{{Points: 100, Count: 4}, {Points: 200, Count: 1}, {Points: 300, Count: 1}}
+		if !slices.Equal(stats.Distribution, wantDistribution) {
+			t.Errorf("distribution = %v, want %v", stats.Distribution, wantDistribution)
+		}
+		if stats.BestStreak != 3 || stats.CurrentStreak != 2 {
+			t.Errorf("streaks = best %d, current %d; want 3, 2", stats.BestStreak, stats.CurrentStreak)
+		}
+	})
+
+	t.Run("splits stats days at the day boundary", func(t *testing.T) {
+		f := newFixture(t)
+		playerID := f.AddPlayer(UniqueName("ct-"))
+		bangkok, err := time.LoadLocation("Asia/Bangkok")
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Days start at 04:00 in Bangkok (UTC+7), i.e. 21:00 UTC the day before
+		days := shared.DayBoundary{Location: bangkok, Offset: 4 * time.Hour}
+		now := time.Date(2026, 3, 11, 12, 0, 0, 0, time.UTC) // a Wednesday
+		at := func(d, hour, minute int) time.Time { return time.Date(2026, 3, d, hour, minute, 0, 0, time.UTC) }
+		store(t, f.Repo, playerID, 100, constants.SpinSourceGame, at(8, 12, 0))  // day 8
+		store(t, f.Repo, playerID, 100, constants.SpinSourceGame, at(9, 21, 30)) // day 10
+		store(t, f.Repo, playerID, 100, constants.SpinSourceGame, at(10, 20, 0)) // day 10
+		store(t, f.Repo, playerID, 200, constants.SpinSourceGame, at(10, 22, 0)) // day 11, today
+
+		stats, err := f.Repo.StatsByPlayer(ctx, playerID, now, days)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// In UTC the spins would fall on the 8th, 9th and 10th, none today
+		if stats.SpinsToday != 1 || stats.PointsToday != 200 {
+			t.Errorf("today = %d spins, %d points; want 1, 200", stats.SpinsToday, stats.PointsToday)
+		}
+		if stats.SpinsThisWeek != 3 || stats.PointsThisWeek != 400 {
+			t.Errorf("this week = %d spins, %d points; want 3, 400", stats.SpinsThisWeek, stats.PointsThisWeek)
+		}
+		if stats.BestStreak != 2 || stats.CurrentStreak != 2 {
+			t.Errorf("streaks = best %d, current %d; want 2, 2", stats.BestStreak, stats.CurrentStreak)
+		}
+	})
+
+	t.Run("streams exports oldest first in batches", func(t *testing.T) {
+		f := newFixture(t)
+		nickname := UniqueName("ct-")
+		playerID := f.AddPlayer(nickname)
+		var want []string
+		for i := range 3 {
+			want = append(want, store(t, f.Repo, playerID, 100, constants.SpinSourceGame, base.Add(time.Duration(i)*time.Minute)).ID().String())
+		}
+
+		var got []string
+		var sizes []int
+		err := f.Repo.StreamForExport(ctx, playerID, historydomain.SpinLogFilter{}, 2, func(batch []*historydomain.SpinLogWithPlayer) error {
+			sizes = append(sizes, len(batch))
+			for _, item := range batch {
+				if item.PlayerNickname != nickname {
+					t.Errorf("nickname = %q, want %q", item.PlayerNickname, nickname)
+				}
+				got = append(got, item.SpinLog.ID().String())
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(got, want) || !slices.Equal(sizes, []int{2, 1}) {
+			t.Fatalf("streamed %v in batches %v, want %v in [2 1]", got, sizes, want)
+		}
+	})
+}
+
+func spinLogIDs(logs []*historydomain.SpinLog) []string {
+	ids := make([]string, len(logs))
+	for i, l := range logs {
+		ids[i] = l.ID().String()
+	}
+	return ids
+}
+
+func resultIDs(page *historydomain.SpinLogCursorResult) []string {
+	ids := make([]string, len(page.Data))
+	for i, item := range page.Data {
+		ids[i] = item.SpinLog.ID().String()
+	}
+	return ids
+}
@@ -0,0 +1,120 @@
+package cursor
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// Version is the current cursor format; older versions are rejected
+const Version = 1
+
+const tokenPrefix = "v1."
+
+// Direction is the paging direction a cursor continues in
+type Direction string
+
+const (
+	Next Direction = "next"
+	Prev Direction = "prev"
+)
+
+var (
+	// ErrInvalid is returned for malformed, forged or outdated cursors
+	ErrInvalid = errors.New("invalid cursor")
+	// ErrMismatch is returned when a cursor is reused for another listing, filter or sort
+	ErrMismatch = errors.New("cursor does not match the requested filters")
+)
+
+// Cursor is the signed position in a keyset-paginated listing
+type Cursor struct {
+	Version   int       `json:"v"`
+	Scope     string    `json:"s"`           // listing, e.g. "history.global"
+	Filter    string    `json:"f,omitempty"` // canonical filters the listing was started with
+	Sort      string    `json:"o"`           // e.g. "created_at:desc,id:desc"
+	Direction Direction `json:"d"`
+	Key       []string  `json:"k"` // sort key values of the boundary row
+}
+
+// Codec signs and verifies cursors with HMAC-SHA256
+type Codec struct {
+	secret []byte
+}
+
+// NewCodec creates a codec; every instance serving the API must share secret
+func NewCodec(secret string) *Codec {
+	return &Codec{secret: []byte(secret)}
+}
+
+// Encode returns an opaque, URL-safe token for c
+func (k *Codec) Encode(c Cursor) string {
+	c.Version = Version
+	payload, _ := json.Marshal(c)
+
+	body := base64.RawURLEncoding.EncodeToString(payload)
+	return tokenPrefix + body + "." + base64.RawURLEncoding.EncodeToString(k.sign(body))
+}
+
+// Decode verifies the signature and version of token
+func (k *Codec) Decode(token string) (*Cursor, error) {
+	c, body, sig, err := parse(token)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal(sig, k.sign(body)) {
+		return nil, ErrInvalid
+	}
+	return c, nil
+}
+
+// Peek reads a cursor without verifying it; only use it for values that are
+// verified again by Decode before the cursor is trusted
+func Peek(token string) (*Cursor, error) {
+	c, _, _, err := parse(token)
+	return c, err
+}
+
+// Check returns ErrMismatch unless the cursor belongs to the given listing
+func (c *Cursor) Check(scope, filter, sort string) error {
+	if c.Scope != scope || c.Filter != filter || c.Sort != sort {
+		return ErrMismatch
+	}
+	return nil
+}
+
+func (k *Codec) sign(body string) []byte {
+	mac := hmac.New(sha256.New, k.secret)
+	mac.Write([]byte(body))
+	return mac.Sum(nil)
+}
+
+func parse(token string) (*Cursor, string, []byte, error) {
+	if !strings.HasPrefix(token, tokenPrefix) {
+		return nil, "", nil, ErrInvalid
+	}
+	body, sigPart, ok := strings.Cut(strings.TrimPrefix(token, tokenPrefix), ".")
+	if !ok {
+		return nil, "", nil, ErrInvalid
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(sigPart)
+	if err != nil {
+		return nil, "", nil, ErrInvalid
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(body)
+	if err != nil {
+		return nil, "", nil, ErrInvalid
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(payload, &c); err != nil || c.Version != Version {
+		return nil, "", nil, ErrInvalid
+	}
+	if c.Direction != Next && c.Direction != Prev {
+		return nil, "", nil, ErrInvalid
+	}
+	return &c, body, sig, nil
+}
@@ -0,0 +1,87 @@
+package cursor
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCodecRoundTrip(t *testing.T) {
+	codec := NewCodec("secret")
+	want := Cursor{Scope: "history.global", Filter: "source=spin", Sort: "created_at:desc,id:desc", Direction: Prev, Key: []string{"2026-10-19T00:00:00Z", "abc"}}
+
+	got, err := codec.Decode(codec.Encode(want))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want.Version = Version
+	if got.Version != want.Version || got.Scope != want.Scope || got.Filter != want.Filter || got.Sort != want.Sort ||
+		got.Direction != want.Direction || strings.Join(got.Key, "|") != strings.Join(want.Key, "|") {
+		t.Fatalf("decoded %+v, want %+v", got, want)
+	}
+}
+
+// forge signs an arbitrary payload the way Encode does, without its checks
+func forge(codec *Codec, payload string) string {
+	body := base64.RawURLEncoding.EncodeToString([]byte(payload))
+	return tokenPrefix + body + "." + base64.RawURLEncoding.EncodeToString(codec.sign(body))
+}
+
+func TestCodecRejectsInvalidCursors(t *testing.T) {
+	codec := NewCodec("secret")
+	valid := codec.Encode(Cursor{Scope: "history.global", Sort: "id:desc", Direction: Next, Key: []string{"abc"}})
+	body, sig, _ := strings.Cut(strings.TrimPrefix(valid, tokenPrefix), ".")
+
+	// valid's signature over a payload with another key
+	tampered, _ := json.Marshal(Cursor{Version: Version, Scope: "history.global", Sort: "id:desc", Direction: Next, Key: []string{"abd"}})
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"empty", ""},
+		{"garbage", "not-a-cursor"},
+		{"missing prefix", strings.TrimPrefix(valid, tokenPrefix)},
+		{"other version prefix", "v2." + body + "." + sig},
+		{"missing signature", tokenPrefix + body},
+		{"signature not base64", tokenPrefix + body + ".!!!"},
+		{"body not base64", tokenPrefix + "!!!." + sig},
+		{"tampered body", tokenPrefix + base64.RawURLEncoding.EncodeToString(tampered) + "." + sig},
+		{"truncated signature", valid[:len(valid)-4]},
+		{"signed with another secret", NewCodec("other-secret").Encode(Cursor{Scope: "history.global", Sort: "id:desc", Direction: Next, Key: []string{"abc"}})},
+		{"old version", forge(codec, `{"v":0,"s":"history.global","o":"id:desc","d":"next","k":["abc"]}`)},
+		{"unknown direction", forge(codec, `{"v":1,"s":"history.global","o":"id:desc","d":"sideways","k":["abc"]}`)},
+		{"payload not json", forge(codec, `not json`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if c, err := codec.Decode(tt.token); !errors.Is(err, ErrInvalid) {
+				t.Fatalf("Decode = %+v, %v; want %v", c, err, ErrInvalid)
+			}
+		})
+	}
+}
+
+func TestCursorCheck(t *testing.T) {
+	c := Cursor{Scope: "history.global", Filter: "source=spin", Sort: "id:desc"}
+	tests := []struct {
+		name                string
+		scope, filter, sort string
+		want                error
+	}{
+		{"same listing", "history.global", "source=spin", "id:desc", nil},
+		{"other listing", "history.player", "source=spin", "id:desc", ErrMismatch},
+		{"other filter", "history.global", "source=referral", "id:desc", ErrMismatch},
+		{"filter dropped", "history.global", "", "id:desc", ErrMismatch},
+		{"other sort", "history.global", "source=spin", "id:asc", ErrMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := c.Check(tt.scope, tt.filter, tt.sort); !errors.Is(err, tt.want) {
+				t.Fatalf("Check = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
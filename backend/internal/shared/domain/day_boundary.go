@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// DayBoundary decides which day a moment belongs to for login streaks and
+// spin stats. A day starts at Offset after midnight in Location (e.g. 04:00 Asia/Bangkok).
+type DayBoundary struct {
+	Location *time.Location
+	Offset   time.Duration
+}
+
+// DayOf returns the day t falls on, as midnight UTC of that calendar date
+func (b DayBoundary) DayOf(t time.Time) time.Time {
+	local := t.In(b.location()).Add(-b.Offset)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// StartOf returns the moment day (from DayOf) begins
+func (b DayBoundary) StartOf(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, b.location()).Add(b.Offset)
+}
+
+// Zone returns the IANA name of Location
+func (b DayBoundary) Zone() string {
+	return b.location().String()
+}
+
+func (b DayBoundary) location() *time.Location {
+	if b.Location == nil {
+		return time.UTC
+	}
+	return b.Location
+}
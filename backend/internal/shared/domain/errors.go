@@ -1,6 +1,10 @@
 package domain
 
-import "errors"
+import (
+    "errors"
+
+    "backend/internal/shared/constants"
+)
 
 // Domain-level errors
 var (
@@ -11,9 +15,18 @@ var (
     ErrInvalidCheckpoint  = errors.New("invalid checkpoint value")
     ErrInvalidNickname    = errors.New("invalid nickname")
     ErrNegativePoints     = errors.New("points cannot be negative")
+
+    // Rejected actions by non-active players
+    ErrPlayerSuspended = errors.New("player is suspended")
+    ErrPlayerBanned    = errors.New("player is banned")
+    ErrPlayerDeleted   = errors.New("player is deleted")
+
+    // An update lost against a concurrent write; reload and try again
+    ErrConcurrentModification = errors.New("concurrent modification")
 )
 
-// DomainError wraps errors with code
+// DomainError is an error with a code and a message that is safe to show
+// to clients; the HTTP layer maps the code to a status
 type DomainError struct {
     Code    string
     Message string
@@ -21,7 +34,7 @@ type DomainError struct {
 }
 
 func (e *DomainError) Error() string {
-    if e.Err != nil {
+    if e.Err != nil && e.Err.Error() != e.Message {
         return e.Message + ": " + e.Err.Error()
     }
     return e.Message
@@ -37,4 +50,19 @@ func NewDomainError(code string, message string, err error) *DomainError {
         Message: message,
         Err:     err,
     }
-}
\ No newline at end of file
+}
+
+// NewValidationError reports invalid client input; message is shown as is
+func NewValidationError(message string) *DomainError {
+    return NewDomainError(constants.ErrCodeValidationFailed, message, nil)
+}
+
+// Invalid marks err, found while checking client input, as a validation
+// error. Errors that already carry a code are returned unchanged.
+func Invalid(err error) error {
+    var domainErr *DomainError
+    if err == nil || errors.As(err, &domainErr) {
+        return err
+    }
+    return NewDomainError(constants.ErrCodeValidationFailed, err.Error(), err)
+}
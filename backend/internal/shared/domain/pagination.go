@@ -1,12 +1,5 @@
 package domain
 
-import (
-	"encoding/base64"
-	"fmt"
-	"strings"
-	"time"
-)
-
 // PaginationConfig holds pagination configuration
 type PaginationConfig struct {
 	DefaultLimit  int
@@ -62,13 +55,7 @@ func NewPaginatedResult[T any](data []T, total int64, params PaginationParams) P
 // CursorParams holds cursor pagination input
 type CursorParams struct {
 	Limit  int
-	Cursor string // base64 encoded: "created_at|id"
-}
-
-// CursorData holds decoded cursor values
-type CursorData struct {
-	CreatedAt time.Time
-	ID        string
+	Cursor string // opaque signed cursor (see shared/cursor)
 }
 
 // NewCursorParams creates cursor params with defaults
@@ -86,50 +73,21 @@ func NewCursorParams(limit int, cursor string, cfg PaginationConfig) CursorParam
 	}
 }
 
-// DecodeCursor decodes a cursor string into CursorData
-func DecodeCursor(cursor string) (*CursorData, error) {
-	if cursor == "" {
-		return nil, nil
-	}
-
-	decoded, err := base64.StdEncoding.DecodeString(cursor)
-	if err != nil {
-		return nil, fmt.Errorf("invalid cursor format")
-	}
-
-	parts := strings.Split(string(decoded), "|")
-	if len(parts) != 2 {
-		return nil, fmt.Errorf("invalid cursor format")
-	}
-
-	createdAt, err := time.Parse(time.RFC3339Nano, parts[0])
-	if err != nil {
-		return nil, fmt.Errorf("invalid cursor timestamp")
-	}
-
-	return &CursorData{
-		CreatedAt: createdAt,
-		ID:        parts[1],
-	}, nil
-}
-
-// EncodeCursor encodes CursorData into a cursor string
-func EncodeCursor(createdAt time.Time, id string) string {
-	raw := fmt.Sprintf("%s|%s", createdAt.Format(time.RFC3339Nano), id)
-	return base64.StdEncoding.EncodeToString([]byte(raw))
-}
-
 // CursorResult holds cursor paginated response
 type CursorResult[T any] struct {
 	Data       []T    `json:"data"`
 	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 	HasMore    bool   `json:"has_more"`
+	HasPrev    bool   `json:"has_prev"`
 }
 
-func NewCursorResult[T any](data []T, nextCursor string, hasMore bool) CursorResult[T] {
+func NewCursorResult[T any](data []T, nextCursor, prevCursor string, hasMore, hasPrev bool) CursorResult[T] {
 	return CursorResult[T]{
 		Data:       data,
 		NextCursor: nextCursor,
+		PrevCursor: prevCursor,
 		HasMore:    hasMore,
+		HasPrev:    hasPrev,
 	}
 }
@@ -0,0 +1,9 @@
+package domain
+
+import "context"
+
+// Transactor runs fn atomically: repository calls made with the ctx passed to
+// fn commit or roll back together
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,65 @@
+package events
+
+import (
+	"context"
+	"sync"
+
+	"backend/internal/shared/domain"
+)
+
+// Handler reacts to a published domain event
+type Handler func(ctx context.Context, event domain.DomainEvent)
+
+// Publisher publishes domain events collected by aggregates
+type Publisher interface {
+	Publish(ctx context.Context, events ...domain.DomainEvent)
+}
+
+// Bus is a synchronous in-process event bus
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+	all      []Handler
+}
+
+// NewBus creates an empty bus
+func NewBus() *Bus {
+	return &Bus{
+		handlers: make(map[string][]Handler),
+	}
+}
+
+// Subscribe registers a handler for one event type
+func (b *Bus) Subscribe(eventType string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+// SubscribeAll registers a handler for every event
+func (b *Bus) SubscribeAll(handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.all = append(b.all, handler)
+}
+
+// Publish delivers events to subscribers in registration order
+func (b *Bus) Publish(ctx context.Context, events ...domain.DomainEvent) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, event := range events {
+		for _, handler := range b.all {
+			handler(ctx, event)
+		}
+		for _, handler := range b.handlers[event.EventType()] {
+			handler(ctx, event)
+		}
+	}
+}
+
+// NopPublisher discards all events
+type NopPublisher struct{}
+
+// Publish implements Publisher
+func (NopPublisher) Publish(ctx context.Context, events ...domain.DomainEvent) {}
@@ -0,0 +1,284 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeLayout is used for timestamps in every format
+const timeLayout = time.RFC3339
+
+// Format is the output encoding of an export
+type Format string
+
+const (
+	CSV    Format = "csv"
+	NDJSON Format = "ndjson"
+)
+
+// ParseFormat parses a format name; empty means CSV
+func ParseFormat(s string) (Format, error) {
+	switch Format(strings.ToLower(strings.TrimSpace(s))) {
+	case "", CSV:
+		return CSV, nil
+	case NDJSON:
+		return NDJSON, nil
+	default:
+		return "", fmt.Errorf("format must be csv or ndjson")
+	}
+}
+
+// ContentType returns the HTTP content type for the format
+func (f Format) ContentType() string {
+	if f == NDJSON {
+		return "application/x-ndjson"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Extension returns the file extension for the format
+func (f Format) Extension() string {
+	return string(f)
+}
+
+// Options controls what an export writes
+type Options struct {
+	Format    Format
+	Columns   []string       // empty means the configured defaults
+	Location  *time.Location // timestamps are converted to this zone
+	BatchSize int            // rows fetched per round trip
+}
+
+// DefaultOptions builds the configured defaults; an unknown timezone falls back to UTC
+func DefaultOptions(timezone string, batchSize int, columns []string) Options {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	return Options{
+		Format:    CSV,
+		Columns:   columns,
+		Location:  loc,
+		BatchSize: batchSize,
+	}
+}
+
+// Column extracts one output field from a row
+type Column[T any] struct {
+	Name  string
+	Value func(row T) any
+}
+
+// SelectColumns returns the named columns in the requested order
+func SelectColumns[T any](available []Column[T], names []string) ([]Column[T], error) {
+	if len(names) == 0 {
+		return nil, fmt.Errorf("at least one column is required")
+	}
+
+	byName := make(map[string]Column[T], len(available))
+	for _, col := range available {
+		byName[col.Name] = col
+	}
+
+	selected := make([]Column[T], 0, len(names))
+	for _, name := range names {
+		col, ok := byName[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+		selected = append(selected, col)
+	}
+	return selected, nil
+}
+
+// ColumnNames lists the names of columns
+func ColumnNames[T any](columns []Column[T]) []string {
+	names := make([]string, len(columns))
+	for i, col := range columns {
+		names[i] = col.Name
+	}
+	return names
+}
+
+// Writer encodes rows into w one at a time; call Flush after the last row
+type Writer[T any] struct {
+	format  Format
+	columns []Column[T]
+	loc     *time.Location
+	out     io.Writer
+	buf     *bufio.Writer
+	csv     *csv.Writer
+	record  []string
+}
+
+// NewWriter creates a writer; for CSV the header row is written immediately
+func NewWriter[T any](w io.Writer, format Format, columns []Column[T], loc *time.Location) (*Writer[T], error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	ew := &Writer[T]{
+		format:  format,
+		columns: columns,
+		loc:     loc,
+		out:     w,
+		buf:     bufio.NewWriter(w),
+		record:  make([]string, len(columns)),
+	}
+
+	if format == CSV {
+		ew.csv = csv.NewWriter(ew.buf)
+		if err := ew.csv.Write(ColumnNames(columns)); err != nil {
+			return nil, err
+		}
+	}
+	return ew, nil
+}
+
+// Write encodes a single row
+func (w *Writer[T]) Write(row T) error {
+	if w.format == CSV {
+		for i, col := range w.columns {
+			w.record[i] = w.formatCSV(col.Value(row))
+		}
+		return w.csv.Write(w.record)
+	}
+	return w.writeJSON(row)
+}
+
+// Flush writes buffered rows to the underlying writer, flushing it too when
+// it buffers (e.g. a streamed HTTP response)
+func (w *Writer[T]) Flush() error {
+	if w.csv != nil {
+		w.csv.Flush()
+		if err := w.csv.Error(); err != nil {
+			return err
+		}
+	}
+	if err := w.buf.Flush(); err != nil {
+		return err
+	}
+	if f, ok := w.out.(interface{ Flush() error }); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+// writeJSON writes one object per line, keeping the column order
+func (w *Writer[T]) writeJSON(row T) error {
+	var line bytes.Buffer
+	line.WriteByte('{')
+	for i, col := range w.columns {
+		if i > 0 {
+			line.WriteByte(',')
+		}
+		key, _ := json.Marshal(col.Name)
+		line.Write(key)
+		line.WriteByte(':')
+
+		value := col.Value(row)
+		if t, ok := value.(time.Time); ok {
+			value = t.In(w.loc).Format(timeLayout)
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("column %s: %w", col.Name, err)
+		}
+		line.Write(encoded)
+	}
+	line.WriteString("}\n")
+
+	_, err := w.buf.Write(line.Bytes())
+	return err
+}
+
+func (w *Writer[T]) formatCSV(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case time.Time:
+		return v.In(w.loc).Format(timeLayout)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// ResolveOptions applies request overrides (format name, comma-separated
+// columns, IANA timezone) on top of the configured defaults
+func ResolveOptions(defaults Options, format, columns, timezone string) (Options, error) {
+	opts := defaults
+
+	f, err := ParseFormat(format)
+	if err != nil {
+		return opts, err
+	}
+	opts.Format = f
+
+	if strings.TrimSpace(columns) != "" {
+		opts.Columns = strings.Split(columns, ",")
+	}
+
+	if timezone != "" {
+		loc, err := time.LoadLocation(timezone)
+		if err != nil {
+			return opts, fmt.Errorf("unknown timezone %q", timezone)
+		}
+		opts.Location = loc
+	}
+
+	return opts, nil
+}
+
+// Job is a validated export ready to be written
+type Job struct {
+	Format   Format
+	Filename string
+	run      func(ctx context.Context, w io.Writer) (int, error)
+}
+
+// NewJob builds a job that writes the rows produced by stream with columns.
+// stream must call fn with consecutive batches of at most batchSize rows.
+func NewJob[T any](name string, opts Options, columns []Column[T], stream func(ctx context.Context, batchSize int, fn func([]T) error) error) *Job {
+	return &Job{
+		Format:   opts.Format,
+		Filename: name + "." + opts.Format.Extension(),
+		run: func(ctx context.Context, w io.Writer) (int, error) {
+			ew, err := NewWriter(w, opts.Format, columns, opts.Location)
+			if err != nil {
+				return 0, err
+			}
+
+			rows := 0
+			err = stream(ctx, opts.BatchSize, func(batch []T) error {
+				for _, row := range batch {
+					if err := ew.Write(row); err != nil {
+						return err
+					}
+				}
+				rows += len(batch)
+				// Flush per batch so output is sent as it is produced
+				return ew.Flush()
+			})
+			if err != nil {
+				return rows, err
+			}
+			return rows, ew.Flush()
+		},
+	}
+}
+
+// Run writes the export to w and returns the number of rows written
+func (j *Job) Run(ctx context.Context, w io.Writer) (int, error) {
+	return j.run(ctx, w)
+}
@@ -0,0 +1,147 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"backend/internal/shared/constants"
+	"backend/internal/shared/cursor"
+	shared "backend/internal/shared/domain"
+	"backend/internal/shared/i18n"
+	"backend/internal/shared/logging"
+)
+
+// ErrorSpec is how an error is shown to clients
+type ErrorSpec struct {
+	Status  int
+	Code    string
+	Message string
+	// Specific is set when Message is the error's own text rather than a
+	// registered message
+	Specific bool
+}
+
+type registeredError struct {
+	target error
+	spec   ErrorSpec
+}
+
+// ErrorRegistry maps domain errors to a status, code and safe message.
+// Modules register their errors at startup; ErrorHandler renders them.
+type ErrorRegistry struct {
+	errors []registeredError
+	codes  map[string]int
+}
+
+// NewErrorRegistry returns a registry that knows the shared domain errors
+func NewErrorRegistry() *ErrorRegistry {
+	r := &ErrorRegistry{codes: make(map[string]int)}
+
+	r.RegisterCode(constants.ErrCodeValidationFailed, constants.StatusBadRequest)
+
+	r.Register(shared.ErrPlayerNotFound, constants.StatusNotFound, constants.ErrCodePlayerNotFound, "Player not found")
+	r.Register(shared.ErrPlayerSuspended, constants.StatusForbidden, constants.ErrCodePlayerSuspended, "Player is suspended")
+	r.Register(shared.ErrPlayerBanned, constants.StatusForbidden, constants.ErrCodePlayerBanned, "Player is banned")
+	r.Register(shared.ErrPlayerDeleted, constants.StatusForbidden, constants.ErrCodePlayerDeleted, "Player is deleted")
+	r.Register(shared.ErrInvalidNickname, constants.StatusBadRequest, constants.ErrCodeValidationFailed, "")
+	r.Register(shared.ErrConcurrentModification, constants.StatusConflict, constants.ErrCodeConcurrentModification, "Player was updated concurrently, please retry")
+	r.Register(cursor.ErrInvalid, constants.StatusBadRequest, constants.ErrCodeValidationFailed, "")
+	r.Register(cursor.ErrMismatch, constants.StatusBadRequest, constants.ErrCodeValidationFailed, "")
+	return r
+}
+
+// Register maps target, and errors wrapping it, to a response. An empty
+// message shows the error's own text, for errors written for clients.
+func (r *ErrorRegistry) Register(target error, status int, code, message string) {
+	r.errors = append(r.errors, registeredError{
+		target: target,
+		spec:   ErrorSpec{Status: status, Code: code, Message: message},
+	})
+}
+
+// RegisterCode sets the status of shared.DomainError values with code
+func (r *ErrorRegistry) RegisterCode(code string, status int) {
+	r.codes[code] = status
+}
+
+// Resolve finds the response for err; ok is false for unknown errors
+func (r *ErrorRegistry) Resolve(err error) (spec ErrorSpec, ok bool) {
+	for _, e := range r.errors {
+		if errors.Is(err, e.target) {
+			spec = e.spec
+			if spec.Message == "" {
+				spec.Message = err.Error()
+				spec.Specific = true
+			}
+			return spec, true
+		}
+	}
+
+	var domainErr *shared.DomainError
+	if errors.As(err, &domainErr) {
+		if status, known := r.codes[domainErr.Code]; known {
+			return ErrorSpec{Status: status, Code: domainErr.Code, Message: domainErr.Message, Specific: true}, true
+		}
+	}
+
+	// Errors raised by Fiber itself, e.g. unknown routes
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return ErrorSpec{Status: fiberErr.Code, Code: statusCode(fiberErr.Code), Message: fiberErr.Message, Specific: true}, true
+	}
+	return ErrorSpec{}, false
+}
+
+// ErrorHandler is the Fiber error handler: handlers return errors and this
+// writes them in the Response envelope (or the route's legacy shape), in the
+// request's locale. Unknown errors are logged and shown as a generic 500 so
+// internal details never reach clients.
+func ErrorHandler(registry *ErrorRegistry) fiber.ErrorHandler {
+	return func(c *fiber.Ctx, err error) error {
+		spec, ok := registry.Resolve(err)
+		if !ok {
+			logging.FromContext(c.UserContext()).Error("request failed", "method", c.Method(), "path", c.Path(), "error", err)
+			spec = ErrorSpec{
+				Status:  constants.StatusInternalServerError,
+				Code:    constants.ErrCodeInternal,
+				Message: "Internal server error",
+			}
+		}
+		if !spec.Specific {
+			return Error(c, spec.Status, spec.Code, spec.Message)
+		}
+
+		// A translated code replaces the error's own text, which is kept
+		// as details since it may say exactly what was wrong
+		body := ErrorBody{Code: spec.Code, Message: spec.Message}
+		if message, ok := i18n.Message(c.UserContext(), spec.Code); ok {
+			body.Message, body.Details = message, spec.Message
+		}
+		return writeError(c, spec.Status, body)
+	}
+}
+
+// RenderError writes a handler chain's error with the app's error handler, so
+// middleware that reads the response afterwards sees what the client gets.
+// A failing error handler leaves a bare 500.
+func RenderError(c *fiber.Ctx, err error) {
+	if err == nil {
+		return
+	}
+	if handlerErr := c.App().ErrorHandler(c, err); handlerErr != nil {
+		logging.FromContext(c.UserContext()).Error("error handler failed", "path", c.Path(), "error", handlerErr)
+		_ = c.SendStatus(fiber.StatusInternalServerError)
+	}
+}
+
+// statusCode turns an HTTP status into an error code, e.g. 404 -> NOT_FOUND
+func statusCode(status int) string {
+	text := http.StatusText(status)
+	if text == "" {
+		return constants.ErrCodeInternal
+	}
+	return strings.ToUpper(strings.ReplaceAll(strings.ReplaceAll(text, " ", "_"), "-", "_"))
+}
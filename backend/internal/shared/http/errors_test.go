@@ -0,0 +1,145 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"backend/internal/shared/constants"
+	shared "backend/internal/shared/domain"
+	"backend/internal/shared/i18n"
+)
+
+func TestErrorHandlerRendersEnvelope(t *testing.T) {
+	errNicknameTaken := errors.New("nickname already taken")
+	errInvalidStatus := errors.New("invalid status change")
+
+	registry := NewErrorRegistry()
+	registry.Register(errNicknameTaken, constants.StatusConflict, constants.ErrCodeNicknameTaken, "Nickname already taken")
+	registry.Register(errInvalidStatus, constants.StatusBadRequest, constants.ErrCodeValidationFailed, "")
+
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler(registry)})
+	fail := func(path string, err error) {
+		app.Get(path, func(c *fiber.Ctx) error { return err })
+	}
+	fail("/registered", fmt.Errorf("rename: %w", errNicknameTaken))
+	fail("/own-text", fmt.Errorf("%w: a reason is required", errInvalidStatus))
+	fail("/shared", shared.ErrPlayerNotFound)
+	fail("/validation", shared.NewValidationError("limit must be positive"))
+	fail("/invalid", shared.Invalid(errors.New("unknown column \"x\"")))
+	fail("/internal", errors.New("pq: connection refused"))
+
+	tests := []struct {
+		path    string
+		status  int
+		code    string
+		message string
+	}{
+		{"/registered", constants.StatusConflict, constants.ErrCodeNicknameTaken, "Nickname already taken"},
+		{"/own-text", constants.StatusBadRequest, constants.ErrCodeValidationFailed, "invalid status change: a reason is required"},
+		{"/shared", constants.StatusNotFound, constants.ErrCodePlayerNotFound, "Player not found"},
+		{"/validation", constants.StatusBadRequest, constants.ErrCodeValidationFailed, "limit must be positive"},
+		{"/invalid", constants.StatusBadRequest, constants.ErrCodeValidationFailed, "unknown column \"x\""},
+		{"/internal", constants.StatusInternalServerError, constants.ErrCodeInternal, "Internal server error"},
+		{"/missing", constants.StatusNotFound, "NOT_FOUND", "Cannot GET /missing"},
+	}
+	for _, tt := range tests {
+		resp, err := app.Test(httptest.NewRequest("GET", tt.path, nil))
+		if err != nil {
+			t.Fatalf("%s: %v", tt.path, err)
+		}
+
+		var body Response
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Fatalf("%s: decode: %v", tt.path, err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != tt.status || body.Success || body.Error == nil {
+			t.Fatalf("%s: status %d, body %+v; want %d with an error", tt.path, resp.StatusCode, body, tt.status)
+		}
+		if body.Error.Code != tt.code || body.Error.Message != tt.message {
+			t.Errorf("%s: error = %+v, want %s %q", tt.path, *body.Error, tt.code, tt.message)
+		}
+	}
+}
+
+func TestErrorHandlerTranslatesMessages(t *testing.T) {
+	bundle, err := i18n.Load("en")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler(NewErrorRegistry())})
+	app.Use(func(c *fiber.Ctx) error {
+		c.SetUserContext(i18n.WithLocale(c.UserContext(), bundle, bundle.Negotiate(c.Get(fiber.HeaderAcceptLanguage))))
+		return c.Next()
+	})
+	app.Get("/shared", func(c *fiber.Ctx) error { return shared.ErrPlayerNotFound })
+	app.Get("/validation", func(c *fiber.Ctx) error { return shared.NewValidationError("limit must be positive") })
+
+	tests := []struct {
+		path     string
+		language string
+		message  string
+		details  string
+	}{
+		{"/shared", "th-TH", "ไม่พบผู้เล่น", ""},
+		{"/shared", "fr", "Player not found", ""},
+		{"/validation", "th", "ข้อมูลไม่ถูกต้อง", "limit must be positive"},
+		{"/validation", "en", "limit must be positive", ""},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", tt.path, nil)
+		req.Header.Set(fiber.HeaderAcceptLanguage, tt.language)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.path, err)
+		}
+
+		var body Response
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Fatalf("%s: decode: %v", tt.path, err)
+		}
+		resp.Body.Close()
+
+		if body.Error == nil || body.Error.Message != tt.message || body.Error.Details != tt.details {
+			t.Errorf("%s in %s: error = %+v, want %q with details %q", tt.path, tt.language, body.Error, tt.message, tt.details)
+		}
+	}
+}
+
+func TestRenderErrorLetsMiddlewareSeeFinalStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler fiber.ErrorHandler
+		status  int
+	}{
+		{"registered error", ErrorHandler(NewErrorRegistry()), constants.StatusNotFound},
+		{"failing error handler", func(c *fiber.Ctx, err error) error { return err }, constants.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New(fiber.Config{ErrorHandler: tt.handler})
+			seen := 0
+			app.Use(func(c *fiber.Ctx) error {
+				RenderError(c, c.Next())
+				seen = c.Response().StatusCode()
+				return nil
+			})
+			app.Get("/", func(c *fiber.Ctx) error { return shared.ErrPlayerNotFound })
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if seen != tt.status || resp.StatusCode != tt.status {
+				t.Fatalf("middleware saw %d, client got %d; want %d", seen, resp.StatusCode, tt.status)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package http
+
+import (
+	"bufio"
+
+	"github.com/gofiber/fiber/v2"
+
+	"backend/internal/shared/export"
+	"backend/internal/shared/logging"
+)
+
+// Export streams job as a file download. Rows are sent as they are read, so
+// the status is already 200 when a failure happens mid-stream; such failures
+// are logged and the response is cut short.
+func Export(c *fiber.Ctx, job *export.Job) error {
+	ctx := c.UserContext()
+
+	c.Set(fiber.HeaderContentType, job.Format.ContentType())
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+job.Filename+`"`)
+	c.Set(fiber.HeaderCacheControl, "no-store")
+
+	// The writer runs after the handler returns, so only ctx and job are captured
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		rows, err := job.Run(ctx, w)
+		if err != nil {
+			logging.FromContext(ctx).Error("export failed", "file", job.Filename, "rows", rows, "error", err)
+			return
+		}
+		logging.FromContext(ctx).Info("export completed", "file", job.Filename, "rows", rows)
+	})
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 
 	"backend/internal/shared/constants"
+	"backend/internal/shared/i18n"
 )
 
 // Response is the standard API response format
@@ -16,10 +17,48 @@ type Response struct {
 type ErrorBody struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
+	// Details is the untranslated specific message when Message is a
+	// translated generic one
+	Details string `json:"details,omitempty"`
+}
+
+// Locals keys set by the Legacy and LegacyErrors middleware
+const (
+	legacyKey       = "httputil.legacy"
+	legacyErrorsKey = "httputil.legacy_errors"
+)
+
+// ErrorWriter writes an error body in a route's own shape
+type ErrorWriter func(c *fiber.Ctx, status int, body ErrorBody) error
+
+// Legacy serves the unversioned API's response shapes: successes are the
+// bare data, and errors use the envelope unless the route set LegacyErrors
+func Legacy() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Locals(legacyKey, true)
+		return c.Next()
+	}
+}
+
+// LegacyErrors writes the errors of legacy requests with write, for routes
+// whose unversioned errors were not in the envelope
+func LegacyErrors(write ErrorWriter) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Locals(legacyErrorsKey, write)
+		return c.Next()
+	}
+}
+
+func isLegacy(c *fiber.Ctx) bool {
+	legacy, _ := c.Locals(legacyKey).(bool)
+	return legacy
 }
 
 // Helper functions
 func Success(c *fiber.Ctx, status int, data interface{}) error {
+	if isLegacy(c) {
+		return c.Status(status).JSON(data)
+	}
 	return c.Status(status).JSON(Response{
 		Success: true,
 		Data:    data,
@@ -30,20 +69,35 @@ func Created(c *fiber.Ctx, data interface{}) error {
 	return Success(c, constants.StatusCreated, data)
 }
 
+// Error writes an error response; message is replaced by the code's
+// translation in the request's locale when the catalogs have one
 func Error(c *fiber.Ctx, status int, code string, message string) error {
-	return c.Status(status).JSON(Response{
-		Success: false,
-		Error: &ErrorBody{
-			Code:    code,
-			Message: message,
-		},
-	})
+	if translated, ok := i18n.Message(c.UserContext(), code); ok {
+		message = translated
+	}
+	return writeError(c, status, ErrorBody{Code: code, Message: message})
+}
+
+// writeError writes an error in the envelope, or in the route's legacy shape
+func writeError(c *fiber.Ctx, status int, body ErrorBody) error {
+	if write, ok := c.Locals(legacyErrorsKey).(ErrorWriter); ok && isLegacy(c) {
+		return write(c, status, body)
+	}
+	return c.Status(status).JSON(Response{Success: false, Error: &body})
 }
 
 func BadRequest(c *fiber.Ctx, code string, message string) error {
 	return Error(c, constants.StatusBadRequest, code, message)
 }
 
+func Unauthorized(c *fiber.Ctx, code string, message string) error {
+	return Error(c, constants.StatusUnauthorized, code, message)
+}
+
+func Forbidden(c *fiber.Ctx, code string, message string) error {
+	return Error(c, constants.StatusForbidden, code, message)
+}
+
 func NotFound(c *fiber.Ctx, code string, message string) error {
 	return Error(c, constants.StatusNotFound, code, message)
 }
@@ -57,5 +111,5 @@ func TooManyRequests(c *fiber.Ctx, code string, message string) error {
 }
 
 func InternalError(c *fiber.Ctx, message string) error {
-	return Error(c, constants.StatusInternalServerError, constants.ErrCodeValidationFailed, message)
+	return Error(c, constants.StatusInternalServerError, constants.ErrCodeInternal, message)
 }
@@ -0,0 +1,68 @@
+package http
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+
+	"backend/internal/shared/constants"
+	shared "backend/internal/shared/domain"
+)
+
+func TestLegacyKeepsUnversionedShapes(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler(NewErrorRegistry())})
+	flat := func(c *fiber.Ctx, status int, body ErrorBody) error {
+		return c.Status(status).JSON(fiber.Map{"code": body.Code})
+	}
+	ok := func(c *fiber.Ctx) error { return Success(c, constants.StatusOK, fiber.Map{"id": "p1"}) }
+	fail := func(c *fiber.Ctx) error { return shared.ErrPlayerNotFound }
+
+	app.Use("/legacy", Legacy())
+	app.Use("/legacy/flat", LegacyErrors(flat))
+	app.Use("/v1/flat", LegacyErrors(flat))
+	for _, prefix := range []string{"/legacy", "/v1"} {
+		app.Get(prefix+"/ok", ok)
+		app.Get(prefix+"/fail", fail)
+		app.Get(prefix+"/flat", fail)
+	}
+
+	tests := []struct {
+		path string
+		body string
+	}{
+		{"/v1/ok", `{"success":true,"data":{"id":"p1"}}`},
+		{"/v1/fail", `{"success":false,"error":{"code":"PLAYER_NOT_FOUND","message":"Player not found"}}`},
+		// The writer only applies to legacy requests
+		{"/v1/flat", `{"success":false,"error":{"code":"PLAYER_NOT_FOUND","message":"Player not found"}}`},
+		{"/legacy/ok", `{"id":"p1"}`},
+		{"/legacy/fail", `{"success":false,"error":{"code":"PLAYER_NOT_FOUND","message":"Player not found"}}`},
+		{"/legacy/flat", `{"code":"PLAYER_NOT_FOUND"}`},
+	}
+	for _, tt := range tests {
+		resp, err := app.Test(httptest.NewRequest("GET", tt.path, nil))
+		if err != nil {
+			t.Fatalf("%s: %v", tt.path, err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		if !jsonEqual(t, body, tt.body) {
+			t.Errorf("%s: body = %s, want %s", tt.path, body, tt.body)
+		}
+	}
+}
+
+func jsonEqual(t *testing.T, got []byte, want string) bool {
+	t.Helper()
+	var g, w any
+	if err := json.Unmarshal(got, &g); err != nil {
+		t.Fatalf("decode %s: %v", got, err)
+	}
+	if err := json.Unmarshal([]byte(want), &w); err != nil {
+		t.Fatal(err)
+	}
+	gb, _ := json.Marshal(g)
+	wb, _ := json.Marshal(w)
+	return string(gb) == string(wb)
+}
@@ -0,0 +1,155 @@
+// Package i18n holds the message catalogs and picks the locale of each request
+package i18n
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+	"golang.org/x/text/language"
+
+	"backend/locales"
+)
+
+// Catalog is one locale's translations
+type Catalog struct {
+	// Errors are client messages by API error code
+	Errors map[string]string `yaml:"errors"`
+	// Rewards are reward texts by reward ID (checkpoint value)
+	Rewards map[int]RewardText `yaml:"rewards"`
+}
+
+// RewardText is a reward's name and description in one locale
+type RewardText struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+}
+
+// Bundle holds the catalog of every supported locale
+type Bundle struct {
+	defaultLocale string
+	locales       []string // default first, then sorted
+	catalogs      map[string]*Catalog
+	matcher       language.Matcher
+}
+
+// Load reads the embedded catalogs
+func Load(defaultLocale string) (*Bundle, error) {
+	return LoadFS(locales.FS, defaultLocale)
+}
+
+// LoadFS reads every <locale>.yaml catalog in fsys; defaultLocale must be one
+// of them
+func LoadFS(fsys fs.FS, defaultLocale string) (*Bundle, error) {
+	files, err := fs.Glob(fsys, "*.yaml")
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Bundle{defaultLocale: defaultLocale, catalogs: make(map[string]*Catalog, len(files))}
+	for _, file := range files {
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		var catalog Catalog
+		if err := yaml.Unmarshal(data, &catalog); err != nil {
+			return nil, fmt.Errorf("catalog %s: %w", file, err)
+		}
+		locale := strings.TrimSuffix(path.Base(file), path.Ext(file))
+		if _, err := language.Parse(locale); err != nil {
+			return nil, fmt.Errorf("catalog %s: %w", file, err)
+		}
+		b.catalogs[locale] = &catalog
+		if locale != defaultLocale {
+			b.locales = append(b.locales, locale)
+		}
+	}
+	if _, ok := b.catalogs[defaultLocale]; !ok {
+		return nil, fmt.Errorf("no catalog for default locale %q", defaultLocale)
+	}
+	slices.Sort(b.locales)
+	b.locales = append([]string{defaultLocale}, b.locales...)
+
+	// The matcher prefers its first tag when nothing matches
+	tags := make([]language.Tag, len(b.locales))
+	for i, locale := range b.locales {
+		tags[i] = language.Make(locale)
+	}
+	b.matcher = language.NewMatcher(tags)
+	return b, nil
+}
+
+// Default is the locale used when the client asks for none we support
+func (b *Bundle) Default() string {
+	return b.defaultLocale
+}
+
+// Locales lists the supported locales, default first
+func (b *Bundle) Locales() []string {
+	return slices.Clone(b.locales)
+}
+
+// Negotiate picks the supported locale that best fits an Accept-Language
+// header, or the default locale
+func (b *Bundle) Negotiate(acceptLanguage string) string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return b.defaultLocale
+	}
+	_, index, confidence := b.matcher.Match(tags...)
+	if confidence == language.No {
+		return b.defaultLocale
+	}
+	return b.locales[index]
+}
+
+// Match returns the supported locale for a language tag such as "th-TH";
+// ok is false when the language is not supported
+func (b *Bundle) Match(tag string) (locale string, ok bool) {
+	parsed, err := language.Parse(tag)
+	if err != nil {
+		return "", false
+	}
+	_, index, confidence := b.matcher.Match(parsed)
+	if confidence < language.High {
+		return "", false
+	}
+	return b.locales[index], true
+}
+
+// Message returns the message for an error code in locale, falling back to
+// the default locale; ok is false when neither has it
+func (b *Bundle) Message(locale, code string) (string, bool) {
+	for _, l := range []string{locale, b.defaultLocale} {
+		if catalog, found := b.catalogs[l]; found {
+			if message, ok := catalog.Errors[code]; ok && message != "" {
+				return message, true
+			}
+		}
+	}
+	return "", false
+}
+
+// RewardTranslations returns the name and description of a reward by locale,
+// for every catalog that translates it
+func (b *Bundle) RewardTranslations(rewardID int) (names, descriptions map[string]string) {
+	names = make(map[string]string)
+	descriptions = make(map[string]string)
+	for locale, catalog := range b.catalogs {
+		text, ok := catalog.Rewards[rewardID]
+		if !ok {
+			continue
+		}
+		if text.Name != "" {
+			names[locale] = text.Name
+		}
+		if text.Description != "" {
+			descriptions[locale] = text.Description
+		}
+	}
+	return names, descriptions
+}
@@ -0,0 +1,88 @@
+package i18n
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+)
+
+func testBundle(t *testing.T) *Bundle {
+	t.Helper()
+	bundle, err := LoadFS(fstest.MapFS{
+		"en.yaml": {Data: []byte("errors:\n  PLAYER_NOT_FOUND: Player not found\n  RENAME_COOLDOWN: Renamed too recently\n")},
+		"th.yaml": {Data: []byte("errors:\n  PLAYER_NOT_FOUND: ไม่พบผู้เล่น\nrewards:\n  500:\n    name: รางวัล 1\n")},
+	}, "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bundle
+}
+
+func TestNegotiate(t *testing.T) {
+	bundle := testBundle(t)
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", "en"},
+		{"th", "th"},
+		{"th-TH,th;q=0.9", "th"},
+		{"fr-FR, th;q=0.5, en;q=0.8", "en"},
+		{"fr-FR, th;q=0.5", "th"},
+		{"de", "en"},
+		{"not a header;;", "en"},
+	}
+	for _, tt := range tests {
+		if got := bundle.Negotiate(tt.header); got != tt.want {
+			t.Errorf("Negotiate(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestMessagesFallBackToDefaultLocale(t *testing.T) {
+	ctx := WithLocale(context.Background(), testBundle(t), "th")
+
+	if got, _ := Message(ctx, "PLAYER_NOT_FOUND"); got != "ไม่พบผู้เล่น" {
+		t.Errorf("translated message = %q", got)
+	}
+	if got, _ := Message(ctx, "RENAME_COOLDOWN"); got != "Renamed too recently" {
+		t.Errorf("untranslated message = %q, want the default locale's", got)
+	}
+	if _, ok := Message(ctx, "UNKNOWN"); ok {
+		t.Error("unknown code has a message")
+	}
+
+	names, _ := testBundle(t).RewardTranslations(500)
+	if got := Translate(ctx, names, "Reward 1"); got != "รางวัล 1" {
+		t.Errorf("reward name = %q", got)
+	}
+	if got := Translate(ctx, nil, "Reward 1"); got != "Reward 1" {
+		t.Errorf("untranslated reward name = %q, want the base text", got)
+	}
+}
+
+func TestPreferOverridesNegotiatedLocale(t *testing.T) {
+	ctx := WithLocale(context.Background(), testBundle(t), "en")
+
+	Prefer(ctx, "fr")
+	if got := Locale(ctx); got != "en" {
+		t.Fatalf("unsupported preference changed locale to %q", got)
+	}
+	Prefer(ctx, "th-TH")
+	if got := Locale(ctx); got != "th" {
+		t.Fatalf("locale = %q, want th", got)
+	}
+}
+
+func TestEmbeddedCatalogsLoad(t *testing.T) {
+	bundle, err := Load("en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := bundle.Locales(); len(got) != 2 || got[0] != "en" || got[1] != "th" {
+		t.Fatalf("locales = %v, want [en th]", got)
+	}
+	if _, err := Load("xx"); err == nil {
+		t.Fatal("loaded without a catalog for the default locale")
+	}
+}
@@ -0,0 +1,69 @@
+package i18n
+
+import "context"
+
+type ctxKey struct{}
+
+// selection is the request's locale; use cases may replace it with the
+// player's preference once the player is loaded
+type selection struct {
+	bundle *Bundle
+	locale string
+}
+
+// WithLocale returns a context carrying the request's bundle and locale
+func WithLocale(ctx context.Context, bundle *Bundle, locale string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, &selection{bundle: bundle, locale: locale})
+}
+
+// Locale returns the request's locale (empty if none was negotiated)
+func Locale(ctx context.Context) string {
+	if s := fromContext(ctx); s != nil {
+		return s.locale
+	}
+	return ""
+}
+
+// Prefer switches the request to a player's preferred locale; an empty or
+// unsupported preference keeps the negotiated locale
+func Prefer(ctx context.Context, preference string) {
+	s := fromContext(ctx)
+	if s == nil || preference == "" {
+		return
+	}
+	if locale, ok := s.bundle.Match(preference); ok {
+		s.locale = locale
+	}
+}
+
+// Message returns the request's message for an error code
+func Message(ctx context.Context, code string) (string, bool) {
+	s := fromContext(ctx)
+	if s == nil {
+		return "", false
+	}
+	return s.bundle.Message(s.locale, code)
+}
+
+// Translate picks the request's locale from translations, falling back to
+// the default locale and then to base
+func Translate(ctx context.Context, translations map[string]string, base string) string {
+	s := fromContext(ctx)
+	if s == nil {
+		return base
+	}
+	for _, locale := range []string{s.locale, s.bundle.defaultLocale} {
+		if text := translations[locale]; text != "" {
+			return text
+		}
+	}
+	return base
+}
+
+func fromContext(ctx context.Context) *selection {
+	if ctx == nil {
+		return nil
+	}
+	s, _ := ctx.Value(ctxKey{}).(*selection)
+	return s
+}
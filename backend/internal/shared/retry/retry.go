@@ -0,0 +1,39 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	shared "backend/internal/shared/domain"
+)
+
+// Policy bounds how often a load-modify-save operation is re-run after it
+// lost against a concurrent update (shared.ErrConcurrentModification)
+type Policy struct {
+	MaxAttempts int           // total attempts, including the first
+	Backoff     time.Duration // wait before retry n is n * Backoff
+}
+
+// Do runs fn until it succeeds, fails with another error or the attempts run
+// out; fn must reload whatever it modifies. The last error is returned.
+func (p Policy) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	attempts := max(p.MaxAttempts, 1)
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = fn(ctx); !errors.Is(err, shared.ErrConcurrentModification) {
+			return err
+		}
+		if attempt == attempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(attempt) * p.Backoff):
+		}
+	}
+	return err
+}
@@ -0,0 +1,46 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	shared "backend/internal/shared/domain"
+)
+
+func TestDoRetriesOnlyConcurrentModification(t *testing.T) {
+	policy := Policy{MaxAttempts: 3}
+
+	calls := 0
+	err := policy.Do(context.Background(), func(context.Context) error {
+		calls++
+		if calls < 3 {
+			return shared.ErrConcurrentModification
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Fatalf("err = %v, calls = %d; want nil after 3 calls", err, calls)
+	}
+
+	other := errors.New("boom")
+	calls = 0
+	err = policy.Do(context.Background(), func(context.Context) error {
+		calls++
+		return other
+	})
+	if !errors.Is(err, other) || calls != 1 {
+		t.Fatalf("err = %v, calls = %d; want boom after 1 call", err, calls)
+	}
+}
+
+func TestDoStopsAfterMaxAttempts(t *testing.T) {
+	calls := 0
+	err := Policy{MaxAttempts: 2}.Do(context.Background(), func(context.Context) error {
+		calls++
+		return shared.ErrConcurrentModification
+	})
+	if !errors.Is(err, shared.ErrConcurrentModification) || calls != 2 {
+		t.Fatalf("err = %v, calls = %d; want ErrConcurrentModification after 2 calls", err, calls)
+	}
+}
@@ -0,0 +1,10 @@
+// Package locales embeds the message catalogs, one <locale>.yaml per
+// supported language
+package locales
+
+import "embed"
+
+// FS holds every catalog in this directory
+//
+//go:embed *.yaml
+var FS embed.FS
@@ -0,0 +1,14 @@
+-- Create rate_limit_buckets table
+-- Shared rate limiter state for multi-instance deployments
+CREATE TABLE rate_limit_buckets (
+    bucket_key VARCHAR(255) PRIMARY KEY,
+    tokens DOUBLE PRECISION NOT NULL DEFAULT 0,
+    count INTEGER NOT NULL DEFAULT 0,
+    prev_count INTEGER NOT NULL DEFAULT 0,
+    window_start TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+-- Index for purging expired buckets
+CREATE INDEX idx_rate_limit_buckets_expires_at ON rate_limit_buckets(expires_at);
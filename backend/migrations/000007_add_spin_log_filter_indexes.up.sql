@@ -0,0 +1,8 @@
+-- Indexes for filtered history listings (ordered by created_at DESC, id DESC)
+CREATE INDEX IF NOT EXISTS idx_spin_logs_created_at_id ON spin_logs(created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_spin_logs_player_created_at ON spin_logs(player_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_spin_logs_source_created_at ON spin_logs(source, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_spin_logs_points_gained ON spin_logs(points_gained);
+
+-- Nickname prefix search (LIKE 'abc%') on global history
+CREATE INDEX IF NOT EXISTS idx_players_nickname_pattern ON players(nickname text_pattern_ops);
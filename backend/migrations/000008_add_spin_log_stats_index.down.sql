@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_spin_logs_player_stats;
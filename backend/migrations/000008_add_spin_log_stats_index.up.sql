@@ -0,0 +1,2 @@
+-- Covering index for per-player aggregates (GET /players/:id/stats)
+CREATE INDEX IF NOT EXISTS idx_spin_logs_player_stats ON spin_logs(player_id, created_at) INCLUDE (points_gained);
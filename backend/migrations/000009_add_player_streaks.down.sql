@@ -0,0 +1,8 @@
+ALTER TABLE players
+    DROP COLUMN IF EXISTS multiplier_expires_at,
+    DROP COLUMN IF EXISTS points_multiplier,
+    DROP COLUMN IF EXISTS bonus_spins,
+    DROP COLUMN IF EXISTS streak_freezes,
+    DROP COLUMN IF EXISTS streak_last_day,
+    DROP COLUMN IF EXISTS longest_streak,
+    DROP COLUMN IF EXISTS current_streak;
@@ -0,0 +1,4 @@
+-- Drop referrals table and invite codes
+DROP TABLE IF EXISTS referrals;
+DROP INDEX IF EXISTS idx_players_invite_code;
+ALTER TABLE players DROP COLUMN IF EXISTS invite_code;
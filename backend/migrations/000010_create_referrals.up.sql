@@ -0,0 +1,26 @@
+-- Invite codes: existing players and rows inserted without one (e.g. seed.sh)
+-- get a random code
+ALTER TABLE players
+    ADD COLUMN invite_code VARCHAR(8) NOT NULL DEFAULT UPPER(SUBSTRING(MD5(gen_random_uuid()::text) FROM 1 FOR 8));
+
+CREATE UNIQUE INDEX idx_players_invite_code ON players(invite_code);
+
+-- Create referrals table (one row per referred player)
+CREATE TABLE referrals (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    referrer_id UUID NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    referee_id UUID NOT NULL UNIQUE REFERENCES players(id) ON DELETE CASCADE,
+    invite_code VARCHAR(8) NOT NULL,
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    status VARCHAR(10) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'rewarded')),
+    referee_spins INTEGER NOT NULL DEFAULT 0 CHECK (referee_spins >= 0),
+    referee_points INTEGER NOT NULL DEFAULT 0 CHECK (referee_points >= 0),
+    reward_points INTEGER NOT NULL DEFAULT 0 CHECK (reward_points >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    rewarded_at TIMESTAMPTZ,
+    CHECK (referrer_id <> referee_id)
+);
+
+-- Referral summaries and the per-referrer and per-IP daily caps
+CREATE INDEX idx_referrals_referrer_created ON referrals(referrer_id, created_at DESC);
+CREATE INDEX idx_referrals_ip_created ON referrals(ip_address, created_at);
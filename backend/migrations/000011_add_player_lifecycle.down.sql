@@ -0,0 +1,15 @@
+DROP INDEX IF EXISTS idx_players_deleted;
+DROP INDEX IF EXISTS idx_players_nickname_unreleased;
+
+-- Released nicknames may repeat; give them a unique placeholder
+UPDATE players SET nickname = 'released-' || id::text WHERE nickname_released_at IS NOT NULL;
+ALTER TABLE players ADD CONSTRAINT players_nickname_key UNIQUE (nickname);
+
+ALTER TABLE players
+    DROP CONSTRAINT IF EXISTS players_suspended_until_check,
+    DROP COLUMN IF EXISTS nickname_released_at,
+    DROP COLUMN IF EXISTS nickname_changed_at,
+    DROP COLUMN IF EXISTS status_changed_at,
+    DROP COLUMN IF EXISTS status_reason,
+    DROP COLUMN IF EXISTS suspended_until,
+    DROP COLUMN IF EXISTS status;
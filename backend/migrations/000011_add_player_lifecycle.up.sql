@@ -0,0 +1,17 @@
+-- Account lifecycle: active, suspended (until a time), banned or soft deleted
+ALTER TABLE players
+    ADD COLUMN status VARCHAR(10) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'suspended', 'banned', 'deleted')),
+    ADD COLUMN suspended_until TIMESTAMPTZ,
+    ADD COLUMN status_reason TEXT NOT NULL DEFAULT '',
+    ADD COLUMN status_changed_at TIMESTAMPTZ,
+    ADD COLUMN nickname_changed_at TIMESTAMPTZ,
+    ADD COLUMN nickname_released_at TIMESTAMPTZ,
+    ADD CONSTRAINT players_suspended_until_check CHECK (status <> 'suspended' OR suspended_until IS NOT NULL);
+
+-- Nicknames of deleted players are released after a grace period, so they
+-- only need to be unique among unreleased rows
+ALTER TABLE players DROP CONSTRAINT players_nickname_key;
+CREATE UNIQUE INDEX idx_players_nickname_unreleased ON players(nickname) WHERE nickname_released_at IS NULL;
+
+-- Finding deleted players whose nickname is due for release
+CREATE INDEX idx_players_deleted ON players(status_changed_at) WHERE status = 'deleted' AND nickname_released_at IS NULL;
@@ -0,0 +1,18 @@
+DROP TABLE IF EXISTS compliance_log;
+
+ALTER TABLE referrals DROP CONSTRAINT referrals_referee_id_fkey;
+ALTER TABLE referrals ADD CONSTRAINT referrals_referee_id_fkey
+    FOREIGN KEY (referee_id) REFERENCES players(id) ON DELETE CASCADE;
+ALTER TABLE referrals DROP CONSTRAINT referrals_referrer_id_fkey;
+ALTER TABLE referrals ADD CONSTRAINT referrals_referrer_id_fkey
+    FOREIGN KEY (referrer_id) REFERENCES players(id) ON DELETE CASCADE;
+
+ALTER TABLE reward_transactions DROP CONSTRAINT reward_transactions_player_id_fkey;
+ALTER TABLE reward_transactions ADD CONSTRAINT reward_transactions_player_id_fkey
+    FOREIGN KEY (player_id) REFERENCES players(id) ON DELETE CASCADE;
+
+ALTER TABLE spin_logs DROP CONSTRAINT spin_logs_player_id_fkey;
+ALTER TABLE spin_logs ADD CONSTRAINT spin_logs_player_id_fkey
+    FOREIGN KEY (player_id) REFERENCES players(id) ON DELETE CASCADE;
+
+ALTER TABLE players DROP COLUMN IF EXISTS erased_at;
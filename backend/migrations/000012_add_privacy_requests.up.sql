@@ -0,0 +1,32 @@
+-- Erased players keep their row (pseudonymised) so totals and claims stay intact
+ALTER TABLE players ADD COLUMN erased_at TIMESTAMPTZ;
+
+-- Accounting history must survive player deletion: refuse to delete a player
+-- that still has spins, claims or referrals instead of cascading
+ALTER TABLE spin_logs DROP CONSTRAINT spin_logs_player_id_fkey;
+ALTER TABLE spin_logs ADD CONSTRAINT spin_logs_player_id_fkey
+    FOREIGN KEY (player_id) REFERENCES players(id) ON DELETE RESTRICT;
+
+ALTER TABLE reward_transactions DROP CONSTRAINT reward_transactions_player_id_fkey;
+ALTER TABLE reward_transactions ADD CONSTRAINT reward_transactions_player_id_fkey
+    FOREIGN KEY (player_id) REFERENCES players(id) ON DELETE RESTRICT;
+
+ALTER TABLE referrals DROP CONSTRAINT referrals_referrer_id_fkey;
+ALTER TABLE referrals ADD CONSTRAINT referrals_referrer_id_fkey
+    FOREIGN KEY (referrer_id) REFERENCES players(id) ON DELETE RESTRICT;
+ALTER TABLE referrals DROP CONSTRAINT referrals_referee_id_fkey;
+ALTER TABLE referrals ADD CONSTRAINT referrals_referee_id_fkey
+    FOREIGN KEY (referee_id) REFERENCES players(id) ON DELETE RESTRICT;
+
+-- Create compliance_log table (one row per data export or erasure request).
+-- No foreign key: entries must outlive anything that happens to the player.
+CREATE TABLE compliance_log (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    player_id UUID NOT NULL,
+    request_type VARCHAR(20) NOT NULL CHECK (request_type IN ('export', 'erasure')),
+    requested_by VARCHAR(20) NOT NULL CHECK (requested_by IN ('player', 'admin')),
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_compliance_log_player_created ON compliance_log(player_id, created_at);
@@ -0,0 +1,5 @@
+ALTER TABLE players DROP COLUMN IF EXISTS locale;
+
+ALTER TABLE reward_config
+    DROP COLUMN IF EXISTS reward_description_i18n,
+    DROP COLUMN IF EXISTS reward_name_i18n;
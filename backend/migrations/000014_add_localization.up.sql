@@ -0,0 +1,8 @@
+-- Reward names and descriptions by locale (e.g. {"th": "..."}); the plain
+-- columns keep the default locale's text
+ALTER TABLE reward_config
+    ADD COLUMN reward_name_i18n JSONB NOT NULL DEFAULT '{}',
+    ADD COLUMN reward_description_i18n JSONB NOT NULL DEFAULT '{}';
+
+-- The player's preferred locale; empty follows Accept-Language
+ALTER TABLE players ADD COLUMN locale VARCHAR(10) NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+-- Where the player signed up from, so referrals from the referrer's own
+-- network can be refused; cleared on erasure
+ALTER TABLE players ADD COLUMN signup_ip VARCHAR(45) NOT NULL DEFAULT '';
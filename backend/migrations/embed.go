@@ -0,0 +1,11 @@
+// Package migrations embeds the SQL migration files so binaries do not depend
+// on the working directory
+package migrations
+
+import "embed"
+
+// FS holds every *.up.sql and *.down.sql file in this directory, and the
+// SQLite versions of the same migrations under sqlite/
+//
+//go:embed *.sql sqlite/*.sql
+var FS embed.FS
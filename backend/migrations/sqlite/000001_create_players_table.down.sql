@@ -0,0 +1,3 @@
+-- Drop players table
+DROP TRIGGER IF EXISTS update_players_updated_at;
+DROP TABLE IF EXISTS players;
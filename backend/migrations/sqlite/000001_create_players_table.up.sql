@@ -0,0 +1,20 @@
+-- Create players table
+-- SQLite has no timestamp type: times are stored as UTC text, which sorts
+-- chronologically. Nickname uniqueness is an index so 000011 can make it partial.
+CREATE TABLE players (
+    id TEXT PRIMARY KEY,
+    nickname VARCHAR(50) NOT NULL,
+    total_points INTEGER NOT NULL DEFAULT 0 CHECK (total_points >= 0),
+    created_at DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE UNIQUE INDEX players_nickname_key ON players(nickname);
+
+-- Create trigger to update updated_at on row changes
+CREATE TRIGGER update_players_updated_at
+    AFTER UPDATE ON players
+    FOR EACH ROW
+BEGIN
+    UPDATE players SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE id = NEW.id;
+END;
@@ -0,0 +1,12 @@
+-- Create spin_logs table
+CREATE TABLE spin_logs (
+    id TEXT PRIMARY KEY,
+    player_id TEXT NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    points_gained INTEGER NOT NULL CHECK (points_gained > 0),
+    source VARCHAR(20) NOT NULL CHECK (source IN ('GAME', 'BONUS', 'ADMIN')),
+    created_at DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+-- Create indexes for performance
+CREATE INDEX idx_spin_logs_player_id ON spin_logs(player_id);
+CREATE INDEX idx_spin_logs_created_at ON spin_logs(created_at);
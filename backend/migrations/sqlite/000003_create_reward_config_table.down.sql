@@ -0,0 +1,2 @@
+-- Drop reward_config table
+DROP TABLE IF EXISTS reward_config;
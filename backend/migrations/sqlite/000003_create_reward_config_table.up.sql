@@ -0,0 +1,8 @@
+-- Create reward_config table
+CREATE TABLE reward_config (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    checkpoint_val INTEGER UNIQUE NOT NULL,
+    reward_name VARCHAR(100) NOT NULL,
+    reward_description TEXT,
+    created_at DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
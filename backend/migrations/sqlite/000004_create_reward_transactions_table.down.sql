@@ -0,0 +1,2 @@
+-- Drop reward_transactions table
+DROP TABLE IF EXISTS reward_transactions;
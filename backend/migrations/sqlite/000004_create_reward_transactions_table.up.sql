@@ -0,0 +1,8 @@
+-- Create reward_transactions table
+CREATE TABLE reward_transactions (
+    id TEXT PRIMARY KEY,
+    player_id TEXT NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    checkpoint_val INTEGER NOT NULL,
+    claimed_at DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    UNIQUE(player_id, checkpoint_val)
+);
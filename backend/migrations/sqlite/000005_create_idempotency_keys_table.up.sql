@@ -0,0 +1,17 @@
+-- Create idempotency_keys table
+-- Stores the first response for an Idempotency-Key so that retried requests are replayed
+CREATE TABLE idempotency_keys (
+    idempotency_key VARCHAR(255) NOT NULL,
+    scope VARCHAR(64) NOT NULL DEFAULT '',
+    request_hash CHAR(64) NOT NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('PROCESSING', 'COMPLETED')),
+    response_status INTEGER,
+    response_content_type VARCHAR(255),
+    response_body BLOB,
+    created_at DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    expires_at DATETIME NOT NULL,
+    PRIMARY KEY (idempotency_key, scope)
+);
+
+-- Index for purging expired keys
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_players_nickname_pattern;
+DROP INDEX IF EXISTS idx_spin_logs_points_gained;
+DROP INDEX IF EXISTS idx_spin_logs_source_created_at;
+DROP INDEX IF EXISTS idx_spin_logs_player_created_at;
+DROP INDEX IF EXISTS idx_spin_logs_created_at_id;
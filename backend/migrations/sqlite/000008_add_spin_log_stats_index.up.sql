@@ -0,0 +1,3 @@
+-- Covering index for per-player aggregates (GET /players/:id/stats); SQLite
+-- has no INCLUDE, so points_gained is a trailing key column
+CREATE INDEX IF NOT EXISTS idx_spin_logs_player_stats ON spin_logs(player_id, created_at, points_gained);
@@ -0,0 +1,7 @@
+ALTER TABLE players DROP COLUMN multiplier_expires_at;
+ALTER TABLE players DROP COLUMN points_multiplier;
+ALTER TABLE players DROP COLUMN bonus_spins;
+ALTER TABLE players DROP COLUMN streak_freezes;
+ALTER TABLE players DROP COLUMN streak_last_day;
+ALTER TABLE players DROP COLUMN longest_streak;
+ALTER TABLE players DROP COLUMN current_streak;
@@ -0,0 +1,8 @@
+-- Login streaks and the boosts streak rewards grant
+ALTER TABLE players ADD COLUMN current_streak INTEGER NOT NULL DEFAULT 0 CHECK (current_streak >= 0);
+ALTER TABLE players ADD COLUMN longest_streak INTEGER NOT NULL DEFAULT 0 CHECK (longest_streak >= 0);
+ALTER TABLE players ADD COLUMN streak_last_day DATE;
+ALTER TABLE players ADD COLUMN streak_freezes INTEGER NOT NULL DEFAULT 0 CHECK (streak_freezes >= 0);
+ALTER TABLE players ADD COLUMN bonus_spins INTEGER NOT NULL DEFAULT 0 CHECK (bonus_spins >= 0);
+ALTER TABLE players ADD COLUMN points_multiplier NUMERIC(6,2) NOT NULL DEFAULT 1 CHECK (points_multiplier >= 1);
+ALTER TABLE players ADD COLUMN multiplier_expires_at DATETIME;
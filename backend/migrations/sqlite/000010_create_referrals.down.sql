@@ -0,0 +1,5 @@
+-- Drop referrals table and invite codes
+DROP TABLE IF EXISTS referrals;
+DROP TRIGGER IF EXISTS players_default_invite_code;
+DROP INDEX IF EXISTS idx_players_invite_code;
+ALTER TABLE players DROP COLUMN invite_code;
@@ -0,0 +1,34 @@
+-- Invite codes: existing players get a random code, and so do rows inserted
+-- without one (e.g. seed.sh). SQLite cannot add a column with a random
+-- default, so a trigger fills it in instead.
+ALTER TABLE players ADD COLUMN invite_code VARCHAR(8) NOT NULL DEFAULT '';
+UPDATE players SET invite_code = hex(randomblob(4));
+
+CREATE UNIQUE INDEX idx_players_invite_code ON players(invite_code);
+
+CREATE TRIGGER players_default_invite_code
+    AFTER INSERT ON players
+    FOR EACH ROW WHEN NEW.invite_code = ''
+BEGIN
+    UPDATE players SET invite_code = hex(randomblob(4)) WHERE id = NEW.id;
+END;
+
+-- Create referrals table (one row per referred player)
+CREATE TABLE referrals (
+    id TEXT PRIMARY KEY,
+    referrer_id TEXT NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    referee_id TEXT NOT NULL UNIQUE REFERENCES players(id) ON DELETE CASCADE,
+    invite_code VARCHAR(8) NOT NULL,
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    status VARCHAR(10) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'rewarded')),
+    referee_spins INTEGER NOT NULL DEFAULT 0 CHECK (referee_spins >= 0),
+    referee_points INTEGER NOT NULL DEFAULT 0 CHECK (referee_points >= 0),
+    reward_points INTEGER NOT NULL DEFAULT 0 CHECK (reward_points >= 0),
+    created_at DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    rewarded_at DATETIME,
+    CHECK (referrer_id <> referee_id)
+);
+
+-- Referral summaries and the per-referrer and per-IP daily caps
+CREATE INDEX idx_referrals_referrer_created ON referrals(referrer_id, created_at DESC);
+CREATE INDEX idx_referrals_ip_created ON referrals(ip_address, created_at);
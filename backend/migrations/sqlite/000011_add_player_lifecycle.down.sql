@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS idx_players_deleted;
+DROP INDEX IF EXISTS idx_players_nickname_unreleased;
+
+-- Released nicknames may repeat; give them a unique placeholder
+UPDATE players SET nickname = 'released-' || id WHERE nickname_released_at IS NOT NULL;
+CREATE UNIQUE INDEX players_nickname_key ON players(nickname);
+
+-- suspended_until's check reads status, so it goes first
+ALTER TABLE players DROP COLUMN nickname_released_at;
+ALTER TABLE players DROP COLUMN nickname_changed_at;
+ALTER TABLE players DROP COLUMN status_changed_at;
+ALTER TABLE players DROP COLUMN status_reason;
+ALTER TABLE players DROP COLUMN suspended_until;
+ALTER TABLE players DROP COLUMN status;
@@ -0,0 +1,57 @@
+DROP TABLE IF EXISTS compliance_log;
+
+-- Rebuild the child tables with their cascading foreign keys
+CREATE TABLE spin_logs_new (
+    id TEXT PRIMARY KEY,
+    player_id TEXT NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    points_gained INTEGER NOT NULL CHECK (points_gained > 0),
+    source VARCHAR(20) NOT NULL CHECK (source IN ('GAME', 'BONUS', 'ADMIN')),
+    created_at DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+INSERT INTO spin_logs_new (id, player_id, points_gained, source, created_at)
+    SELECT id, player_id, points_gained, source, created_at FROM spin_logs;
+DROP TABLE spin_logs;
+ALTER TABLE spin_logs_new RENAME TO spin_logs;
+
+CREATE INDEX idx_spin_logs_player_id ON spin_logs(player_id);
+CREATE INDEX idx_spin_logs_created_at ON spin_logs(created_at);
+CREATE INDEX idx_spin_logs_created_at_id ON spin_logs(created_at DESC, id DESC);
+CREATE INDEX idx_spin_logs_player_created_at ON spin_logs(player_id, created_at DESC, id DESC);
+CREATE INDEX idx_spin_logs_source_created_at ON spin_logs(source, created_at DESC, id DESC);
+CREATE INDEX idx_spin_logs_points_gained ON spin_logs(points_gained);
+CREATE INDEX idx_spin_logs_player_stats ON spin_logs(player_id, created_at, points_gained);
+
+CREATE TABLE reward_transactions_new (
+    id TEXT PRIMARY KEY,
+    player_id TEXT NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    checkpoint_val INTEGER NOT NULL,
+    claimed_at DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    UNIQUE(player_id, checkpoint_val)
+);
+INSERT INTO reward_transactions_new (id, player_id, checkpoint_val, claimed_at)
+    SELECT id, player_id, checkpoint_val, claimed_at FROM reward_transactions;
+DROP TABLE reward_transactions;
+ALTER TABLE reward_transactions_new RENAME TO reward_transactions;
+
+CREATE TABLE referrals_new (
+    id TEXT PRIMARY KEY,
+    referrer_id TEXT NOT NULL REFERENCES players(id) ON DELETE CASCADE,
+    referee_id TEXT NOT NULL UNIQUE REFERENCES players(id) ON DELETE CASCADE,
+    invite_code VARCHAR(8) NOT NULL,
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    status VARCHAR(10) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'rewarded')),
+    referee_spins INTEGER NOT NULL DEFAULT 0 CHECK (referee_spins >= 0),
+    referee_points INTEGER NOT NULL DEFAULT 0 CHECK (referee_points >= 0),
+    reward_points INTEGER NOT NULL DEFAULT 0 CHECK (reward_points >= 0),
+    created_at DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    rewarded_at DATETIME,
+    CHECK (referrer_id <> referee_id)
+);
+INSERT INTO referrals_new SELECT * FROM referrals;
+DROP TABLE referrals;
+ALTER TABLE referrals_new RENAME TO referrals;
+
+CREATE INDEX idx_referrals_referrer_created ON referrals(referrer_id, created_at DESC);
+CREATE INDEX idx_referrals_ip_created ON referrals(ip_address, created_at);
+
+ALTER TABLE players DROP COLUMN erased_at;
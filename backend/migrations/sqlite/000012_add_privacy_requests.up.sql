@@ -0,0 +1,71 @@
+-- Erased players keep their row (pseudonymised) so totals and claims stay intact
+ALTER TABLE players ADD COLUMN erased_at DATETIME;
+
+-- Accounting history must survive player deletion: refuse to delete a player
+-- that still has spins, claims or referrals instead of cascading. SQLite
+-- cannot alter a foreign key, so each child table is rebuilt.
+CREATE TABLE spin_logs_new (
+    id TEXT PRIMARY KEY,
+    player_id TEXT NOT NULL REFERENCES players(id) ON DELETE RESTRICT,
+    points_gained INTEGER NOT NULL CHECK (points_gained > 0),
+    source VARCHAR(20) NOT NULL CHECK (source IN ('GAME', 'BONUS', 'ADMIN')),
+    created_at DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+INSERT INTO spin_logs_new (id, player_id, points_gained, source, created_at)
+    SELECT id, player_id, points_gained, source, created_at FROM spin_logs;
+DROP TABLE spin_logs;
+ALTER TABLE spin_logs_new RENAME TO spin_logs;
+
+CREATE INDEX idx_spin_logs_player_id ON spin_logs(player_id);
+CREATE INDEX idx_spin_logs_created_at ON spin_logs(created_at);
+CREATE INDEX idx_spin_logs_created_at_id ON spin_logs(created_at DESC, id DESC);
+CREATE INDEX idx_spin_logs_player_created_at ON spin_logs(player_id, created_at DESC, id DESC);
+CREATE INDEX idx_spin_logs_source_created_at ON spin_logs(source, created_at DESC, id DESC);
+CREATE INDEX idx_spin_logs_points_gained ON spin_logs(points_gained);
+CREATE INDEX idx_spin_logs_player_stats ON spin_logs(player_id, created_at, points_gained);
+
+CREATE TABLE reward_transactions_new (
+    id TEXT PRIMARY KEY,
+    player_id TEXT NOT NULL REFERENCES players(id) ON DELETE RESTRICT,
+    checkpoint_val INTEGER NOT NULL,
+    claimed_at DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    UNIQUE(player_id, checkpoint_val)
+);
+INSERT INTO reward_transactions_new (id, player_id, checkpoint_val, claimed_at)
+    SELECT id, player_id, checkpoint_val, claimed_at FROM reward_transactions;
+DROP TABLE reward_transactions;
+ALTER TABLE reward_transactions_new RENAME TO reward_transactions;
+
+CREATE TABLE referrals_new (
+    id TEXT PRIMARY KEY,
+    referrer_id TEXT NOT NULL REFERENCES players(id) ON DELETE RESTRICT,
+    referee_id TEXT NOT NULL UNIQUE REFERENCES players(id) ON DELETE RESTRICT,
+    invite_code VARCHAR(8) NOT NULL,
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    status VARCHAR(10) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'rewarded')),
+    referee_spins INTEGER NOT NULL DEFAULT 0 CHECK (referee_spins >= 0),
+    referee_points INTEGER NOT NULL DEFAULT 0 CHECK (referee_points >= 0),
+    reward_points INTEGER NOT NULL DEFAULT 0 CHECK (reward_points >= 0),
+    created_at DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    rewarded_at DATETIME,
+    CHECK (referrer_id <> referee_id)
+);
+INSERT INTO referrals_new SELECT * FROM referrals;
+DROP TABLE referrals;
+ALTER TABLE referrals_new RENAME TO referrals;
+
+CREATE INDEX idx_referrals_referrer_created ON referrals(referrer_id, created_at DESC);
+CREATE INDEX idx_referrals_ip_created ON referrals(ip_address, created_at);
+
+-- Create compliance_log table (one row per data export or erasure request).
+-- No foreign key: entries must outlive anything that happens to the player.
+CREATE TABLE compliance_log (
+    id TEXT PRIMARY KEY,
+    player_id TEXT NOT NULL,
+    request_type VARCHAR(20) NOT NULL CHECK (request_type IN ('export', 'erasure')),
+    requested_by VARCHAR(20) NOT NULL CHECK (requested_by IN ('player', 'admin')),
+    reason TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE INDEX idx_compliance_log_player_created ON compliance_log(player_id, created_at);
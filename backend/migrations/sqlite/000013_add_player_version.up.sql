@@ -0,0 +1,2 @@
+-- Optimistic concurrency: every player update must match the version it read
+ALTER TABLE players ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,4 @@
+ALTER TABLE players DROP COLUMN locale;
+
+ALTER TABLE reward_config DROP COLUMN reward_description_i18n;
+ALTER TABLE reward_config DROP COLUMN reward_name_i18n;
@@ -29,7 +29,7 @@ export function useNicknameForm() {
       router.push('/home')
     },
     onError: (error: any) => {
-      const message = error.response?.data?.error || AUTH_ERRORS.default
+      const message = error.response?.data?.error?.message || AUTH_ERRORS.default
       setServerError(message)
     },
   })
@@ -69,7 +69,7 @@ export function useGamePage(options: UseGamePageOptions = {}) {
       setIsLoadingResult(false)
       setPendingTotalPoints(null)
       setErrorMessage(
-        error?.response?.data?.message || 'เกิดข้อผิดพลาด กรุณาลองใหม่'
+        error?.response?.data?.error?.message || 'เกิดข้อผิดพลาด กรุณาลองใหม่'
       )
     }
   }, [player, spinWheel, isLoadingResult])
@@ -5,7 +5,8 @@ import { getAPIConfig } from '@/config'
 const apiConfig = getAPIConfig()
 
 const api = axios.create({
-  baseURL: apiConfig.url,
+  // Version 1 of the API; the unversioned paths are deprecated aliases
+  baseURL: `${apiConfig.url}/v1`,
   timeout: apiConfig.timeout,
   headers: {
     'Content-Type': 'application/json',
@@ -1,14 +1,14 @@
 import api from '@/lib/axios'
-import { EnterRequest, EnterResponse, ProfileResponse } from '@/types/api'
+import { ApiResponse, EnterRequest, EnterResponse, ProfileResponse } from '@/types/api'
 
 export const authService = {
   enter: async (nickname: string) => {
-    const response = await api.post<EnterResponse>('/players/enter', { nickname })
-    return response.data
+    const response = await api.post<ApiResponse<EnterResponse>>('/players/enter', { nickname })
+    return response.data.data
   },
 
   getProfile: async (id: string) => {
-    const response = await api.get<ProfileResponse>(`/players/${id}`)
-    return response.data
+    const response = await api.get<ApiResponse<ProfileResponse>>(`/players/${id}`)
+    return response.data.data
   }
 }
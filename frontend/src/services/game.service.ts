@@ -1,9 +1,9 @@
 import api from '@/lib/axios'
-import { SpinRequest, SpinResponse } from '@/types/api'
+import { ApiResponse, SpinRequest, SpinResponse } from '@/types/api'
 
 export const gameService = {
   spin: async (request: SpinRequest) => {
-    const response = await api.post<SpinResponse>('/game/spin', request)
-    return response.data
+    const response = await api.post<ApiResponse<SpinResponse>>('/game/spin', request)
+    return response.data.data
   },
 }
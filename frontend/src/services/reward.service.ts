@@ -1,16 +1,16 @@
 import api from '@/lib/axios'
-import { RewardHistoryResponse, ClaimResponse } from '@/types/api'
+import { ApiResponse, RewardHistoryResponse, ClaimResponse } from '@/types/api'
 
 export const rewardService = {
   getHistory: async (playerId: string) => {
-    const response = await api.get<RewardHistoryResponse>(`/rewards/${playerId}`)
-    return response.data
+    const response = await api.get<ApiResponse<RewardHistoryResponse>>(`/rewards/${playerId}`)
+    return response.data.data
   },
   claimCheckpoint: async (playerId: string, checkpointVal: number) => {
-    const response = await api.post<ClaimResponse>('/rewards/claim', {
+    const response = await api.post<ApiResponse<ClaimResponse>>('/rewards/claim', {
       player_id: playerId,
       checkpoint_val: checkpointVal,
     })
-    return response.data
+    return response.data.data
   },
 }
@@ -1,7 +1,12 @@
-// Generic API Response
+// Envelope every JSON endpoint responds with
 export interface ApiResponse<T> {
+  success: boolean
   data: T
-  message?: string
+  error?: {
+    code: string
+    message: string
+    details?: string
+  }
 }
 
 // Player / Auth
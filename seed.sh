@@ -35,7 +35,7 @@ SELECT DISTINCT ON (nickname)
        created_at,
        NOW()
 FROM players_tmp
-ON CONFLICT (nickname) DO NOTHING; 
+ON CONFLICT (nickname) WHERE nickname_released_at IS NULL DO NOTHING; 
 -- ถ้ามีชื่ออยู่แล้ว ไม่ต้องทำอะไร (DO NOTHING) ข้ามไปขั้นตอนบวกเลขเลย
 
 -- 3. บันทึกประวัติการเล่นทั้งหมด (Insert Spin Logs)
@@ -54,7 +54,7 @@ SELECT
     'GAME',               -- Source: MOCK
     t.created_at
 FROM players_tmp t
-JOIN players p ON t.nickname = p.nickname;
+JOIN players p ON t.nickname = p.nickname AND p.nickname_released_at IS NULL;
 -- ตรงนี้ไม่มี DISTINCT แล้ว! CSV มีกี่แถว ยัดลง Log หมดเลย
 
 -- 4. อัปเดตยอดเงินรวมของ Players (Sum Points)
@@ -70,7 +70,7 @@ FROM (
     FROM players_tmp
     GROUP BY nickname
 ) sub
-WHERE p.nickname = sub.nickname;
+WHERE p.nickname = sub.nickname AND p.nickname_released_at IS NULL;
 
 \echo '🧹 Cleaning up...'
 DROP TABLE players_tmp;